@@ -16,57 +16,206 @@ limitations under the License.
 
 package buffer
 
-// RingGrowing is a growing ring buffer.
+// TypedRingGrowing is a growing ring buffer.
 // Not thread safe.
-type RingGrowing struct {
-	data     []interface{}
+type TypedRingGrowing[T any] struct {
+	data     []T
 	n        int // Size of Data
 	beg      int // First available element
 	readable int // Number of data items available
+	dropped  int64
+	opts     RingGrowingOptions
 }
 
-// NewRingGrowing constructs a new RingGrowing instance with provided parameters.
-func NewRingGrowing(initialSize int) *RingGrowing {
-	return &RingGrowing{
-		data: make([]interface{}, initialSize),
-		n:    initialSize,
+// RingGrowingOptions sets parameters for [RingGrowing] and
+// [TypedRingGrowing].
+type RingGrowingOptions struct {
+	// InitialSize is the number of pre-allocated elements in the
+	// initial underlying storage buffer.
+	InitialSize int
+	// MaxSize, if positive, is the maximum number of elements in the
+	// underlying storage buffer. Once the buffer holds MaxSize elements,
+	// writing another one discards the oldest element to make room.
+	MaxSize int
+	// OnDrop, if set, is called whenever an element is discarded because
+	// the buffer is full at MaxSize. For the synchronized and blocking
+	// buffers it is called with the buffer locked, so it must not call
+	// back into the buffer.
+	OnDrop func()
+	// Shrink enables releasing memory after a burst: whenever reading
+	// leaves the buffer no more than a quarter full, its capacity is
+	// halved, though never below InitialSize.
+	Shrink bool
+}
+
+// NewTypedRingGrowing constructs a new TypedRingGrowing instance with provided parameters.
+func NewTypedRingGrowing[T any](opts RingGrowingOptions) *TypedRingGrowing[T] {
+	if opts.MaxSize > 0 && opts.InitialSize > opts.MaxSize {
+		opts.InitialSize = opts.MaxSize
+	}
+	return &TypedRingGrowing[T]{
+		data: make([]T, opts.InitialSize),
+		n:    opts.InitialSize,
+		opts: opts,
+	}
+}
+
+// resize moves the elements of the buffer to a new underlying storage buffer of size newN, which
+// must be at least r.readable.
+func (r *TypedRingGrowing[T]) resize(newN int) {
+	newData := make([]T, newN)
+	to := r.beg + r.readable
+	if to <= r.n {
+		copy(newData, r.data[r.beg:to])
+	} else {
+		copied := copy(newData, r.data[r.beg:])
+		copy(newData[copied:], r.data[:(to%r.n)])
+	}
+	r.beg = 0
+	r.data = newData
+	r.n = newN
+}
+
+// maybeShrink halves the capacity of the buffer if shrinking is enabled and the buffer is no
+// more than a quarter full. Shrinking at a quarter rather than half full avoids repeatedly
+// growing and shrinking when the number of elements hovers around a power of two.
+func (r *TypedRingGrowing[T]) maybeShrink() {
+	if !r.opts.Shrink {
+		return
+	}
+	for r.n > r.opts.InitialSize && r.n > 1 && r.readable <= r.n/4 {
+		newN := r.n / 2
+		if newN < r.opts.InitialSize {
+			newN = r.opts.InitialSize
+		}
+		r.resize(newN)
 	}
 }
 
 // ReadOne reads (consumes) first item from the buffer if it is available, otherwise returns false.
-func (r *RingGrowing) ReadOne() (data interface{}, ok bool) {
+func (r *TypedRingGrowing[T]) ReadOne() (data T, ok bool) {
 	if r.readable == 0 {
-		return nil, false
+		return
 	}
 	r.readable--
 	element := r.data[r.beg]
-	r.data[r.beg] = nil // Remove reference to the object to help GC
+	var zero T
+	r.data[r.beg] = zero // Remove reference to the object to help GC
 	if r.beg == r.n-1 {
 		// Was the last element
 		r.beg = 0
 	} else {
 		r.beg++
 	}
+	r.maybeShrink()
 	return element, true
 }
 
-// WriteOne adds an item to the end of the buffer, growing it if it is full.
-func (r *RingGrowing) WriteOne(data interface{}) {
+// Peek returns the first item in the buffer without consuming it if it is available, otherwise
+// returns false.
+func (r *TypedRingGrowing[T]) Peek() (data T, ok bool) {
+	if r.readable == 0 {
+		return
+	}
+	return r.data[r.beg], true
+}
+
+// ReadN reads (consumes) up to n items from the beginning of the buffer and returns them in
+// order. It returns nil if the buffer is empty or n is not positive.
+func (r *TypedRingGrowing[T]) ReadN(n int) []T {
+	if n > r.readable {
+		n = r.readable
+	}
+	if n <= 0 {
+		return nil
+	}
+	out := make([]T, n)
+	var zero T
+	copied := 0
+	for copied < n {
+		// Copy up to the end of the underlying array, then wrap around
+		end := r.beg + n - copied
+		if end > r.n {
+			end = r.n
+		}
+		c := copy(out[copied:], r.data[r.beg:end])
+		for i := r.beg; i < end; i++ {
+			r.data[i] = zero // Remove reference to the object to help GC
+		}
+		copied += c
+		r.beg = end % r.n
+	}
+	r.readable -= n
+	r.maybeShrink()
+	return out
+}
+
+// Drain reads (consumes) all items in the buffer and returns them in order. It returns nil if
+// the buffer is empty.
+func (r *TypedRingGrowing[T]) Drain() []T {
+	return r.ReadN(r.readable)
+}
+
+// WriteOne adds an item to the end of the buffer, growing it if it is full. If the buffer is full
+// at its maximum size, the oldest item is discarded instead.
+func (r *TypedRingGrowing[T]) WriteOne(data T) {
+	if r.readable == r.n && r.opts.MaxSize > 0 && r.n >= r.opts.MaxSize {
+		// Full at the maximum size, overwrite the oldest element
+		r.data[r.beg] = data
+		r.beg = (r.beg + 1) % r.n
+		r.dropped++
+		if r.opts.OnDrop != nil {
+			r.opts.OnDrop()
+		}
+		return
+	}
 	if r.readable == r.n {
 		// Time to grow
 		newN := r.n * 2
-		newData := make([]interface{}, newN)
-		to := r.beg + r.readable
-		if to <= r.n {
-			copy(newData, r.data[r.beg:to])
-		} else {
-			copied := copy(newData, r.data[r.beg:])
-			copy(newData[copied:], r.data[:(to%r.n)])
+		if newN == 0 {
+			newN = 1
 		}
-		r.beg = 0
-		r.data = newData
-		r.n = newN
+		if r.opts.MaxSize > 0 && newN > r.opts.MaxSize {
+			newN = r.opts.MaxSize
+		}
+		r.resize(newN)
 	}
 	r.data[(r.readable+r.beg)%r.n] = data
 	r.readable++
 }
+
+// Clone returns an independent copy of the buffer, which shares no storage with the original.
+func (r *TypedRingGrowing[T]) Clone() *TypedRingGrowing[T] {
+	c := *r
+	c.data = make([]T, r.n)
+	copy(c.data, r.data)
+	return &c
+}
+
+// Len returns the number of items in the buffer.
+func (r *TypedRingGrowing[T]) Len() int {
+	return r.readable
+}
+
+// Cap returns the capacity of the buffer.
+func (r *TypedRingGrowing[T]) Cap() int {
+	return r.n
+}
+
+// Dropped returns the number of items discarded because the buffer was full at its maximum size.
+func (r *TypedRingGrowing[T]) Dropped() int64 {
+	return r.dropped
+}
+
+// RingGrowing is a growing ring buffer.
+// Not thread safe.
+//
+// Deprecated: Use TypedRingGrowing[any] instead.
+type RingGrowing = TypedRingGrowing[any]
+
+// NewRingGrowing constructs a new RingGrowing instance with provided parameters.
+//
+// Deprecated: Use NewTypedRingGrowing[any] instead.
+func NewRingGrowing(initialSize int) *RingGrowing {
+	return NewTypedRingGrowing[any](RingGrowingOptions{InitialSize: initialSize})
+}
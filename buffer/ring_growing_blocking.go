@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buffer
+
+import (
+	"context"
+	"sync"
+)
+
+// TypedBlockingRingGrowing is a growing ring buffer that is safe for
+// concurrent use and whose ReadOne waits for an item to become available.
+// Since writes never block, it can be used as an unbounded channel, for
+// example to fan in events from many producers.
+type TypedBlockingRingGrowing[T any] struct {
+	lock sync.Mutex
+	cond sync.Cond
+	ring TypedRingGrowing[T]
+}
+
+// NewTypedBlockingRingGrowing constructs a new TypedBlockingRingGrowing
+// instance with provided parameters.
+func NewTypedBlockingRingGrowing[T any](opts RingGrowingOptions) *TypedBlockingRingGrowing[T] {
+	r := &TypedBlockingRingGrowing[T]{
+		ring: *NewTypedRingGrowing[T](opts),
+	}
+	r.cond.L = &r.lock
+	return r
+}
+
+// ReadOne reads (consumes) first item from the buffer, waiting for one to be
+// written if the buffer is empty. It returns ctx.Err() if ctx is done before
+// an item is available.
+func (r *TypedBlockingRingGrowing[T]) ReadOne(ctx context.Context) (data T, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.ring.Len() == 0 && ctx.Done() != nil {
+		// Wake up the waiters below when ctx is done, since a condition
+		// variable can't wait on a channel.
+		stop := make(chan struct{})
+		defer close(stop)
+		go func() {
+			select {
+			case <-ctx.Done():
+				r.lock.Lock()
+				r.cond.Broadcast()
+				r.lock.Unlock()
+			case <-stop:
+			}
+		}()
+	}
+	for r.ring.Len() == 0 {
+		if err := ctx.Err(); err != nil {
+			return data, err
+		}
+		r.cond.Wait()
+	}
+	data, _ = r.ring.ReadOne()
+	return data, nil
+}
+
+// WriteOne adds an item to the end of the buffer, growing it if it is full,
+// and wakes up a waiting reader. If the buffer is full at its maximum size,
+// the oldest item is discarded instead.
+func (r *TypedBlockingRingGrowing[T]) WriteOne(data T) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.ring.WriteOne(data)
+	r.cond.Signal()
+}
+
+// Len returns the number of items in the buffer.
+func (r *TypedBlockingRingGrowing[T]) Len() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.ring.Len()
+}
+
+// Dropped returns the number of items discarded because the buffer was full at its maximum size.
+func (r *TypedBlockingRingGrowing[T]) Dropped() int64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.ring.Dropped()
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buffer
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testTimeout = 30 * time.Second
+
+func TestBlockingReadWaitsForWrite(t *testing.T) {
+	t.Parallel()
+	g := NewTypedBlockingRingGrowing[int](RingGrowingOptions{InitialSize: 1})
+
+	result := make(chan int)
+	go func() {
+		v, err := g.ReadOne(context.Background())
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		result <- v
+	}()
+
+	select {
+	case v := <-result:
+		t.Fatalf("expected ReadOne to block on an empty buffer, got %d", v)
+	case <-time.After(50 * time.Millisecond):
+	}
+	g.WriteOne(42)
+	select {
+	case v := <-result:
+		if v != 42 {
+			t.Fatalf("expected 42, got %d", v)
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("timed out waiting for ReadOne")
+	}
+}
+
+func TestBlockingReadAvailable(t *testing.T) {
+	t.Parallel()
+	g := NewTypedBlockingRingGrowing[string](RingGrowingOptions{})
+	g.WriteOne("a")
+	g.WriteOne("b")
+	if g.Len() != 2 {
+		t.Fatalf("expected Len to be 2: %d", g.Len())
+	}
+	// A cancelled context doesn't prevent reading items that are available.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, e := range []string{"a", "b"} {
+		v, err := g.ReadOne(ctx)
+		if err != nil || v != e {
+			t.Fatalf("expected %q, nil; got %q, %v", e, v, err)
+		}
+	}
+	if _, err := g.ReadOne(ctx); err != context.Canceled {
+		t.Fatalf("expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestBlockingReadCancel(t *testing.T) {
+	t.Parallel()
+	g := NewTypedBlockingRingGrowing[int](RingGrowingOptions{InitialSize: 1})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	errCh := make(chan error)
+	go func() {
+		_, err := g.ReadOne(ctx)
+		errCh <- err
+	}()
+	select {
+	case err := <-errCh:
+		if err != context.DeadlineExceeded {
+			t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("timed out waiting for ReadOne to return")
+	}
+
+	// The buffer is still usable afterwards.
+	g.WriteOne(1)
+	if v, err := g.ReadOne(context.Background()); err != nil || v != 1 {
+		t.Fatalf("expected 1, nil; got %d, %v", v, err)
+	}
+}
+
+func TestBlockingFanIn(t *testing.T) {
+	t.Parallel()
+	const producers = 4
+	const items = 1000
+	g := NewTypedBlockingRingGrowing[int](RingGrowingOptions{InitialSize: 1})
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < items; i++ {
+				g.WriteOne(p*items + i)
+			}
+		}(p)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	seen := make([]bool, producers*items)
+	for read := 0; read < producers*items; read++ {
+		v, err := g.ReadOne(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error after reading %d items: %v", read, err)
+		}
+		if seen[v] {
+			t.Fatalf("read %d twice", v)
+		}
+		seen[v] = true
+	}
+	wg.Wait()
+	if g.Len() != 0 {
+		t.Fatalf("expected Len to be zero: %d", g.Len())
+	}
+}
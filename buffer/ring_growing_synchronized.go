@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buffer
+
+import (
+	"sync"
+)
+
+// TypedSynchronizedRingGrowing is a growing ring buffer that is safe for
+// concurrent use, for example by a producer and a consumer goroutine.
+type TypedSynchronizedRingGrowing[T any] struct {
+	lock sync.Mutex
+	ring TypedRingGrowing[T]
+}
+
+// NewTypedSynchronizedRingGrowing constructs a new TypedSynchronizedRingGrowing
+// instance with provided parameters.
+func NewTypedSynchronizedRingGrowing[T any](opts RingGrowingOptions) *TypedSynchronizedRingGrowing[T] {
+	return &TypedSynchronizedRingGrowing[T]{
+		ring: *NewTypedRingGrowing[T](opts),
+	}
+}
+
+// ReadOne reads (consumes) first item from the buffer if it is available, otherwise returns false.
+func (r *TypedSynchronizedRingGrowing[T]) ReadOne() (data T, ok bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.ring.ReadOne()
+}
+
+// Peek returns the first item in the buffer without consuming it if it is available, otherwise
+// returns false.
+func (r *TypedSynchronizedRingGrowing[T]) Peek() (data T, ok bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.ring.Peek()
+}
+
+// ReadN reads (consumes) up to n items from the beginning of the buffer and returns them in
+// order. It returns nil if the buffer is empty or n is not positive. Reading items in batches
+// takes the lock once per batch instead of once per item.
+func (r *TypedSynchronizedRingGrowing[T]) ReadN(n int) []T {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.ring.ReadN(n)
+}
+
+// Drain reads (consumes) all items in the buffer and returns them in order. It returns nil if
+// the buffer is empty.
+func (r *TypedSynchronizedRingGrowing[T]) Drain() []T {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.ring.Drain()
+}
+
+// WriteOne adds an item to the end of the buffer, growing it if it is full. If the buffer is full
+// at its maximum size, the oldest item is discarded instead.
+func (r *TypedSynchronizedRingGrowing[T]) WriteOne(data T) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.ring.WriteOne(data)
+}
+
+// Clone returns an independent copy of the buffer, which shares no storage with the original.
+// This allows taking a consistent snapshot of the contents while writers continue to use the
+// original.
+func (r *TypedSynchronizedRingGrowing[T]) Clone() *TypedSynchronizedRingGrowing[T] {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return &TypedSynchronizedRingGrowing[T]{ring: *r.ring.Clone()}
+}
+
+// Len returns the number of items in the buffer.
+func (r *TypedSynchronizedRingGrowing[T]) Len() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.ring.Len()
+}
+
+// Cap returns the capacity of the buffer.
+func (r *TypedSynchronizedRingGrowing[T]) Cap() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.ring.Cap()
+}
+
+// Dropped returns the number of items discarded because the buffer was full at its maximum size.
+func (r *TypedSynchronizedRingGrowing[T]) Dropped() int64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.ring.Dropped()
+}
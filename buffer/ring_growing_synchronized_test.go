@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buffer
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestSynchronizedConcurrentUse(t *testing.T) {
+	t.Parallel()
+	const producers = 4
+	const items = 1000
+	g := NewTypedSynchronizedRingGrowing[int](RingGrowingOptions{InitialSize: 1})
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < items; i++ {
+				g.WriteOne(p*items + i)
+			}
+		}(p)
+	}
+
+	seen := make([]bool, producers*items)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for read := 0; read < producers*items; {
+			v, ok := g.ReadOne()
+			if !ok {
+				continue
+			}
+			if seen[v] {
+				t.Errorf("read %d twice", v)
+			}
+			seen[v] = true
+			read++
+		}
+	}()
+	wg.Wait()
+	<-done
+
+	for v, ok := range seen {
+		if !ok {
+			t.Fatalf("expected to have read %d", v)
+		}
+	}
+	if g.Len() != 0 {
+		t.Fatalf("expected Len to be zero: %d", g.Len())
+	}
+	if g.Cap() < 1 {
+		t.Fatalf("expected Cap to be positive: %d", g.Cap())
+	}
+}
+
+func TestSynchronizedBatchRead(t *testing.T) {
+	t.Parallel()
+	g := NewTypedSynchronizedRingGrowing[int](RingGrowingOptions{InitialSize: 1})
+	for i := 0; i < 5; i++ {
+		g.WriteOne(i)
+	}
+	if v, ok := g.Peek(); !ok || v != 0 {
+		t.Fatalf("expected 0, true; got %d, %v", v, ok)
+	}
+	if got, e := g.ReadN(2), []int{0, 1}; !reflect.DeepEqual(got, e) {
+		t.Fatalf("expected %v, got %v", e, got)
+	}
+	if got, e := g.Drain(), []int{2, 3, 4}; !reflect.DeepEqual(got, e) {
+		t.Fatalf("expected %v, got %v", e, got)
+	}
+}
+
+func TestSynchronizedDropped(t *testing.T) {
+	t.Parallel()
+	g := NewTypedSynchronizedRingGrowing[int](RingGrowingOptions{MaxSize: 2})
+	for i := 0; i < 5; i++ {
+		g.WriteOne(i)
+	}
+	if g.Dropped() != 3 {
+		t.Fatalf("expected Dropped to be 3: %d", g.Dropped())
+	}
+	if got, e := g.Drain(), []int{3, 4}; !reflect.DeepEqual(got, e) {
+		t.Fatalf("expected %v, got %v", e, got)
+	}
+}
+
+func TestSynchronizedClone(t *testing.T) {
+	t.Parallel()
+	g := NewTypedSynchronizedRingGrowing[int](RingGrowingOptions{InitialSize: 1})
+	for i := 0; i < 3; i++ {
+		g.WriteOne(i)
+	}
+	c := g.Clone()
+	g.WriteOne(3)
+	if got, e := c.Drain(), []int{0, 1, 2}; !reflect.DeepEqual(got, e) {
+		t.Fatalf("expected clone to contain %v, got %v", e, got)
+	}
+	if got, e := g.Drain(), []int{0, 1, 2, 3}; !reflect.DeepEqual(got, e) {
+		t.Fatalf("expected original to contain %v, got %v", e, got)
+	}
+}
@@ -61,3 +61,317 @@ func TestEmpty(t *testing.T) {
 		t.Fatal("expected false")
 	}
 }
+
+func TestGrowFromZero(t *testing.T) {
+	t.Parallel()
+	g := NewTypedRingGrowing[int](RingGrowingOptions{})
+	for i := 0; i < 3; i++ {
+		g.WriteOne(i)
+	}
+	if g.Len() != 3 {
+		t.Fatalf("expected Len to be 3: %d", g.Len())
+	}
+	if g.Cap() != 4 {
+		t.Fatalf("expected Cap to be 4: %d", g.Cap())
+	}
+	for i := 0; i < 3; i++ {
+		v, ok := g.ReadOne()
+		if !ok || v != i {
+			t.Fatalf("expected %d, true; got %d, %v", i, v, ok)
+		}
+	}
+}
+
+type ringTestItem struct {
+	key   string
+	value int
+}
+
+func TestTypedGrowth(t *testing.T) {
+	t.Parallel()
+	x := 10
+	g := NewTypedRingGrowing[ringTestItem](RingGrowingOptions{InitialSize: 1})
+	for i := 0; i < x; i++ {
+		if e, a := i, g.Len(); e != a {
+			t.Fatalf("expected equal, got %#v, %#v", e, a)
+		}
+		g.WriteOne(ringTestItem{key: "item", value: i})
+	}
+	read := 0
+	for g.Len() > 0 {
+		v, ok := g.ReadOne()
+		if !ok {
+			t.Fatal("expected true")
+		}
+		if e := (ringTestItem{key: "item", value: read}); e != v {
+			t.Fatalf("expected %#v==%#v", e, v)
+		}
+		read++
+	}
+	if x != read {
+		t.Fatalf("expected to have read %d items: %d", x, read)
+	}
+	if g.Cap() != 16 {
+		t.Fatalf("expected Cap to be 16: %d", g.Cap())
+	}
+	if _, ok := g.ReadOne(); ok {
+		t.Fatal("expected false")
+	}
+}
+
+func TestTypedNoAllocations(t *testing.T) {
+	g := NewTypedRingGrowing[ringTestItem](RingGrowingOptions{InitialSize: 16})
+	allocs := testing.AllocsPerRun(100, func() {
+		for i := 0; i < 10; i++ {
+			g.WriteOne(ringTestItem{key: "item", value: i})
+		}
+		for i := 0; i < 10; i++ {
+			g.ReadOne()
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("expected no allocations, got %v", allocs)
+	}
+}
+
+func TestPeek(t *testing.T) {
+	t.Parallel()
+	g := NewTypedRingGrowing[int](RingGrowingOptions{InitialSize: 2})
+	if _, ok := g.Peek(); ok {
+		t.Fatal("expected false")
+	}
+	g.WriteOne(1)
+	g.WriteOne(2)
+	for i := 0; i < 2; i++ {
+		if v, ok := g.Peek(); !ok || v != 1 {
+			t.Fatalf("expected 1, true; got %d, %v", v, ok)
+		}
+	}
+	if g.Len() != 2 {
+		t.Fatalf("expected Peek not to consume, Len is %d", g.Len())
+	}
+	g.ReadOne()
+	if v, ok := g.Peek(); !ok || v != 2 {
+		t.Fatalf("expected 2, true; got %d, %v", v, ok)
+	}
+}
+
+func TestReadN(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		skip     int // items written and read first, to move the beginning of the ring
+		write    int
+		n        int
+		expected []int
+	}{
+		{name: "empty", write: 0, n: 3, expected: nil},
+		{name: "zero", write: 3, n: 0, expected: nil},
+		{name: "negative", write: 3, n: -1, expected: nil},
+		{name: "fewer than available", write: 5, n: 3, expected: []int{0, 1, 2}},
+		{name: "all available", write: 5, n: 5, expected: []int{0, 1, 2, 3, 4}},
+		{name: "more than available", write: 5, n: 10, expected: []int{0, 1, 2, 3, 4}},
+		{name: "wrapped around", skip: 6, write: 5, n: 4, expected: []int{0, 1, 2, 3}},
+		{name: "wrapped around, all", skip: 6, write: 8, n: 8, expected: []int{0, 1, 2, 3, 4, 5, 6, 7}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewTypedRingGrowing[int](RingGrowingOptions{InitialSize: 8})
+			for i := 0; i < tt.skip; i++ {
+				g.WriteOne(-1)
+				g.ReadOne()
+			}
+			for i := 0; i < tt.write; i++ {
+				g.WriteOne(i)
+			}
+			got := g.ReadN(tt.n)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, got)
+			}
+			if e, a := tt.write-len(tt.expected), g.Len(); e != a {
+				t.Fatalf("expected Len to be %d: %d", e, a)
+			}
+			// The remaining items are still read in order.
+			for i := len(tt.expected); i < tt.write; i++ {
+				if v, ok := g.ReadOne(); !ok || v != i {
+					t.Fatalf("expected %d, true; got %d, %v", i, v, ok)
+				}
+			}
+			for i, v := range g.data {
+				if v != 0 {
+					t.Fatalf("expected consumed element %d to be cleared, got %d", i, v)
+				}
+			}
+		})
+	}
+}
+
+func TestDrain(t *testing.T) {
+	t.Parallel()
+	g := NewTypedRingGrowing[int](RingGrowingOptions{InitialSize: 1})
+	if got := g.Drain(); got != nil {
+		t.Fatalf("expected nil, got %v", got)
+	}
+	for i := 0; i < 5; i++ {
+		g.WriteOne(i)
+	}
+	if got, e := g.Drain(), []int{0, 1, 2, 3, 4}; !reflect.DeepEqual(got, e) {
+		t.Fatalf("expected %v, got %v", e, got)
+	}
+	if g.Len() != 0 {
+		t.Fatalf("expected Len to be zero: %d", g.Len())
+	}
+}
+
+func TestMaxSize(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		opts        RingGrowingOptions
+		write       int
+		expected    []int
+		expectedCap int
+	}{
+		{
+			name:        "under the maximum",
+			opts:        RingGrowingOptions{InitialSize: 1, MaxSize: 8},
+			write:       5,
+			expected:    []int{0, 1, 2, 3, 4},
+			expectedCap: 8,
+		},
+		{
+			name:        "growth capped at the maximum",
+			opts:        RingGrowingOptions{InitialSize: 2, MaxSize: 6},
+			write:       6,
+			expected:    []int{0, 1, 2, 3, 4, 5},
+			expectedCap: 6,
+		},
+		{
+			name:        "oldest overwritten",
+			opts:        RingGrowingOptions{InitialSize: 1, MaxSize: 4},
+			write:       10,
+			expected:    []int{6, 7, 8, 9},
+			expectedCap: 4,
+		},
+		{
+			name:        "initial size above the maximum",
+			opts:        RingGrowingOptions{InitialSize: 10, MaxSize: 3},
+			write:       5,
+			expected:    []int{2, 3, 4},
+			expectedCap: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewTypedRingGrowing[int](tt.opts)
+			for i := 0; i < tt.write; i++ {
+				g.WriteOne(i)
+			}
+			if g.Cap() != tt.expectedCap {
+				t.Fatalf("expected Cap to be %d: %d", tt.expectedCap, g.Cap())
+			}
+			if e, a := int64(tt.write-len(tt.expected)), g.Dropped(); e != a {
+				t.Fatalf("expected Dropped to be %d: %d", e, a)
+			}
+			if got := g.Drain(); !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestOnDrop(t *testing.T) {
+	t.Parallel()
+	calls := 0
+	g := NewTypedRingGrowing[int](RingGrowingOptions{InitialSize: 2, MaxSize: 2, OnDrop: func() { calls++ }})
+	g.WriteOne(0)
+	g.WriteOne(1)
+	if calls != 0 || g.Dropped() != 0 {
+		t.Fatalf("expected no drops, got %d calls and Dropped %d", calls, g.Dropped())
+	}
+	g.WriteOne(2)
+	g.WriteOne(3)
+	g.WriteOne(4)
+	if calls != 3 || g.Dropped() != 3 {
+		t.Fatalf("expected 3 drops, got %d calls and Dropped %d", calls, g.Dropped())
+	}
+	// Reading makes room again without resetting the count.
+	g.ReadOne()
+	g.WriteOne(5)
+	if calls != 3 || g.Dropped() != 3 {
+		t.Fatalf("expected 3 drops, got %d calls and Dropped %d", calls, g.Dropped())
+	}
+}
+
+func TestShrink(t *testing.T) {
+	t.Parallel()
+	g := NewTypedRingGrowing[int](RingGrowingOptions{InitialSize: 4, Shrink: true})
+	for i := 0; i < 64; i++ {
+		g.WriteOne(i)
+	}
+	if g.Cap() != 64 {
+		t.Fatalf("expected Cap to be 64: %d", g.Cap())
+	}
+
+	// Reading down to a quarter full halves the capacity.
+	for i := 0; i < 48; i++ {
+		if v, ok := g.ReadOne(); !ok || v != i {
+			t.Fatalf("expected %d, true; got %d, %v", i, v, ok)
+		}
+	}
+	if g.Cap() != 32 {
+		t.Fatalf("expected Cap to be 32: %d", g.Cap())
+	}
+
+	// Draining shrinks back to, but not below, the initial size.
+	if got := g.Drain(); len(got) != 16 || got[0] != 48 || got[15] != 63 {
+		t.Fatalf("expected 48 through 63, got %v", got)
+	}
+	if g.Cap() != 4 {
+		t.Fatalf("expected Cap to be 4: %d", g.Cap())
+	}
+
+	// The buffer still works after shrinking.
+	for i := 0; i < 10; i++ {
+		g.WriteOne(i)
+	}
+	if got, e := g.Drain(), []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}; !reflect.DeepEqual(got, e) {
+		t.Fatalf("expected %v, got %v", e, got)
+	}
+}
+
+func TestNoShrinkByDefault(t *testing.T) {
+	t.Parallel()
+	g := NewTypedRingGrowing[int](RingGrowingOptions{InitialSize: 1})
+	for i := 0; i < 16; i++ {
+		g.WriteOne(i)
+	}
+	g.Drain()
+	if g.Cap() != 16 {
+		t.Fatalf("expected Cap to stay 16: %d", g.Cap())
+	}
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+	g := NewTypedRingGrowing[int](RingGrowingOptions{InitialSize: 4, MaxSize: 4})
+	// Wrap around the end of the underlying storage.
+	for i := 0; i < 6; i++ {
+		g.WriteOne(i)
+	}
+	c := g.Clone()
+	if c.Len() != g.Len() || c.Cap() != g.Cap() || c.Dropped() != g.Dropped() {
+		t.Fatalf("expected clone to match, got Len %d/%d, Cap %d/%d, Dropped %d/%d",
+			c.Len(), g.Len(), c.Cap(), g.Cap(), c.Dropped(), g.Dropped())
+	}
+
+	// Changes to either buffer don't affect the other.
+	g.WriteOne(6)
+	if got, e := c.Drain(), []int{2, 3, 4, 5}; !reflect.DeepEqual(got, e) {
+		t.Fatalf("expected clone to contain %v, got %v", e, got)
+	}
+	c.WriteOne(100)
+	if got, e := g.Drain(), []int{3, 4, 5, 6}; !reflect.DeepEqual(got, e) {
+		t.Fatalf("expected original to contain %v, got %v", e, got)
+	}
+}
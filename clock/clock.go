@@ -16,7 +16,10 @@ limitations under the License.
 
 package clock
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // PassiveClock allows for injecting fake or real clocks into code
 // that needs to read the current time but does not support scheduling
@@ -37,7 +40,7 @@ type Clock interface {
 	// NewTimer returns a new Timer.
 	NewTimer(d time.Duration) Timer
 	// Sleep sleeps for the provided duration d.
-	// Consider making the sleep interruptible by using 'select' on a context channel and a timer channel.
+	// Consider making the sleep interruptible by using SleepContext.
 	Sleep(d time.Duration)
 	// Tick returns the channel of a new Ticker.
 	// This method does not allow to free/GC the backing ticker. Use
@@ -130,11 +133,48 @@ func (RealClock) NewTicker(d time.Duration) Ticker {
 }
 
 // Sleep is the same as time.Sleep(d)
-// Consider making the sleep interruptible by using 'select' on a context channel and a timer channel.
+// Consider making the sleep interruptible by using SleepContext.
 func (RealClock) Sleep(d time.Duration) {
 	time.Sleep(d)
 }
 
+// SleepContext sleeps for the provided duration d, returning early with ctx's
+// error if ctx is done first.
+func (r RealClock) SleepContext(ctx context.Context, d time.Duration) error {
+	return sleepContext(ctx, r, d)
+}
+
+// SleepContext sleeps for the provided duration d using c, returning early
+// with ctx's error if ctx is done first. If c has a SleepContext method, as
+// RealClock and testing.FakeClock do, it is used; otherwise the sleep uses a
+// timer from c.
+func SleepContext(ctx context.Context, c Clock, d time.Duration) error {
+	if s, ok := c.(interface {
+		SleepContext(context.Context, time.Duration) error
+	}); ok {
+		return s.SleepContext(ctx, d)
+	}
+	return sleepContext(ctx, c, d)
+}
+
+// sleepContext implements SleepContext with a timer from c.
+func sleepContext(ctx context.Context, c Clock, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if d <= 0 {
+		return nil
+	}
+	t := c.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C():
+		return nil
+	}
+}
+
 // Timer allows for injecting fake or real timers into code that
 // needs to do arbitrary things based on time.
 type Timer interface {
@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clock_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/utils/clock"
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+func TestRealClockSleepContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := clock.SleepContext(ctx, clock.RealClock{}, time.Hour); err != context.DeadlineExceeded {
+		t.Errorf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Minute {
+		t.Errorf("expected sleep to end early, took %v", elapsed)
+	}
+	if err := clock.SleepContext(context.Background(), clock.RealClock{}, time.Millisecond); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestFakeClockSleepContext(t *testing.T) {
+	start := time.Now()
+	fakeClock := testingclock.NewFakeClock(start)
+	if err := clock.SleepContext(context.Background(), fakeClock, time.Hour); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if fakeClock.Since(start) != time.Hour {
+		t.Errorf("expected sleep to step the clock by an hour, got %v", fakeClock.Since(start))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := clock.SleepContext(ctx, fakeClock, time.Hour); err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+	if fakeClock.Since(start) != time.Hour {
+		t.Errorf("expected cancelled sleep not to step the clock, got %v", fakeClock.Since(start))
+	}
+}
+
+func TestSleepContextWithTimer(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	// hide FakeClock's SleepContext, so that a timer is used
+	c := struct{ clock.Clock }{fakeClock}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	done := make(chan error)
+	go func() {
+		done <- clock.SleepContext(ctx, c, time.Second)
+	}()
+	if err := fakeClock.BlockUntilWaiters(ctx, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fakeClock.Step(time.Second)
+	if err := <-done; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	sleepCtx, sleepCancel := context.WithCancel(ctx)
+	go func() {
+		done <- clock.SleepContext(sleepCtx, c, time.Second)
+	}()
+	if err := fakeClock.BlockUntilWaiters(ctx, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sleepCancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+	if fakeClock.HasWaiters() {
+		t.Errorf("expected the timer to be stopped")
+	}
+}
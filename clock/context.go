@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithDeadline is like context.WithDeadline, but the deadline is measured by
+// c instead of the real time, so that code mixing contexts and clocks can be
+// tested with a fake clock. The deadline of the parent context is not taken
+// into account, since it may be measured by a different clock.
+func WithDeadline(parent context.Context, c Clock, d time.Time) (context.Context, context.CancelFunc) {
+	dc := &deadlineContext{Context: parent, deadline: d, done: make(chan struct{})}
+	cancel := func() { dc.cancel(context.Canceled) }
+	select {
+	case <-parent.Done():
+		dc.cancel(parent.Err())
+		return dc, cancel
+	default:
+	}
+	dur := d.Sub(c.Now())
+	if dur <= 0 {
+		dc.cancel(context.DeadlineExceeded)
+		return dc, cancel
+	}
+	timer := c.NewTimer(dur)
+	go func() {
+		defer timer.Stop()
+		select {
+		case <-parent.Done():
+			dc.cancel(parent.Err())
+		case <-timer.C():
+			dc.cancel(context.DeadlineExceeded)
+		case <-dc.done:
+		}
+	}()
+	return dc, cancel
+}
+
+// WithTimeout returns WithDeadline(parent, c, c.Now().Add(timeout)).
+func WithTimeout(parent context.Context, c Clock, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return WithDeadline(parent, c, c.Now().Add(timeout))
+}
+
+// deadlineContext is a context which reports context.DeadlineExceeded once
+// its deadline, measured by a Clock, has passed. It has its own done channel
+// instead of being built on context.WithCancel, so that contexts derived from
+// it with the context package wait for its Done channel and take its Err,
+// rather than being cancelled directly along with the context it would be
+// built on, with context.Canceled.
+type deadlineContext struct {
+	context.Context
+	deadline time.Time
+	done     chan struct{}
+
+	// lock guards err, which is set before done is closed
+	lock sync.Mutex
+	err  error
+}
+
+// cancel closes the done channel with err, unless it is already closed.
+func (c *deadlineContext) cancel(err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.err != nil {
+		return
+	}
+	c.err = err
+	close(c.done)
+}
+
+func (c *deadlineContext) Deadline() (time.Time, bool) {
+	return c.deadline, true
+}
+
+func (c *deadlineContext) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *deadlineContext) Err() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.err
+}
+
+func (c *deadlineContext) String() string {
+	return "clock.WithDeadline(" + c.deadline.String() + ")"
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clock_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"k8s.io/utils/clock"
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+func TestWithTimeout(t *testing.T) {
+	start := time.Now()
+	fakeClock := testingclock.NewFakeClock(start)
+	ctx, cancel := clock.WithTimeout(context.Background(), fakeClock, time.Minute)
+	defer cancel()
+
+	if deadline, ok := ctx.Deadline(); !ok || !deadline.Equal(start.Add(time.Minute)) {
+		t.Errorf("expected deadline %v, got %v, %v", start.Add(time.Minute), deadline, ok)
+	}
+	if err := fakeClock.BlockUntilWaiters(context.Background(), 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fakeClock.Step(59 * time.Second)
+	select {
+	case <-ctx.Done():
+		t.Fatalf("expected context not to be done before its deadline")
+	case <-time.After(10 * time.Millisecond):
+	}
+	if err := ctx.Err(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	fakeClock.Step(time.Second)
+	select {
+	case <-ctx.Done():
+	case <-time.After(30 * time.Second):
+		t.Fatalf("timed out waiting for the context to expire")
+	}
+	if err := ctx.Err(); err != context.DeadlineExceeded {
+		t.Errorf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	// contexts derived from an expired context inherit its error
+	child, childCancel := context.WithCancel(ctx)
+	defer childCancel()
+	if err := child.Err(); err != context.DeadlineExceeded {
+		t.Errorf("expected %v for a child context, got %v", context.DeadlineExceeded, err)
+	}
+	// cancelling an expired context doesn't change its error
+	cancel()
+	if err := ctx.Err(); err != context.DeadlineExceeded {
+		t.Errorf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestWithDeadlineChildContexts(t *testing.T) {
+	start := time.Now()
+	fakeClock := testingclock.NewFakeClock(start)
+	ctx, cancel := clock.WithTimeout(context.Background(), fakeClock, time.Minute)
+	defer cancel()
+
+	// children created before the deadline report that it was exceeded, not
+	// that they were cancelled
+	child, childCancel := context.WithCancel(ctx)
+	defer childCancel()
+	timeoutChild, timeoutChildCancel := context.WithTimeout(ctx, time.Hour)
+	defer timeoutChildCancel()
+	grandchild, grandchildCancel := context.WithCancel(child)
+	defer grandchildCancel()
+
+	if err := fakeClock.BlockUntilWaiters(context.Background(), 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fakeClock.Step(time.Minute)
+	for name, c := range map[string]context.Context{"child": child, "timeout child": timeoutChild, "grandchild": grandchild} {
+		select {
+		case <-c.Done():
+		case <-time.After(30 * time.Second):
+			t.Fatalf("timed out waiting for the %s context to be done", name)
+		}
+		if err := c.Err(); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected %v for the %s context, got %v", context.DeadlineExceeded, name, err)
+		}
+	}
+
+	// cancelling a child doesn't affect the parent
+	other, otherCancel := clock.WithTimeout(context.Background(), fakeClock, time.Minute)
+	defer otherCancel()
+	otherChild, otherChildCancel := context.WithCancel(other)
+	otherChildCancel()
+	if err := other.Err(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := otherChild.Err(); err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestWithDeadlineCancel(t *testing.T) {
+	start := time.Now()
+	fakeClock := testingclock.NewFakeClock(start)
+
+	type key struct{}
+	parent, parentCancel := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+	ctx, cancel := clock.WithDeadline(parent, fakeClock, start.Add(time.Minute))
+	defer cancel()
+	if ctx.Value(key{}) != "value" {
+		t.Errorf("expected values to be inherited from the parent")
+	}
+
+	parentCancel()
+	<-ctx.Done()
+	if err := ctx.Err(); err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+	// the timer is released
+	for fakeClock.HasWaiters() {
+		time.Sleep(time.Millisecond)
+	}
+
+	past, pastCancel := clock.WithDeadline(context.Background(), fakeClock, start)
+	defer pastCancel()
+	<-past.Done()
+	if err := past.Err(); err != context.DeadlineExceeded {
+		t.Errorf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+}
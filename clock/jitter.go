@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clock
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Jitter returns a duration between d and d*(1+maxFactor), picked at random.
+// If maxFactor is not positive, d is returned unchanged.
+func Jitter(d time.Duration, maxFactor float64) time.Duration {
+	if maxFactor <= 0 {
+		return d
+	}
+	return d + time.Duration(rand.Float64()*maxFactor*float64(d))
+}
+
+// NewJitteredTicker returns a Ticker whose ticks are separated by intervals of
+// Jitter(d, jitterFactor), with a new random jitter for each interval, so that
+// loops started at the same time don't keep running in lockstep. It is driven
+// by timers from c, so it can be tested with a fake clock. As with
+// time.Ticker, ticks are dropped for slow receivers. It panics if d is not
+// positive.
+func NewJitteredTicker(c Clock, d time.Duration, jitterFactor float64) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewJitteredTicker")
+	}
+	t := newForwardingTicker()
+	timer := c.NewTimer(Jitter(d, jitterFactor))
+	go func() {
+		defer timer.Stop()
+		for {
+			select {
+			case <-t.stop:
+				return
+			case now := <-timer.C():
+				t.send(now)
+				timer.Reset(Jitter(d, jitterFactor))
+			}
+		}
+	}()
+	return t
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clock_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/utils/clock"
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+func TestJitter(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxFactor float64
+		max       time.Duration
+	}{
+		{"no jitter", 0, time.Second},
+		{"negative factor", -1, time.Second},
+		{"jitter", 0.5, 1500 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				if d := clock.Jitter(time.Second, tt.maxFactor); d < time.Second || d > tt.max {
+					t.Fatalf("expected jittered duration between %v and %v, got %v", time.Second, tt.max, d)
+				}
+			}
+		})
+	}
+}
+
+func TestJitteredTicker(t *testing.T) {
+	start := time.Now()
+	fakeClock := testingclock.NewFakeClock(start)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	ticker := clock.NewJitteredTicker(fakeClock, time.Second, 0.5)
+	for i := 0; i < 5; i++ {
+		if err := fakeClock.BlockUntilWaiters(ctx, 1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		deadline := fakeClock.WaiterDeadlines()[0]
+		if interval := deadline.Sub(fakeClock.Now()); interval < time.Second || interval > 1500*time.Millisecond {
+			t.Errorf("expected interval between 1s and 1.5s, got %v", interval)
+		}
+		fakeClock.SetTime(deadline)
+		select {
+		case tick := <-ticker.C():
+			if !tick.Equal(deadline) {
+				t.Errorf("expected tick at %v, got %v", deadline, tick)
+			}
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for tick %d", i)
+		}
+	}
+
+	ticker.Stop()
+	ticker.Stop()
+	for fakeClock.HasWaiters() {
+		time.Sleep(time.Millisecond)
+	}
+}
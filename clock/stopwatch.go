@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clock
+
+import (
+	"time"
+)
+
+// Stopwatch measures elapsed time using a PassiveClock, so that latency
+// measurements can be tested with a fake clock. It is not thread safe.
+type Stopwatch struct {
+	clock   PassiveClock
+	running bool
+	start   time.Time
+	lap     time.Time
+}
+
+// NewStopwatch returns a stopped Stopwatch which measures time using c.
+func NewStopwatch(c PassiveClock) *Stopwatch {
+	return &Stopwatch{clock: c}
+}
+
+// Start starts the stopwatch, unless it is already running. It returns s so
+// that it can be chained with NewStopwatch.
+func (s *Stopwatch) Start() *Stopwatch {
+	if !s.running {
+		s.running = true
+		s.start = s.clock.Now()
+		s.lap = s.start
+	}
+	return s
+}
+
+// Lap returns the time since the previous call to Lap, or since the stopwatch
+// was started for the first lap, and starts a new lap. It returns zero if the
+// stopwatch is not running.
+func (s *Stopwatch) Lap() time.Duration {
+	if !s.running {
+		return 0
+	}
+	now := s.clock.Now()
+	lap := now.Sub(s.lap)
+	s.lap = now
+	return lap
+}
+
+// Elapsed returns the time since the stopwatch was started, or zero if it is
+// not running.
+func (s *Stopwatch) Elapsed() time.Duration {
+	if !s.running {
+		return 0
+	}
+	return s.clock.Since(s.start)
+}
+
+// Reset stops the stopwatch, so that the next call to Start starts measuring
+// from scratch.
+func (s *Stopwatch) Reset() {
+	s.running = false
+	s.start = time.Time{}
+	s.lap = time.Time{}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/utils/clock"
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+func TestStopwatch(t *testing.T) {
+	fakeClock := testingclock.NewFakePassiveClock(time.Now())
+	step := func(d time.Duration) {
+		fakeClock.SetTime(fakeClock.Now().Add(d))
+	}
+
+	sw := clock.NewStopwatch(fakeClock)
+	step(time.Second)
+	if sw.Elapsed() != 0 || sw.Lap() != 0 {
+		t.Errorf("expected a stopped stopwatch to measure nothing")
+	}
+
+	sw.Start()
+	step(time.Second)
+	if lap := sw.Lap(); lap != time.Second {
+		t.Errorf("expected first lap of 1s, got %v", lap)
+	}
+	step(2 * time.Second)
+	// starting a running stopwatch has no effect
+	sw.Start()
+	if lap := sw.Lap(); lap != 2*time.Second {
+		t.Errorf("expected second lap of 2s, got %v", lap)
+	}
+	step(time.Second)
+	if elapsed := sw.Elapsed(); elapsed != 4*time.Second {
+		t.Errorf("expected 4s elapsed, got %v", elapsed)
+	}
+
+	sw.Reset()
+	if sw.Elapsed() != 0 {
+		t.Errorf("expected a reset stopwatch to measure nothing")
+	}
+	sw.Start()
+	step(time.Second)
+	if elapsed := sw.Elapsed(); elapsed != time.Second {
+		t.Errorf("expected 1s elapsed after restarting, got %v", elapsed)
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package testing
 
 import (
+	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -41,6 +43,19 @@ type FakeClock struct {
 
 	// waiters are waiting for the fake time to pass their specified time
 	waiters []*fakeClockWaiter
+	// waitersChanged, if set, is broadcast whenever waiters changes. It uses
+	// the write lock.
+	waitersChanged *sync.Cond
+	// autoAdvance is true if the time moves forward on its own to the
+	// earliest deadline while there are one-shot waiters.
+	autoAdvance bool
+	// advancing is true while the goroutine moving the time forward in
+	// auto-advance mode is running.
+	advancing bool
+	// waitersGeneration is incremented whenever waiters changes.
+	waitersGeneration uint64
+	// stepHooks are called whenever Step or SetTime change the time.
+	stepHooks []func(oldTime, newTime time.Time)
 }
 
 type fakeClockWaiter struct {
@@ -96,6 +111,8 @@ func (f *FakeClock) After(d time.Duration) <-chan time.Time {
 		targetTime: stopTime,
 		destChan:   ch,
 	})
+	f.waitersChangedLocked()
+	f.autoAdvanceLocked()
 	return ch
 }
 
@@ -113,6 +130,8 @@ func (f *FakeClock) NewTimer(d time.Duration) clock.Timer {
 		},
 	}
 	f.waiters = append(f.waiters, &timer.waiter)
+	f.waitersChangedLocked()
+	f.autoAdvanceLocked()
 	return timer
 }
 
@@ -132,6 +151,8 @@ func (f *FakeClock) AfterFunc(d time.Duration, cb func()) clock.Timer {
 		},
 	}
 	f.waiters = append(f.waiters, &timer.waiter)
+	f.waitersChangedLocked()
+	f.autoAdvanceLocked()
 	return timer
 }
 
@@ -150,6 +171,7 @@ func (f *FakeClock) Tick(d time.Duration) <-chan time.Time {
 		skipIfBlocked: true,
 		destChan:      ch,
 	})
+	f.waitersChangedLocked()
 
 	return ch
 }
@@ -166,6 +188,7 @@ func (f *FakeClock) NewTicker(d time.Duration) clock.Ticker {
 		skipIfBlocked: true,
 		destChan:      ch,
 	})
+	f.waitersChangedLocked()
 
 	return &fakeTicker{
 		c: ch,
@@ -176,15 +199,37 @@ func (f *FakeClock) NewTicker(d time.Duration) clock.Ticker {
 // Tick, or NewTimer.
 func (f *FakeClock) Step(d time.Duration) {
 	f.lock.Lock()
-	defer f.lock.Unlock()
+	oldTime := f.time
 	f.setTimeLocked(f.time.Add(d))
+	f.unlockAndCallStepHooks(oldTime)
 }
 
 // SetTime sets the time.
 func (f *FakeClock) SetTime(t time.Time) {
 	f.lock.Lock()
-	defer f.lock.Unlock()
+	oldTime := f.time
 	f.setTimeLocked(t)
+	f.unlockAndCallStepHooks(oldTime)
+}
+
+// AddStepHook registers hook to be called with the old and the new time
+// whenever Step, SetTime or Sleep change the time, or auto-advance mode moves
+// it forward, after any waiters have been notified. Hooks are called in the order they were added, without holding
+// f's lock, so they may use f.
+func (f *FakeClock) AddStepHook(hook func(oldTime, newTime time.Time)) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.stepHooks = append(f.stepHooks, hook)
+}
+
+// unlockAndCallStepHooks releases f's write lock and calls the step hooks for
+// a change of time from oldTime.
+func (f *FakeClock) unlockAndCallStepHooks(oldTime time.Time) {
+	newTime, hooks := f.time, f.stepHooks
+	f.lock.Unlock()
+	for _, hook := range hooks {
+		hook(oldTime, newTime)
+	}
 }
 
 // Actually changes the time and checks any waiters. f must be write-locked.
@@ -219,6 +264,7 @@ func (f *FakeClock) setTimeLocked(t time.Time) {
 		}
 	}
 	f.waiters = newWaiters
+	f.waitersChangedLocked()
 }
 
 // HasWaiters returns true if After or AfterFunc has been called on f but not yet satisfied (so you can
@@ -229,11 +275,171 @@ func (f *FakeClock) HasWaiters() bool {
 	return len(f.waiters) > 0
 }
 
+// NumWaiters returns the number of timers, tickers and After or AfterFunc calls
+// which are waiting for f's time to pass their deadline.
+func (f *FakeClock) NumWaiters() int {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return len(f.waiters)
+}
+
+// WaiterDeadlines returns the times that the timers, tickers and After or
+// AfterFunc calls waiting on f will fire at next, in ascending order.
+func (f *FakeClock) WaiterDeadlines() []time.Time {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	deadlines := make([]time.Time, 0, len(f.waiters))
+	for _, w := range f.waiters {
+		deadlines = append(deadlines, w.targetTime)
+	}
+	sort.Slice(deadlines, func(i, j int) bool {
+		return deadlines[i].Before(deadlines[j])
+	})
+	return deadlines
+}
+
+// BlockUntilWaiters blocks until at least n timers, tickers or After or
+// AfterFunc calls are waiting on f, so that tests can step the clock once the
+// code under test has started waiting. It returns ctx's error if ctx is done
+// first.
+func (f *FakeClock) BlockUntilWaiters(ctx context.Context, n int) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.waitersChanged == nil {
+		f.waitersChanged = sync.NewCond(&f.lock)
+	}
+	if len(f.waiters) >= n {
+		return nil
+	}
+	// wake up the loop below when ctx is done
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			f.lock.Lock()
+			defer f.lock.Unlock()
+			f.waitersChanged.Broadcast()
+		case <-done:
+		}
+	}()
+	for len(f.waiters) < n {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		f.waitersChanged.Wait()
+	}
+	return nil
+}
+
+// SetAutoAdvance enables or disables auto-advance mode. In this mode, whenever
+// After, NewTimer, AfterFunc or a timer's Reset is called, the time starts
+// moving forward on its own, from one deadline to the next earliest one, as
+// Step would, so that code waiting on the clock runs at full speed without the
+// test stepping it. The time only moves once the waiters have stayed unchanged
+// for a short while in real time, so that code starting several timers at once
+// sees them fire in order. It stops moving once only tickers are left, since
+// they never stop waiting.
+func (f *FakeClock) SetAutoAdvance(enabled bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.autoAdvance = enabled
+	f.autoAdvanceLocked()
+}
+
+// autoAdvanceDelay is how long the waiters must stay unchanged before
+// auto-advance mode moves the time forward.
+const autoAdvanceDelay = time.Millisecond
+
+// autoAdvanceLocked starts moving the time forward if auto-advance mode is
+// enabled and it isn't already. f must be write-locked.
+func (f *FakeClock) autoAdvanceLocked() {
+	if !f.autoAdvance || f.advancing {
+		return
+	}
+	f.advancing = true
+	go f.runAutoAdvance()
+}
+
+// runAutoAdvance moves the time forward to the earliest deadline of the
+// waiters whenever they have stayed unchanged for autoAdvanceDelay, until
+// auto-advance mode is disabled or there are no one-shot waiters left.
+func (f *FakeClock) runAutoAdvance() {
+	for {
+		f.lock.RLock()
+		generation := f.waitersGeneration
+		f.lock.RUnlock()
+
+		time.Sleep(autoAdvanceDelay)
+
+		f.lock.Lock()
+		deadline, ok := f.nextAutoAdvanceLocked()
+		if !ok {
+			f.advancing = false
+			f.lock.Unlock()
+			return
+		}
+		if generation != f.waitersGeneration {
+			f.lock.Unlock()
+			continue
+		}
+		oldTime := f.time
+		f.setTimeLocked(deadline)
+		f.unlockAndCallStepHooks(oldTime)
+	}
+}
+
+// nextAutoAdvanceLocked returns the time auto-advance mode should move to next:
+// the earliest deadline of the waiters, or the current time if that has
+// already passed. It returns false if auto-advance mode is disabled or there
+// are no one-shot waiters. f must be locked.
+func (f *FakeClock) nextAutoAdvanceLocked() (time.Time, bool) {
+	if !f.autoAdvance {
+		return time.Time{}, false
+	}
+	var deadline time.Time
+	oneShot := false
+	for i, w := range f.waiters {
+		if i == 0 || w.targetTime.Before(deadline) {
+			deadline = w.targetTime
+		}
+		if w.stepInterval == 0 {
+			oneShot = true
+		}
+	}
+	if !oneShot {
+		return time.Time{}, false
+	}
+	if deadline.Before(f.time) {
+		deadline = f.time
+	}
+	return deadline, true
+}
+
+// waitersChangedLocked wakes up BlockUntilWaiters calls after waiters has
+// changed. f must be write-locked.
+func (f *FakeClock) waitersChangedLocked() {
+	f.waitersGeneration++
+	if f.waitersChanged != nil {
+		f.waitersChanged.Broadcast()
+	}
+}
+
 // Sleep is akin to time.Sleep
 func (f *FakeClock) Sleep(d time.Duration) {
 	f.Step(d)
 }
 
+// SleepContext is like Sleep, but returns ctx's error without moving the clock
+// if ctx is already done.
+func (f *FakeClock) SleepContext(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	f.Step(d)
+	return nil
+}
+
 // IntervalClock implements clock.PassiveClock, but each invocation of Now steps the clock forward the specified duration.
 // IntervalClock technically implements the other methods of clock.Clock, but each implementation is just a panic.
 //
@@ -321,6 +527,7 @@ func (f *fakeTimer) Stop() bool {
 	}
 
 	f.fakeClock.waiters = newWaiters
+	f.fakeClock.waitersChangedLocked()
 
 	return active
 }
@@ -345,7 +552,9 @@ func (f *fakeTimer) Reset(d time.Duration) bool {
 	}
 	if !active {
 		f.fakeClock.waiters = append(f.fakeClock.waiters, &f.waiter)
+		f.fakeClock.waitersChangedLocked()
 	}
+	f.fakeClock.autoAdvanceLocked()
 
 	return active
 }
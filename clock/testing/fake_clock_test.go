@@ -17,6 +17,9 @@ limitations under the License.
 package testing
 
 import (
+	"context"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -444,3 +447,186 @@ func assertReadTime(t testing.TB, c <-chan time.Time) time.Time {
 	}
 	panic("unreachable")
 }
+
+func TestFakeWaiters(t *testing.T) {
+	startTime := time.Now()
+	tc := NewFakeClock(startTime)
+	if tc.NumWaiters() != 0 || len(tc.WaiterDeadlines()) != 0 {
+		t.Errorf("expected no waiters")
+	}
+
+	tc.After(3 * time.Second)
+	timer := tc.NewTimer(time.Second)
+	tc.NewTicker(2 * time.Second)
+	if tc.NumWaiters() != 3 {
+		t.Errorf("expected 3 waiters, got %d", tc.NumWaiters())
+	}
+	expected := []time.Time{startTime.Add(time.Second), startTime.Add(2 * time.Second), startTime.Add(3 * time.Second)}
+	if actual := tc.WaiterDeadlines(); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected deadlines %v, got %v", expected, actual)
+	}
+
+	timer.Stop()
+	tc.Step(2 * time.Second)
+	// the ticker is rescheduled
+	expected = []time.Time{startTime.Add(3 * time.Second), startTime.Add(4 * time.Second)}
+	if actual := tc.WaiterDeadlines(); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected deadlines %v, got %v", expected, actual)
+	}
+}
+
+func TestFakeBlockUntilWaiters(t *testing.T) {
+	tc := NewFakeClock(time.Now())
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	fired := make(chan struct{})
+	go func() {
+		<-tc.After(time.Second)
+		<-tc.After(time.Second)
+		close(fired)
+	}()
+	if err := tc.BlockUntilWaiters(ctx, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tc.Step(time.Second)
+	if err := tc.BlockUntilWaiters(ctx, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tc.Step(time.Second)
+	select {
+	case <-fired:
+	case <-ctx.Done():
+		t.Fatalf("timed out waiting for the waiters to fire")
+	}
+
+	// waiting for more waiters than will ever exist fails once ctx is done
+	shortCtx, shortCancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer shortCancel()
+	if err := tc.BlockUntilWaiters(shortCtx, 1); err != context.DeadlineExceeded {
+		t.Errorf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestFakeAutoAdvance(t *testing.T) {
+	startTime := time.Now()
+	tc := NewFakeClock(startTime)
+	tc.SetAutoAdvance(true)
+
+	// code waiting on the clock runs without the test stepping it
+	for i := 0; i < 100; i++ {
+		<-tc.After(time.Hour)
+	}
+	if elapsed := tc.Since(startTime); elapsed != 100*time.Hour {
+		t.Errorf("expected the clock to advance by 100h, got %v", elapsed)
+	}
+
+	timer := tc.NewTimer(time.Minute)
+	<-timer.C()
+	timer.Reset(time.Minute)
+	<-timer.C()
+	fired := make(chan struct{})
+	tc.AfterFunc(time.Minute, func() { close(fired) })
+	<-fired
+	<-tc.After(-time.Minute)
+	if elapsed := tc.Since(startTime); elapsed != 100*time.Hour+3*time.Minute {
+		t.Errorf("expected the clock to advance by 100h3m, got %v", elapsed)
+	}
+
+	// tickers don't advance the clock
+	tc.NewTicker(time.Second)
+	time.Sleep(10 * autoAdvanceDelay)
+	if elapsed := tc.Since(startTime); elapsed != 100*time.Hour+3*time.Minute {
+		t.Errorf("expected a ticker not to advance the clock, got %v", elapsed)
+	}
+
+	tc.SetAutoAdvance(false)
+	tc.After(time.Second)
+	time.Sleep(10 * autoAdvanceDelay)
+	if elapsed := tc.Since(startTime); elapsed != 100*time.Hour+3*time.Minute {
+		t.Errorf("expected the clock not to advance once auto-advance is disabled, got %v", elapsed)
+	}
+}
+
+func TestFakeAutoAdvanceEarliestFirst(t *testing.T) {
+	startTime := time.Now()
+	tc := NewFakeClock(startTime)
+
+	var lock sync.Mutex
+	var steps []time.Duration
+	tc.AddStepHook(func(oldTime, newTime time.Time) {
+		lock.Lock()
+		defer lock.Unlock()
+		steps = append(steps, newTime.Sub(startTime))
+	})
+	tc.SetAutoAdvance(true)
+
+	// a poll loop with an overall timeout registered before the poll timer
+	timeout := tc.After(time.Hour)
+	poll := tc.NewTimer(25 * time.Minute)
+	polls := 0
+loop:
+	for {
+		select {
+		case <-timeout:
+			poll.Stop()
+			break loop
+		case <-poll.C():
+			polls++
+			poll.Reset(25 * time.Minute)
+		}
+	}
+	if polls != 2 {
+		t.Errorf("expected 2 polls before the timeout, got %d", polls)
+	}
+	if elapsed := tc.Since(startTime); elapsed != time.Hour {
+		t.Errorf("expected the clock to advance by 1h, got %v", elapsed)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	expected := []time.Duration{25 * time.Minute, 50 * time.Minute, time.Hour}
+	if !reflect.DeepEqual(steps, expected) {
+		t.Errorf("expected step hooks to be called for %v, got %v", expected, steps)
+	}
+}
+
+func TestFakeStepHooks(t *testing.T) {
+	startTime := time.Now()
+	tc := NewFakeClock(startTime)
+
+	type step struct {
+		oldTime, newTime time.Time
+	}
+	var steps []step
+	tc.AddStepHook(func(oldTime, newTime time.Time) {
+		steps = append(steps, step{oldTime, newTime})
+	})
+	var order []int
+	for i := 0; i < 2; i++ {
+		i := i
+		tc.AddStepHook(func(oldTime, newTime time.Time) {
+			order = append(order, i)
+			// hooks may use the clock
+			if !tc.Now().Equal(newTime) {
+				t.Errorf("expected hook to see the new time %v, got %v", newTime, tc.Now())
+			}
+		})
+	}
+
+	tc.Step(time.Second)
+	tc.SetTime(startTime)
+	tc.Sleep(time.Minute)
+
+	expected := []step{
+		{startTime, startTime.Add(time.Second)},
+		{startTime.Add(time.Second), startTime},
+		{startTime, startTime.Add(time.Minute)},
+	}
+	if !reflect.DeepEqual(expected, steps) {
+		t.Errorf("expected steps %v, got %v", expected, steps)
+	}
+	if e, a := []int{0, 1, 0, 1, 0, 1}, order; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected hooks to be called in order %v, got %v", e, a)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+var (
+	_ = clock.PassiveClock(&ScriptedClock{})
+)
+
+// ExhaustedScriptBehavior selects what a ScriptedClock does once it has
+// returned all of its scripted times.
+type ExhaustedScriptBehavior int
+
+const (
+	// RepeatLastTime keeps returning the last scripted time.
+	RepeatLastTime ExhaustedScriptBehavior = iota
+	// RestartScript starts over from the first scripted time.
+	RestartScript
+	// PanicOnExhaustion panics, for tests which expect an exact number of
+	// calls to Now.
+	PanicOnExhaustion
+)
+
+// ScriptedClock implements clock.PassiveClock, returning a pre-programmed
+// sequence of times from successive calls to Now. The times don't need to be
+// increasing, so that tests can exercise repeated or non-monotonic times.
+type ScriptedClock struct {
+	lock      sync.Mutex
+	times     []time.Time
+	next      int
+	exhausted ExhaustedScriptBehavior
+}
+
+// NewScriptedClock returns a ScriptedClock which returns times in order, and
+// then behaves as selected by exhausted. It panics if times is empty.
+func NewScriptedClock(exhausted ExhaustedScriptBehavior, times ...time.Time) *ScriptedClock {
+	if len(times) == 0 {
+		panic("NewScriptedClock requires at least one time")
+	}
+	return &ScriptedClock{
+		times:     append([]time.Time(nil), times...),
+		exhausted: exhausted,
+	}
+}
+
+// Now returns the next time in the script.
+func (s *ScriptedClock) Now() time.Time {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.next == len(s.times) {
+		switch s.exhausted {
+		case RestartScript:
+			s.next = 0
+		case PanicOnExhaustion:
+			panic("ScriptedClock has run out of times")
+		default:
+			return s.times[len(s.times)-1]
+		}
+	}
+	t := s.times[s.next]
+	s.next++
+	return t
+}
+
+// Since returns the time since ts, using the next time in the script as the
+// current time.
+func (s *ScriptedClock) Since(ts time.Time) time.Duration {
+	return s.Now().Sub(ts)
+}
+
+// Remaining returns the number of scripted times which haven't been returned
+// yet.
+func (s *ScriptedClock) Remaining() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.times) - s.next
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScriptedClock(t *testing.T) {
+	t0 := time.Now()
+	t1 := t0.Add(time.Second)
+	t2 := t0.Add(-time.Second)
+
+	cases := []struct {
+		name      string
+		exhausted ExhaustedScriptBehavior
+		expected  []time.Time
+	}{
+		{"repeat last", RepeatLastTime, []time.Time{t0, t1, t1, t2, t2, t2}},
+		{"restart", RestartScript, []time.Time{t0, t1, t1, t2, t0, t1}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			sc := NewScriptedClock(c.exhausted, t0, t1, t1, t2)
+			for i, expected := range c.expected {
+				if actual := sc.Now(); !expected.Equal(actual) {
+					t.Errorf("call %d: expected %v, got %v", i, expected, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestScriptedClockSince(t *testing.T) {
+	t0 := time.Now()
+	sc := NewScriptedClock(RepeatLastTime, t0.Add(time.Second), t0.Add(3*time.Second))
+	if d := sc.Since(t0); d != time.Second {
+		t.Errorf("expected 1s, got %v", d)
+	}
+	if sc.Remaining() != 1 {
+		t.Errorf("expected 1 remaining time, got %d", sc.Remaining())
+	}
+	if d := sc.Since(t0); d != 3*time.Second {
+		t.Errorf("expected 3s, got %v", d)
+	}
+	if sc.Remaining() != 0 {
+		t.Errorf("expected no remaining times, got %d", sc.Remaining())
+	}
+}
+
+func TestScriptedClockPanic(t *testing.T) {
+	sc := NewScriptedClock(PanicOnExhaustion, time.Now())
+	sc.Now()
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic once the script is exhausted")
+		}
+	}()
+	sc.Now()
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// NewTickerWithImmediateFirstTick is like c.NewTicker(d), but the returned
+// Ticker also ticks once straight away, for loops which run immediately and
+// then every interval.
+func NewTickerWithImmediateFirstTick(c WithTicker, d time.Duration) Ticker {
+	t := newForwardingTicker()
+	t.send(c.Now())
+	ticker := c.NewTicker(d)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-t.stop:
+				return
+			case now := <-ticker.C():
+				t.send(now)
+			}
+		}
+	}()
+	return t
+}
+
+// forwardingTicker is a Ticker whose ticks are sent by a goroutine, which
+// returns once the ticker is stopped.
+type forwardingTicker struct {
+	c        chan time.Time
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func newForwardingTicker() *forwardingTicker {
+	return &forwardingTicker{
+		c:    make(chan time.Time, 1),
+		stop: make(chan struct{}),
+	}
+}
+
+// send sends a tick, dropping it if the previous one hasn't been received yet.
+func (t *forwardingTicker) send(now time.Time) {
+	select {
+	case t.c <- now:
+	default:
+	}
+}
+
+func (t *forwardingTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *forwardingTicker) Stop() {
+	t.stopOnce.Do(func() {
+		close(t.stop)
+	})
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/utils/clock"
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+func TestTickerWithImmediateFirstTick(t *testing.T) {
+	start := time.Now()
+	fakeClock := testingclock.NewFakeClock(start)
+	ticker := clock.NewTickerWithImmediateFirstTick(fakeClock, time.Second)
+	defer ticker.Stop()
+
+	select {
+	case tick := <-ticker.C():
+		if !tick.Equal(start) {
+			t.Errorf("expected first tick at %v, got %v", start, tick)
+		}
+	default:
+		t.Fatalf("expected an immediate first tick")
+	}
+
+	for i := 1; i <= 3; i++ {
+		fakeClock.Step(time.Second)
+		select {
+		case tick := <-ticker.C():
+			if expected := start.Add(time.Duration(i) * time.Second); !tick.Equal(expected) {
+				t.Errorf("expected tick at %v, got %v", expected, tick)
+			}
+		case <-time.After(30 * time.Second):
+			t.Fatalf("timed out waiting for tick %d", i)
+		}
+	}
+}
+
+func TestRealTickerWithImmediateFirstTick(t *testing.T) {
+	ticker := clock.NewTickerWithImmediateFirstTick(clock.RealClock{}, time.Millisecond)
+	for i := 0; i < 3; i++ {
+		select {
+		case <-ticker.C():
+		case <-time.After(30 * time.Second):
+			t.Fatalf("timed out waiting for tick %d", i)
+		}
+	}
+	ticker.Stop()
+	ticker.Stop()
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+// BoundedOutput runs cmd like Output, but keeps only the last limit bytes of
+// its standard output, so that a misbehaving command can't exhaust memory. It
+// also returns the total number of bytes the command wrote, which is larger
+// than len(out) if output was discarded.
+func BoundedOutput(cmd Cmd, limit int) (out []byte, written int64, err error) {
+	w := newTailWriter(limit)
+	cmd.SetStdout(w)
+	err = cmd.Run()
+	return w.Bytes(), w.written, err
+}
+
+// BoundedCombinedOutput is like BoundedOutput, for the combined standard
+// output and standard error of cmd, as in CombinedOutput.
+func BoundedCombinedOutput(cmd Cmd, limit int) (out []byte, written int64, err error) {
+	w := newTailWriter(limit)
+	cmd.SetStdout(w)
+	cmd.SetStderr(w)
+	err = cmd.Run()
+	return w.Bytes(), w.written, err
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBoundedOutput(t *testing.T) {
+	tests := []struct {
+		name            string
+		combined        bool
+		script          string
+		limit           int
+		expectedOutput  string
+		expectedWritten int64
+	}{
+		{
+			name:            "below limit",
+			script:          "echo out; echo err >&2",
+			limit:           100,
+			expectedOutput:  "out\n",
+			expectedWritten: 4,
+		},
+		{
+			name:            "truncated",
+			script:          "head -c 100000 /dev/zero; echo end",
+			limit:           8,
+			expectedOutput:  "\x00\x00\x00\x00end\n",
+			expectedWritten: 100004,
+		},
+		{
+			name:            "zero limit",
+			script:          "echo out",
+			limit:           0,
+			expectedOutput:  "",
+			expectedWritten: 4,
+		},
+		{
+			name:            "combined",
+			combined:        true,
+			script:          "echo out; echo err >&2",
+			limit:           6,
+			expectedOutput:  "t\nerr\n",
+			expectedWritten: 8,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := New().Command("/bin/sh", "-c", tt.script)
+			bounded := BoundedOutput
+			if tt.combined {
+				bounded = BoundedCombinedOutput
+			}
+			out, written, err := bounded(cmd, tt.limit)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(out, []byte(tt.expectedOutput)) {
+				t.Errorf("expected output %q, got %q", tt.expectedOutput, out)
+			}
+			if written != tt.expectedWritten {
+				t.Errorf("expected %d bytes written, got %d", tt.expectedWritten, written)
+			}
+		})
+	}
+}
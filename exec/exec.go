@@ -17,12 +17,20 @@ limitations under the License.
 package exec
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"io/fs"
+	"os"
 	osexec "os/exec"
+	"runtime"
+	"sync"
 	"syscall"
 	"time"
+
+	utiltrace "k8s.io/utils/trace"
 )
 
 // ErrExecutableNotFound is returned if the executable is not found.
@@ -39,13 +47,81 @@ type Interface interface {
 	//
 	// The provided context is used to kill the process if the context becomes done
 	// before the command completes on its own. For example, a timeout can be set in
-	// the context.
+	// the context. If the context holds a trace, as returned by trace.FromContext,
+	// the command is recorded as a nested trace when it runs.
 	CommandContext(ctx context.Context, cmd string, args ...string) Cmd
 
 	// LookPath wraps os/exec.LookPath
 	LookPath(file string) (string, error)
 }
 
+// CommandWithOptionsInterface is implemented by Interfaces which can create
+// commands set up by Options, such as the one returned by New. It is separate
+// from Interface so that existing implementations of Interface keep
+// compiling; use CommandWithOptions to create such a command from any
+// Interface.
+type CommandWithOptionsInterface interface {
+	// CommandWithOptions returns a Cmd instance which can be used to run a
+	// single command, set up as described by opts.
+	CommandWithOptions(cmd string, opts Options, args ...string) Cmd
+}
+
+// CommandWithOptions returns a Cmd from exec which runs cmd with args, set up
+// as described by opts. If exec doesn't implement
+// CommandWithOptionsInterface, the Cmd is created with Command and its
+// environment, directory and stdin are set with the setters of Cmd, while
+// ExtraFiles, KillChildren and DetailedErrors are ignored.
+func CommandWithOptions(exec Interface, cmd string, opts Options, args ...string) Cmd {
+	if e, ok := exec.(CommandWithOptionsInterface); ok {
+		return e.CommandWithOptions(cmd, opts, args...)
+	}
+	var c Cmd
+	if opts.Context != nil {
+		c = exec.CommandContext(opts.Context, cmd, args...)
+	} else {
+		c = exec.Command(cmd, args...)
+	}
+	if opts.Env != nil {
+		c.SetEnv(opts.Env)
+	}
+	if opts.Dir != "" {
+		c.SetDir(opts.Dir)
+	}
+	if opts.Stdin != nil {
+		c.SetStdin(opts.Stdin)
+	}
+	return c
+}
+
+// Options describes how to set up a command created by CommandWithOptions.
+type Options struct {
+	// Context, if set, kills the command once it is done, as with
+	// CommandContext.
+	Context context.Context
+	// Env is the environment of the command, as in SetEnv. If it is nil,
+	// the command inherits the environment of the current process.
+	Env []string
+	// Dir is the working directory of the command, as in SetDir. If it is
+	// empty, the command runs in the current directory.
+	Dir string
+	// Stdin is the standard input of the command, as in SetStdin.
+	Stdin io.Reader
+	// ExtraFiles are additional open files inherited by the command, as in
+	// os/exec.Cmd.ExtraFiles. They are not supported on Windows.
+	ExtraFiles []*os.File
+	// KillChildren makes Stop, the timeout set with SetTimeout, and Context
+	// being done from Go 1.20 on, signal the processes started by the
+	// command along with the command itself. It runs the command in a new
+	// process group on Unix and in a job object on Windows, where Stop
+	// terminates them right away since Windows has no SIGTERM. Processes
+	// which leave the process group or job are not stopped.
+	KillChildren bool
+	// DetailedErrors makes Run, Output, CombinedOutput and Wait return a
+	// *CommandError rather than an *ExitErrorWrapper when the command exits
+	// unsuccessfully.
+	DetailedErrors bool
+}
+
 // Cmd is an interface that presents an API that is very similar to Cmd from os/exec.
 // As more functionality is needed, this can grow. Since Cmd is a struct, we will have
 // to replace fields with get/set method pairs.
@@ -79,6 +155,47 @@ type Cmd interface {
 	Stop()
 }
 
+// TimeoutCmd is implemented by Cmds which can be given a timeout, such as
+// those of New. It is separate from Cmd so that existing implementations of
+// Cmd keep compiling; use SetTimeout to set the timeout of any Cmd.
+type TimeoutCmd interface {
+	// SetTimeout sets how long the command may run before it is killed, in
+	// which case Run, Output, CombinedOutput and Wait return a *TimeoutError.
+	// Zero means no timeout.
+	SetTimeout(d time.Duration)
+}
+
+// SetTimeout sets the timeout of cmd if it implements TimeoutCmd. It returns
+// false if cmd doesn't support timeouts.
+func SetTimeout(cmd Cmd, d time.Duration) bool {
+	tc, ok := cmd.(TimeoutCmd)
+	if ok {
+		tc.SetTimeout(d)
+	}
+	return ok
+}
+
+// GracePeriodCmd is implemented by Cmds which can be stopped gracefully when
+// their context is done, such as those of New. It is separate from Cmd so that
+// existing implementations of Cmd keep compiling; use SetGracePeriod to set
+// the grace period of any Cmd.
+type GracePeriodCmd interface {
+	// SetGracePeriod makes a command created by CommandContext receive
+	// SIGTERM when its context is done, and SIGKILL only if it is still
+	// running after d. Zero, the default, kills it with SIGKILL right away.
+	SetGracePeriod(d time.Duration)
+}
+
+// SetGracePeriod sets the grace period of cmd if it implements
+// GracePeriodCmd. It returns false if cmd doesn't support grace periods.
+func SetGracePeriod(cmd Cmd, d time.Duration) bool {
+	gc, ok := cmd.(GracePeriodCmd)
+	if ok {
+		gc.SetGracePeriod(d)
+	}
+	return ok
+}
+
 // ExitError is an interface that presents an API similar to os.ProcessState, which is
 // what ExitError from os/exec is. This is designed to make testing a bit easier and
 // probably loses some of the cross-platform properties of the underlying library.
@@ -92,6 +209,8 @@ type ExitError interface {
 // Implements Interface in terms of really exec()ing.
 type executor struct{}
 
+var _ CommandWithOptionsInterface = &executor{}
+
 // New returns a new Interface which will os/exec to run commands.
 func New() Interface {
 	return &executor{}
@@ -99,12 +218,33 @@ func New() Interface {
 
 // Command is part of the Interface interface.
 func (executor *executor) Command(cmd string, args ...string) Cmd {
-	return (*cmdWrapper)(maskErrDotCmd(osexec.Command(cmd, args...)))
+	return &cmdWrapper{Cmd: maskErrDotCmd(osexec.Command(cmd, args...))}
 }
 
 // CommandContext is part of the Interface interface.
 func (executor *executor) CommandContext(ctx context.Context, cmd string, args ...string) Cmd {
-	return (*cmdWrapper)(maskErrDotCmd(osexec.CommandContext(ctx, cmd, args...)))
+	return &cmdWrapper{
+		Cmd:        maskErrDotCmd(osexec.CommandContext(ctx, cmd, args...)),
+		hasContext: true,
+		trace:      utiltrace.FromContext(ctx),
+	}
+}
+
+// CommandWithOptions is part of the CommandWithOptionsInterface interface.
+func (executor *executor) CommandWithOptions(cmd string, opts Options, args ...string) Cmd {
+	w := &cmdWrapper{killChildren: opts.KillChildren, detailedErrors: opts.DetailedErrors}
+	if opts.Context != nil {
+		w.Cmd = maskErrDotCmd(osexec.CommandContext(opts.Context, cmd, args...))
+		w.hasContext = true
+		w.trace = utiltrace.FromContext(opts.Context)
+	} else {
+		w.Cmd = maskErrDotCmd(osexec.Command(cmd, args...))
+	}
+	w.Env = opts.Env
+	w.Dir = opts.Dir
+	w.Stdin = opts.Stdin
+	w.ExtraFiles = opts.ExtraFiles
+	return w
 }
 
 // LookPath is part of the Interface interface
@@ -114,9 +254,50 @@ func (executor *executor) LookPath(file string) (string, error) {
 }
 
 // Wraps exec.Cmd so we can capture errors.
-type cmdWrapper osexec.Cmd
+type cmdWrapper struct {
+	*osexec.Cmd
+
+	// hasContext is set if Cmd was created with a context.
+	hasContext bool
+	// gracePeriod is the grace period set with SetGracePeriod.
+	gracePeriod time.Duration
+	// killChildren is set if Stop should signal the whole process group.
+	killChildren bool
+	// detailedErrors is set if unsuccessful exits are reported as
+	// CommandErrors.
+	detailedErrors bool
+	group          *processGroup
+	// trace is the trace of the context of the command, if any, in which
+	// commandTrace records the command once started.
+	trace        *utiltrace.Trace
+	commandTrace *utiltrace.Trace
+
+	timeout time.Duration
+	// timer kills the process once the timeout has passed.
+	timer *time.Timer
+	// lock guards group, waited, timedOut and stopTimer. It is held while
+	// signalling the process, so that it is never signalled once Wait has
+	// reaped it.
+	lock sync.Mutex
+	// waited is set once the process has exited, before Wait reaps it where
+	// that is supported.
+	waited bool
+	// timedOut is set if timer killed the process.
+	timedOut bool
+	// stopTimer sends SIGKILL once Stop's grace period has passed.
+	stopTimer *time.Timer
+	// stderrPipe is set if StderrPipe was called.
+	stderrPipe bool
+	// stderrTail captures the tail of stderr for CommandError and Output.
+	stderrTail *tailWriter
+	// outputStderr is set by Output to report the tail of stderr in the
+	// ExitErrorWrapper, as os/exec does.
+	outputStderr bool
+}
 
 var _ Cmd = &cmdWrapper{}
+var _ TimeoutCmd = &cmdWrapper{}
+var _ GracePeriodCmd = &cmdWrapper{}
 
 func (cmd *cmdWrapper) SetDir(dir string) {
 	cmd.Dir = dir
@@ -138,58 +319,284 @@ func (cmd *cmdWrapper) SetEnv(env []string) {
 	cmd.Env = env
 }
 
+// SetTimeout is part of the TimeoutCmd interface.
+func (cmd *cmdWrapper) SetTimeout(d time.Duration) {
+	cmd.timeout = d
+}
+
+// SetGracePeriod is part of the GracePeriodCmd interface. It requires Go 1.20 or later,
+// and is ignored when built with older versions.
+func (cmd *cmdWrapper) SetGracePeriod(d time.Duration) {
+	cmd.gracePeriod = d
+}
+
 func (cmd *cmdWrapper) StdoutPipe() (io.ReadCloser, error) {
-	r, err := (*osexec.Cmd)(cmd).StdoutPipe()
+	r, err := cmd.Cmd.StdoutPipe()
 	return r, handleError(err)
 }
 
 func (cmd *cmdWrapper) StderrPipe() (io.ReadCloser, error) {
-	r, err := (*osexec.Cmd)(cmd).StderrPipe()
+	r, err := cmd.Cmd.StderrPipe()
+	if err == nil {
+		cmd.stderrPipe = true
+	}
 	return r, handleError(err)
 }
 
+// captureStderr arranges for the tail of stderr to be kept in stderrTail,
+// if it is needed for a CommandError or by Output, unless stderr is read
+// through a pipe or goes to a file, which the command writes to directly. If
+// stderr isn't set, it is only captured by Output: it would otherwise be
+// discarded, and capturing it through a pipe makes Wait block until any
+// processes started by the command that inherited it exit.
+func (cmd *cmdWrapper) captureStderr() {
+	if cmd.stderrPipe || !cmd.outputStderr && (!cmd.detailedErrors || cmd.Stderr == nil) {
+		return
+	}
+	if _, ok := cmd.Stderr.(*os.File); ok {
+		return
+	}
+	cmd.stderrTail = newTailWriter(stderrTailSize)
+	switch {
+	case cmd.Stderr == nil:
+		cmd.Stderr = cmd.stderrTail
+	case interfaceEqual(cmd.Stderr, cmd.Stdout):
+		// Keep a single writer, as os/exec then only writes to it from one
+		// goroutine.
+		cmd.Stderr = io.MultiWriter(cmd.Stderr, cmd.stderrTail)
+		cmd.Stdout = cmd.Stderr
+	default:
+		cmd.Stderr = io.MultiWriter(cmd.Stderr, cmd.stderrTail)
+	}
+}
+
+// interfaceEqual protects against panics from doing equality tests on
+// two interfaces with non-comparable underlying types.
+func interfaceEqual(a, b interface{}) (equal bool) {
+	defer func() {
+		if recover() != nil {
+			equal = false
+		}
+	}()
+	return a == b
+}
+
 func (cmd *cmdWrapper) Start() error {
-	err := (*osexec.Cmd)(cmd).Start()
-	return handleError(err)
+	cmd.captureStderr()
+	if cmd.killChildren {
+		prepareProcessGroup(cmd.Cmd)
+	}
+	if cmd.hasContext {
+		setGracePeriod(cmd.Cmd, cmd.gracePeriod, cmd.cancel)
+	}
+	if cmd.trace != nil {
+		cmd.commandTrace = cmd.trace.Nest("Exec", utiltrace.Field{Key: "cmd", Value: cmd.Path})
+	}
+	// Hold lock until the process group is set up, so that the context
+	// being done in the meantime signals the whole group.
+	cmd.lock.Lock()
+	err := cmd.Cmd.Start()
+	started := err == nil
+	if started && cmd.killChildren {
+		cmd.group, err = startProcessGroup(cmd.Cmd)
+	}
+	cmd.lock.Unlock()
+	if started && err != nil {
+		cmd.Process.Kill()
+		cmd.Cmd.Wait()
+	}
+	if err == nil && cmd.timeout > 0 {
+		cmd.timer = time.AfterFunc(cmd.timeout, cmd.killOnTimeout)
+	}
+	err = handleError(err)
+	if err != nil {
+		cmd.endTrace(err)
+	}
+	return err
+}
+
+// killOnTimeout kills the process once its timeout has passed, unless Wait
+// has already reaped it.
+func (cmd *cmdWrapper) killOnTimeout() {
+	cmd.lock.Lock()
+	defer cmd.lock.Unlock()
+	if cmd.waited {
+		return
+	}
+	// Killing fails once os/exec has seen the process exit, but may still
+	// succeed just after it exited on its own, which Wait checks for.
+	if cmd.signal(syscall.SIGKILL) == nil {
+		cmd.timedOut = true
+	}
 }
 
 func (cmd *cmdWrapper) Wait() error {
-	err := (*osexec.Cmd)(cmd).Wait()
-	return handleError(err)
+	if cmd.group != nil && waitExited(cmd.Process.Pid) {
+		// Stop signalling the process group before its leader is reaped,
+		// since its ID may be reused once it is.
+		cmd.finish()
+	}
+	err := cmd.Cmd.Wait()
+	cmd.finish()
+	timedOut := cmd.timedOut && !exitedOnItsOwn(cmd.ProcessState, cmd.group != nil)
+	err = cmd.commandError(handleError(err))
+	if timedOut {
+		err = &TimeoutError{Timeout: cmd.timeout, Err: err}
+	}
+	cmd.endTrace(err)
+	return err
+}
+
+// finish stops the timers and the signalling of the process, once it has
+// exited.
+func (cmd *cmdWrapper) finish() {
+	if cmd.timer != nil {
+		cmd.timer.Stop()
+	}
+	cmd.lock.Lock()
+	defer cmd.lock.Unlock()
+	cmd.waited = true
+	if cmd.stopTimer != nil {
+		cmd.stopTimer.Stop()
+	}
+	if cmd.group != nil {
+		cmd.group.close()
+	}
+}
+
+// jobTerminatedExitCode is the exit status of the processes of a job
+// terminated on Windows. Bit 29 marks application defined codes, which
+// programs rarely exit with.
+const jobTerminatedExitCode = 0x20000009
+
+// exitedOnItsOwn returns true if state shows that the process, which was
+// killed through its process group if group is set, exited on its own rather
+// than being killed.
+func exitedOnItsOwn(state *os.ProcessState, group bool) bool {
+	if state == nil {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		// Killing a single process fails once it has exited, while
+		// terminating a job succeeds whether or not it has processes left.
+		return group && state.ExitCode() != jobTerminatedExitCode
+	}
+	ws, ok := state.Sys().(syscall.WaitStatus)
+	return ok && !ws.Signaled()
+}
+
+// endTrace completes the trace of the command, if any, with err.
+func (cmd *cmdWrapper) endTrace(err error) {
+	if cmd.commandTrace == nil {
+		return
+	}
+	if err != nil {
+		cmd.commandTrace.SetError(err)
+	}
+	cmd.commandTrace.Log()
+}
+
+// commandError adds the tail of stderr to err if the command exited
+// unsuccessfully, and wraps it in a CommandError if detailedErrors is set.
+func (cmd *cmdWrapper) commandError(err error) error {
+	ee, ok := err.(*ExitErrorWrapper)
+	if !ok {
+		return err
+	}
+	var tail []byte
+	if cmd.stderrTail != nil {
+		tail = cmd.stderrTail.Bytes()
+		if cmd.outputStderr {
+			ee.Stderr = tail
+		}
+	}
+	if !cmd.detailedErrors {
+		return ee
+	}
+	ce := &CommandError{ExitCode: ee.ExitCode(), StderrTail: tail, Err: ee}
+	if ws, ok := ee.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+		ce.Signal = ws.Signal()
+	}
+	return ce
 }
 
 // Run is part of the Cmd interface.
 func (cmd *cmdWrapper) Run() error {
-	err := (*osexec.Cmd)(cmd).Run()
-	return handleError(err)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	return cmd.Wait()
 }
 
 // CombinedOutput is part of the Cmd interface.
 func (cmd *cmdWrapper) CombinedOutput() ([]byte, error) {
-	out, err := (*osexec.Cmd)(cmd).CombinedOutput()
-	return out, handleError(err)
+	if cmd.Stdout != nil {
+		return nil, errors.New("exec: Stdout already set")
+	}
+	if cmd.Stderr != nil {
+		return nil, errors.New("exec: Stderr already set")
+	}
+	var b bytes.Buffer
+	cmd.Stdout = &b
+	cmd.Stderr = &b
+	err := cmd.Run()
+	return b.Bytes(), err
 }
 
 func (cmd *cmdWrapper) Output() ([]byte, error) {
-	out, err := (*osexec.Cmd)(cmd).Output()
-	return out, handleError(err)
+	if cmd.Stdout != nil {
+		return nil, errors.New("exec: Stdout already set")
+	}
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.outputStderr = cmd.Stderr == nil
+	err := cmd.Run()
+	return stdout.Bytes(), err
 }
 
 // Stop is part of the Cmd interface.
 func (cmd *cmdWrapper) Stop() {
-	c := (*osexec.Cmd)(cmd)
+	if cmd.Process == nil {
+		return
+	}
 
-	if c.Process == nil {
+	cmd.lock.Lock()
+	defer cmd.lock.Unlock()
+	if cmd.waited {
 		return
 	}
 
-	c.Process.Signal(syscall.SIGTERM)
+	cmd.signal(syscall.SIGTERM)
 
-	time.AfterFunc(10*time.Second, func() {
-		if !c.ProcessState.Exited() {
-			c.Process.Signal(syscall.SIGKILL)
-		}
-	})
+	if cmd.stopTimer == nil {
+		cmd.stopTimer = time.AfterFunc(10*time.Second, func() {
+			cmd.lock.Lock()
+			defer cmd.lock.Unlock()
+			if !cmd.waited {
+				cmd.signal(syscall.SIGKILL)
+			}
+		})
+	}
+}
+
+// cancel signals the process as signal does once its context is done, unless
+// it has exited.
+func (cmd *cmdWrapper) cancel(sig syscall.Signal) error {
+	cmd.lock.Lock()
+	defer cmd.lock.Unlock()
+	if cmd.waited {
+		return os.ErrProcessDone
+	}
+	return cmd.signal(sig)
+}
+
+// signal sends sig to the process, or to its process group if KillChildren
+// was set. It must be called with lock held.
+func (cmd *cmdWrapper) signal(sig syscall.Signal) error {
+	if cmd.group != nil {
+		return cmd.group.signal(sig)
+	}
+	return cmd.Process.Signal(sig)
 }
 
 func handleError(err error) error {
@@ -211,6 +618,118 @@ func handleError(err error) error {
 	return err
 }
 
+// stderrTailSize is the number of trailing bytes of stderr kept for
+// CommandError.
+const stderrTailSize = 32 * 1024
+
+// tailWriter keeps the last bytes written to it, up to a maximum size.
+type tailWriter struct {
+	size int
+	// buf ends with the bytes kept. It may hold up to twice size bytes, so
+	// that dropping the bytes before the tail only needs a copy once every
+	// size bytes written.
+	buf []byte
+	// written is the total number of bytes written.
+	written int64
+}
+
+func newTailWriter(size int) *tailWriter {
+	return &tailWriter{size: size}
+}
+
+func (w *tailWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	w.written += int64(n)
+	if w.size <= 0 {
+		return n, nil
+	}
+	if len(p) > w.size {
+		p = p[len(p)-w.size:]
+	}
+	if len(w.buf)+len(p) > 2*w.size {
+		keep := w.size - len(p)
+		w.buf = w.buf[:copy(w.buf, w.buf[len(w.buf)-keep:])]
+	}
+	w.buf = append(w.buf, p...)
+	return n, nil
+}
+
+// Bytes returns a copy of the bytes kept, or nil if there are none.
+func (w *tailWriter) Bytes() []byte {
+	tail := w.buf
+	if len(tail) > w.size {
+		tail = tail[len(tail)-w.size:]
+	}
+	if len(tail) == 0 {
+		return nil
+	}
+	return append([]byte(nil), tail...)
+}
+
+// CommandError is returned by Run, Output, CombinedOutput and Wait of the Cmds
+// created by CommandWithOptions with DetailedErrors set when a command exits
+// unsuccessfully, so that callers don't need to parse the error message to
+// find out why. Other Cmds keep returning an *ExitErrorWrapper. It is an
+// ExitError, and unwraps to the underlying *ExitErrorWrapper.
+type CommandError struct {
+	// ExitCode is the exit status of the command, or -1 if it was killed by
+	// a signal.
+	ExitCode int
+	// Signal is the signal which killed the command, or 0.
+	Signal syscall.Signal
+	// StderrTail holds up to the last 32 KiB of the standard error of the
+	// command; of its combined output for CombinedOutput. It is only
+	// captured by Output, CombinedOutput, and when stderr was set to a
+	// writer other than an *os.File.
+	StderrTail []byte
+	// Err is the underlying error.
+	Err *ExitErrorWrapper
+}
+
+var _ ExitError = &CommandError{}
+
+func (e *CommandError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CommandError) String() string {
+	return e.Err.String()
+}
+
+// Exited is part of the ExitError interface.
+func (e *CommandError) Exited() bool {
+	return e.Err.Exited()
+}
+
+// ExitStatus is part of the ExitError interface.
+func (e *CommandError) ExitStatus() int {
+	return e.ExitCode
+}
+
+// Unwrap returns the underlying *ExitErrorWrapper.
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// TimeoutError is returned by a Cmd which was killed because it ran for longer
+// than the timeout set with SetTimeout. A command which exits on its own just
+// as its timeout passes is not reported as timed out.
+type TimeoutError struct {
+	// Timeout is the timeout that was exceeded.
+	Timeout time.Duration
+	// Err is the error the command failed with once it was killed.
+	Err error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("command timed out after %v", e.Timeout)
+}
+
+// Unwrap returns the error the command failed with once it was killed.
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
 // ExitErrorWrapper is an implementation of ExitError in terms of os/exec ExitError.
 // Note: standard exec.ExitError is type *os.ProcessState, which already implements Exited().
 type ExitErrorWrapper struct {
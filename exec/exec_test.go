@@ -17,13 +17,21 @@ limitations under the License.
 package exec
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	osexec "os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
 	"testing"
 	"time"
+
+	utiltrace "k8s.io/utils/trace"
 )
 
 func TestExecutorNoArgs(t *testing.T) {
@@ -143,6 +151,217 @@ func TestTimeout(t *testing.T) {
 	}
 }
 
+func TestSetTimeout(t *testing.T) {
+	ex := New()
+
+	run := map[string]func(cmd Cmd) error{
+		"Run": func(cmd Cmd) error {
+			return cmd.Run()
+		},
+		"Output": func(cmd Cmd) error {
+			_, err := cmd.Output()
+			return err
+		},
+		"CombinedOutput": func(cmd Cmd) error {
+			_, err := cmd.CombinedOutput()
+			return err
+		},
+	}
+	for name, f := range run {
+		cmd := ex.Command("sleep", "10")
+		SetTimeout(cmd, 10*time.Millisecond)
+		err := f(cmd)
+		te, ok := err.(*TimeoutError)
+		if !ok {
+			t.Errorf("%s: expected a TimeoutError, got %v", name, err)
+			continue
+		}
+		if te.Timeout != 10*time.Millisecond {
+			t.Errorf("%s: expected timeout of 10ms, got %v", name, te.Timeout)
+		}
+		if _, ok := te.Err.(ExitError); !ok {
+			t.Errorf("%s: expected the ExitError of the killed process, got %v", name, te.Err)
+		}
+	}
+
+	cmd := ex.Command("/bin/sh", "-c", "echo out; echo err >&2; exit 3")
+	SetTimeout(cmd, time.Minute)
+	out, err := cmd.Output()
+	if string(out) != "out\n" {
+		t.Errorf("unexpected output: %q", string(out))
+	}
+	var ee *ExitErrorWrapper
+	if !errors.As(err, &ee) || ee.ExitStatus() != 3 || string(ee.Stderr) != "err\n" {
+		t.Errorf("expected exit status 3 with stderr, got %v", err)
+	}
+
+	cmd = ex.Command("/bin/sh", "-c", "echo out; echo err >&2")
+	SetTimeout(cmd, time.Minute)
+	out, err = cmd.CombinedOutput()
+	if err != nil {
+		t.Errorf("expected success, got %v", err)
+	}
+	if string(out) != "out\nerr\n" {
+		t.Errorf("unexpected output: %q", string(out))
+	}
+}
+
+func TestSetTimeoutRace(t *testing.T) {
+	// commands exiting on their own as their timeout passes either succeed
+	// or are reported as killed, never as timed out with a successful exit
+	for i := 0; i < 50; i++ {
+		cmd := New().Command("true")
+		SetTimeout(cmd, time.Duration(i%5)*100*time.Microsecond+time.Microsecond)
+		err := cmd.Run()
+		if te, ok := err.(*TimeoutError); ok && te.Err == nil {
+			t.Fatalf("command which exited successfully reported as timed out")
+		}
+	}
+
+	// the timer firing once Wait has returned doesn't change the result
+	cmd := New().Command("true").(*cmdWrapper)
+	cmd.SetTimeout(time.Hour)
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cmd.killOnTimeout()
+	if cmd.timedOut {
+		t.Errorf("expected a command which has been waited for not to time out")
+	}
+}
+
+func TestTailWriter(t *testing.T) {
+	w := newTailWriter(5)
+	var all []byte
+	for i, s := range []string{"ab", "cdefg", "hijklmnop", "q", "r", "s", "t", "u", "v", "w", "xyz", "", "0123456789"} {
+		n, err := w.Write([]byte(s))
+		if n != len(s) || err != nil {
+			t.Fatalf("unexpected result of Write: %d, %v", n, err)
+		}
+		all = append(all, s...)
+		want := all
+		if len(want) > 5 {
+			want = want[len(want)-5:]
+		}
+		if got := w.Bytes(); string(got) != string(want) {
+			t.Errorf("after write %d: expected %q, got %q", i, want, got)
+		}
+		if len(w.buf) > 10 {
+			t.Errorf("after write %d: expected at most 10 bytes to be buffered, got %d", i, len(w.buf))
+		}
+	}
+	if w.written != int64(len(all)) {
+		t.Errorf("expected %d bytes written, got %d", len(all), w.written)
+	}
+
+	empty := newTailWriter(0)
+	empty.Write([]byte("abc"))
+	if b := empty.Bytes(); b != nil || empty.written != 3 {
+		t.Errorf("expected nothing to be kept and 3 bytes written, got %q and %d", b, empty.written)
+	}
+	if b := newTailWriter(5).Bytes(); b != nil {
+		t.Errorf("expected nil before any write, got %q", b)
+	}
+}
+
+func TestCommandError(t *testing.T) {
+	ex := New()
+	command := func(script string) Cmd {
+		return CommandWithOptions(ex, "/bin/sh", Options{DetailedErrors: true}, "-c", script)
+	}
+
+	var stderr bytes.Buffer
+	cmd := command("echo out; echo err >&2; exit 3")
+	cmd.SetStderr(&stderr)
+	err := cmd.Run()
+	var ce *CommandError
+	if !errors.As(err, &ce) {
+		t.Fatalf("expected a CommandError, got %v", err)
+	}
+	if ce.ExitCode != 3 || ce.ExitStatus() != 3 || ce.Signal != 0 {
+		t.Errorf("expected exit code 3 and no signal, got %d and %v", ce.ExitCode, ce.Signal)
+	}
+	if string(ce.StderrTail) != "err\n" || stderr.String() != "err\n" {
+		t.Errorf("expected stderr to be captured and passed on, got %q and %q", ce.StderrTail, stderr.String())
+	}
+	if err.Error() != "exit status 3" {
+		t.Errorf("unexpected error message: %q", err.Error())
+	}
+
+	out, err := command("echo out; kill -9 $$").CombinedOutput()
+	if !errors.As(err, &ce) {
+		t.Fatalf("expected a CommandError, got %v", err)
+	}
+	if ce.ExitCode != -1 || ce.Signal != syscall.SIGKILL {
+		t.Errorf("expected to be killed by SIGKILL, got exit code %d and signal %v", ce.ExitCode, ce.Signal)
+	}
+	if string(out) != "out\n" || string(ce.StderrTail) != "out\n" {
+		t.Errorf("expected the combined output to be captured, got %q and %q", out, ce.StderrTail)
+	}
+
+	// Only the tail of a long stderr is kept.
+	_, err = command("head -c 40000 /dev/zero >&2; echo end >&2; exit 1").Output()
+	if !errors.As(err, &ce) {
+		t.Fatalf("expected a CommandError, got %v", err)
+	}
+	if len(ce.StderrTail) != stderrTailSize || !bytes.HasSuffix(ce.StderrTail, []byte("end\n")) {
+		t.Errorf("expected the last %d bytes of stderr, got %d bytes", stderrTailSize, len(ce.StderrTail))
+	}
+
+	// Without DetailedErrors, the error is still an *ExitErrorWrapper.
+	stderr.Reset()
+	cmd = ex.Command("/bin/sh", "-c", "echo err >&2; exit 3")
+	cmd.SetStderr(&stderr)
+	if _, ok := cmd.Run().(*ExitErrorWrapper); !ok {
+		t.Errorf("expected an *ExitErrorWrapper from Run")
+	}
+	if stderr.String() != "err\n" {
+		t.Errorf("expected stderr to be passed on, got %q", stderr.String())
+	}
+	_, err = ex.Command("/bin/sh", "-c", "echo err >&2; exit 3").Output()
+	if ee, ok := err.(*ExitErrorWrapper); !ok || string(ee.Stderr) != "err\n" {
+		t.Errorf("expected an *ExitErrorWrapper with stderr from Output, got %#v", err)
+	}
+	if _, err = ex.Command("/bin/sh", "-c", "exit 3").CombinedOutput(); err == nil {
+		t.Errorf("expected an error from CombinedOutput")
+	} else if _, ok := err.(*ExitErrorWrapper); !ok {
+		t.Errorf("expected an *ExitErrorWrapper from CombinedOutput, got %#v", err)
+	}
+}
+
+func TestCommandContextTrace(t *testing.T) {
+	tr := utiltrace.New("test")
+	ctx := utiltrace.ContextWithTrace(context.Background(), tr)
+	ex := New()
+
+	if err := ex.CommandContext(ctx, "/bin/sh", "-c", "sleep 0.01").Run(); err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if err := ex.CommandContext(ctx, "false").Run(); err == nil {
+		t.Fatalf("expected failure")
+	}
+	if err := ex.CommandContext(context.Background(), "true").Run(); err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+
+	steps := tr.Steps()
+	if len(steps) != 2 {
+		t.Fatalf("expected 2 nested traces, got %+v", steps)
+	}
+	for i, cmd := range []string{"/bin/sh", "false"} {
+		step := steps[i]
+		if step.Nested == nil || step.Msg != "Exec" || len(step.Fields) != 1 || !strings.HasSuffix(fmt.Sprint(step.Fields[0].Value), cmd) {
+			t.Errorf("expected a nested trace for %s, got %+v", cmd, step)
+		}
+	}
+	if steps[0].Duration < 10*time.Millisecond || steps[0].Nested.Err() != nil {
+		t.Errorf("expected a successful command of at least 10ms, got %v and %v", steps[0].Duration, steps[0].Nested.Err())
+	}
+	if steps[1].Nested.Err() == nil {
+		t.Errorf("expected the error of the failed command to be recorded")
+	}
+}
+
 func TestSetEnv(t *testing.T) {
 	ex := New()
 
@@ -165,6 +384,53 @@ func TestSetEnv(t *testing.T) {
 	}
 }
 
+func TestCommandWithOptions(t *testing.T) {
+	dir := t.TempDir()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if _, err := w.WriteString("extra"); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	cmd := CommandWithOptions(New(), "/bin/sh", Options{
+		Env:        []string{"FOOBAR=baz"},
+		Dir:        dir,
+		Stdin:      strings.NewReader("in"),
+		ExtraFiles: []*os.File{r},
+	}, "-c", `echo "$FOOBAR $(pwd) $(cat) $(cat <&3)"`)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("expected success, got %+v", err)
+	}
+	// pwd may report dir through a symlink, so resolve it as the shell does
+	wd, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "baz " + wd + " in extra\n"; string(out) != want {
+		t.Errorf("expected %q, got %q", want, string(out))
+	}
+
+	// Interfaces without CommandWithOptions get the options through the
+	// setters of Cmd
+	cmd = CommandWithOptions(struct{ Interface }{New()}, "/bin/sh", Options{
+		Env:   []string{"FOOBAR=baz"},
+		Dir:   dir,
+		Stdin: strings.NewReader("in"),
+	}, "-c", `echo "$FOOBAR $(pwd) $(cat)"`)
+	out, err = cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("expected success, got %+v", err)
+	}
+	if want := "baz " + wd + " in\n"; string(out) != want {
+		t.Errorf("expected %q, got %q", want, string(out))
+	}
+}
+
 func TestStdIOPipes(t *testing.T) {
 	cmd := New().Command("/bin/sh", "-c", "echo 'OUT'>&1; echo 'ERR'>&2")
 
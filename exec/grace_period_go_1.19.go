@@ -0,0 +1,29 @@
+//go:build !go1.20
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	osexec "os/exec"
+	"syscall"
+	"time"
+)
+
+// setGracePeriod does nothing: before Go 1.20, os/exec always kills a command
+// with SIGKILL as soon as its context is done.
+func setGracePeriod(c *osexec.Cmd, d time.Duration, signal func(syscall.Signal) error) {}
@@ -0,0 +1,48 @@
+//go:build go1.20
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	osexec "os/exec"
+	"syscall"
+	"time"
+)
+
+// setGracePeriod makes c, which must have been created with a context, get
+// SIGTERM through signal when its context is done and SIGKILL after d.
+func setGracePeriod(c *osexec.Cmd, d time.Duration, signal func(syscall.Signal) error) {
+	if d <= 0 {
+		c.Cancel = func() error {
+			return signal(syscall.SIGKILL)
+		}
+		c.WaitDelay = 0
+		return
+	}
+	c.Cancel = func() error {
+		if err := signal(syscall.SIGTERM); err != nil {
+			// SIGTERM can't be sent on Windows
+			return signal(syscall.SIGKILL)
+		}
+		// WaitDelay only kills the process itself, not the rest of its
+		// process group.
+		time.AfterFunc(d, func() { signal(syscall.SIGKILL) })
+		return nil
+	}
+	c.WaitDelay = d
+}
@@ -0,0 +1,88 @@
+//go:build go1.20
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestSetGracePeriod(t *testing.T) {
+	tests := []struct {
+		name           string
+		script         string
+		gracePeriod    time.Duration
+		expectedOutput string
+		expectedSignal syscall.Signal
+	}{
+		{
+			name:           "no grace period",
+			script:         "trap 'echo term; exit 0' TERM; echo ready; while :; do sleep 0.01; done",
+			expectedOutput: "ready\n",
+			expectedSignal: syscall.SIGKILL,
+		},
+		{
+			name:           "exits on SIGTERM",
+			script:         "trap 'echo term; exit 0' TERM; echo ready; while :; do sleep 0.01; done",
+			gracePeriod:    time.Minute,
+			expectedOutput: "ready\nterm\n",
+		},
+		{
+			name:           "ignores SIGTERM",
+			script:         "trap '' TERM; echo ready; while :; do sleep 0.01; done",
+			gracePeriod:    100 * time.Millisecond,
+			expectedOutput: "ready\n",
+			expectedSignal: syscall.SIGKILL,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var out bytes.Buffer
+			cmd := New().CommandContext(ctx, "/bin/sh", "-c", tt.script)
+			SetGracePeriod(cmd, tt.gracePeriod)
+			cmd.SetStdout(&out)
+			time.AfterFunc(200*time.Millisecond, cancel)
+			err := cmd.Run()
+
+			if out.String() != tt.expectedOutput {
+				t.Errorf("expected output %q, got %q", tt.expectedOutput, out.String())
+			}
+			if tt.expectedSignal == 0 {
+				if !errors.Is(err, context.Canceled) {
+					t.Errorf("expected the context error, got %v", err)
+				}
+				return
+			}
+			ee, ok := err.(*ExitErrorWrapper)
+			if !ok {
+				t.Fatalf("expected an ExitErrorWrapper, got %v", err)
+			}
+			if ws := ee.Sys().(syscall.WaitStatus); !ws.Signaled() || ws.Signal() != tt.expectedSignal {
+				t.Errorf("expected to be killed by %v, got %v", tt.expectedSignal, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrSkipCommand can be returned by Interceptor.Before to skip running a
+// command, which then succeeds without output. This allows implementing
+// dry-run modes.
+var ErrSkipCommand = errors.New("exec: skip command")
+
+// Interceptor holds hooks called around the commands of an Interface created
+// by NewIntercepted. Either hook may be nil.
+type Interceptor struct {
+	// Before is called before a command is run. If it returns an error other
+	// than ErrSkipCommand, the command is not run and fails with that error.
+	Before func(cmd string, args []string) error
+	// After is called once a command has completed, or failed to start,
+	// with how long it ran and the error it failed with.
+	After func(cmd string, args []string, duration time.Duration, err error)
+}
+
+// NewIntercepted returns an Interface which runs commands with inner, calling
+// the hooks of interceptors around Run, Output, CombinedOutput, and Start and
+// Wait. Before hooks are called in order and After hooks in reverse order, so
+// the first interceptor wraps all others. If a Before hook fails, the
+// remaining Before hooks are not called, but the After hooks of the
+// interceptors whose Before hooks were called are.
+func NewIntercepted(inner Interface, interceptors ...Interceptor) Interface {
+	return &interceptedExecutor{inner: inner, interceptors: interceptors}
+}
+
+type interceptedExecutor struct {
+	inner        Interface
+	interceptors []Interceptor
+}
+
+var _ CommandWithOptionsInterface = &interceptedExecutor{}
+
+// Command is part of the Interface interface.
+func (e *interceptedExecutor) Command(cmd string, args ...string) Cmd {
+	return e.wrap(e.inner.Command(cmd, args...), cmd, args)
+}
+
+// CommandContext is part of the Interface interface.
+func (e *interceptedExecutor) CommandContext(ctx context.Context, cmd string, args ...string) Cmd {
+	return e.wrap(e.inner.CommandContext(ctx, cmd, args...), cmd, args)
+}
+
+// CommandWithOptions is part of the CommandWithOptionsInterface interface.
+func (e *interceptedExecutor) CommandWithOptions(cmd string, opts Options, args ...string) Cmd {
+	return e.wrap(CommandWithOptions(e.inner, cmd, opts, args...), cmd, args)
+}
+
+// LookPath is part of the Interface interface.
+func (e *interceptedExecutor) LookPath(file string) (string, error) {
+	return e.inner.LookPath(file)
+}
+
+func (e *interceptedExecutor) wrap(inner Cmd, cmd string, args []string) Cmd {
+	return &interceptedCmd{Cmd: inner, interceptors: e.interceptors, cmd: cmd, args: args}
+}
+
+// interceptedCmd calls the hooks of interceptors around the Cmd it embeds.
+type interceptedCmd struct {
+	Cmd
+	interceptors []Interceptor
+	cmd          string
+	args         []string
+
+	// called is the number of interceptors whose Before hooks were called
+	// for a command started with Start.
+	called  int
+	skipped bool
+	start   time.Time
+}
+
+var _ Cmd = &interceptedCmd{}
+var _ TimeoutCmd = &interceptedCmd{}
+var _ GracePeriodCmd = &interceptedCmd{}
+
+// SetTimeout is part of the TimeoutCmd interface. It sets the timeout of the
+// embedded Cmd, if it supports timeouts.
+func (c *interceptedCmd) SetTimeout(d time.Duration) {
+	SetTimeout(c.Cmd, d)
+}
+
+// SetGracePeriod is part of the GracePeriodCmd interface. It sets the grace
+// period of the embedded Cmd, if it supports grace periods.
+func (c *interceptedCmd) SetGracePeriod(d time.Duration) {
+	SetGracePeriod(c.Cmd, d)
+}
+
+// before calls the Before hooks, returning how many were called and the error
+// which stopped them, if any.
+func (c *interceptedCmd) before() (int, error) {
+	for i, interceptor := range c.interceptors {
+		if interceptor.Before == nil {
+			continue
+		}
+		if err := interceptor.Before(c.cmd, c.args); err != nil {
+			if err == ErrSkipCommand {
+				return len(c.interceptors), err
+			}
+			return i + 1, err
+		}
+	}
+	return len(c.interceptors), nil
+}
+
+// after calls the After hooks of the first called interceptors in reverse
+// order.
+func (c *interceptedCmd) after(called int, start time.Time, err error) {
+	duration := time.Since(start)
+	for i := called - 1; i >= 0; i-- {
+		if after := c.interceptors[i].After; after != nil {
+			after(c.cmd, c.args, duration, err)
+		}
+	}
+}
+
+// intercept calls run between the hooks of the interceptors.
+func (c *interceptedCmd) intercept(run func() error) error {
+	start := time.Now()
+	called, err := c.before()
+	if err == nil {
+		err = run()
+	} else if err == ErrSkipCommand {
+		err = nil
+	}
+	c.after(called, start, err)
+	return err
+}
+
+// Run is part of the Cmd interface.
+func (c *interceptedCmd) Run() error {
+	return c.intercept(c.Cmd.Run)
+}
+
+// CombinedOutput is part of the Cmd interface.
+func (c *interceptedCmd) CombinedOutput() ([]byte, error) {
+	var out []byte
+	err := c.intercept(func() error {
+		var err error
+		out, err = c.Cmd.CombinedOutput()
+		return err
+	})
+	return out, err
+}
+
+// Output is part of the Cmd interface.
+func (c *interceptedCmd) Output() ([]byte, error) {
+	var out []byte
+	err := c.intercept(func() error {
+		var err error
+		out, err = c.Cmd.Output()
+		return err
+	})
+	return out, err
+}
+
+// Start is part of the Cmd interface. The Before hooks are called by Start and
+// the After hooks by Wait, unless the command fails to start.
+func (c *interceptedCmd) Start() error {
+	c.start = time.Now()
+	called, err := c.before()
+	if err == ErrSkipCommand {
+		c.called, c.skipped = called, true
+		return nil
+	}
+	if err == nil {
+		err = c.Cmd.Start()
+	}
+	if err != nil {
+		c.after(called, c.start, err)
+		return err
+	}
+	c.called = called
+	return nil
+}
+
+// Wait is part of the Cmd interface.
+func (c *interceptedCmd) Wait() error {
+	var err error
+	if !c.skipped {
+		err = c.Cmd.Wait()
+	}
+	c.after(c.called, c.start, err)
+	return err
+}
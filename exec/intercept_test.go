@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/utils/exec"
+	testingexec "k8s.io/utils/exec/testing"
+)
+
+// recorder returns an Interceptor appending its calls to log.
+func recorder(name string, log *[]string, beforeErr error) exec.Interceptor {
+	return exec.Interceptor{
+		Before: func(cmd string, args []string) error {
+			*log = append(*log, fmt.Sprintf("%s before %s %v", name, cmd, args))
+			return beforeErr
+		},
+		After: func(cmd string, args []string, duration time.Duration, err error) {
+			*log = append(*log, fmt.Sprintf("%s after %s %v: %v", name, cmd, args, err))
+		},
+	}
+}
+
+func TestIntercepted(t *testing.T) {
+	failed := errors.New("failed")
+	denied := errors.New("denied")
+
+	tests := []struct {
+		name        string
+		results     []error
+		beforeErrs  []error
+		expectedErr error
+		expectedOut string
+		expectedLog []string
+	}{
+		{
+			name:        "success",
+			results:     []error{nil},
+			beforeErrs:  []error{nil, nil},
+			expectedErr: nil,
+			expectedOut: "output",
+			expectedLog: []string{
+				"a before ls [-l]",
+				"b before ls [-l]",
+				"b after ls [-l]: <nil>",
+				"a after ls [-l]: <nil>",
+			},
+		},
+		{
+			name:        "command failure",
+			results:     []error{failed},
+			beforeErrs:  []error{nil, nil},
+			expectedErr: failed,
+			expectedOut: "output",
+			expectedLog: []string{
+				"a before ls [-l]",
+				"b before ls [-l]",
+				"b after ls [-l]: failed",
+				"a after ls [-l]: failed",
+			},
+		},
+		{
+			name:        "before failure",
+			results:     []error{nil},
+			beforeErrs:  []error{denied, nil},
+			expectedErr: denied,
+			expectedLog: []string{
+				"a before ls [-l]",
+				"a after ls [-l]: denied",
+			},
+		},
+		{
+			name:        "skip",
+			results:     []error{nil},
+			beforeErrs:  []error{nil, exec.ErrSkipCommand},
+			expectedErr: nil,
+			expectedLog: []string{
+				"a before ls [-l]",
+				"b before ls [-l]",
+				"b after ls [-l]: <nil>",
+				"a after ls [-l]: <nil>",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fe := scriptedResults(tt.results...)
+			var log []string
+			ex := exec.NewIntercepted(fe,
+				recorder("a", &log, tt.beforeErrs[0]),
+				recorder("b", &log, tt.beforeErrs[1]),
+			)
+			out, err := ex.Command("ls", "-l").CombinedOutput()
+			if err != tt.expectedErr {
+				t.Errorf("expected error %v, got %v", tt.expectedErr, err)
+			}
+			if string(out) != tt.expectedOut {
+				t.Errorf("expected output %q, got %q", tt.expectedOut, out)
+			}
+			if fe.CommandCalls != 1 {
+				t.Errorf("expected the command to be created once, got %d", fe.CommandCalls)
+			}
+			if !reflect.DeepEqual(log, tt.expectedLog) {
+				t.Errorf("expected calls %q, got %q", tt.expectedLog, log)
+			}
+		})
+	}
+}
+
+func TestInterceptedStartWait(t *testing.T) {
+	var log []string
+	var duration time.Duration
+	ex := exec.NewIntercepted(&testingexec.FakeExec{DisableScripts: true},
+		recorder("a", &log, nil),
+		exec.Interceptor{
+			After: func(cmd string, args []string, d time.Duration, err error) {
+				duration = d
+			},
+		},
+	)
+	cmd := ex.Command("sleep", "1")
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"a before sleep [1]"}; !reflect.DeepEqual(log, expected) {
+		t.Errorf("expected calls %q after Start, got %q", expected, log)
+	}
+	time.Sleep(time.Millisecond)
+	if err := cmd.Wait(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"a before sleep [1]", "a after sleep [1]: <nil>"}; !reflect.DeepEqual(log, expected) {
+		t.Errorf("expected calls %q after Wait, got %q", expected, log)
+	}
+	if duration < time.Millisecond {
+		t.Errorf("expected the duration to include the time between Start and Wait, got %v", duration)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"bytes"
+	"sync"
+)
+
+// LineWriter is an io.Writer which calls a function with each line written to
+// it, without the trailing newline. Set it as a Cmd's stdout or stderr to
+// process the output of a command line by line as it runs, for example to
+// report progress or forward it to a log; combine it with io.MultiWriter to
+// also capture the output. Close must be called once the command is done, to
+// pass on a final line which doesn't end with a newline.
+type LineWriter struct {
+	lock   sync.Mutex
+	fn     func(line string)
+	buffer []byte
+}
+
+// NewLineWriter returns a LineWriter which calls fn with each line.
+func NewLineWriter(fn func(line string)) *LineWriter {
+	return &LineWriter{fn: fn}
+}
+
+// Write calls the LineWriter's function with each line completed by p, and
+// keeps any incomplete line until it is completed by a later Write or Close.
+func (w *LineWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	n := len(p)
+	for {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			break
+		}
+		if len(w.buffer) > 0 {
+			w.buffer = append(w.buffer, p[:i]...)
+			w.fn(string(w.buffer))
+			w.buffer = w.buffer[:0]
+		} else {
+			w.fn(string(p[:i]))
+		}
+		p = p[i+1:]
+	}
+	w.buffer = append(w.buffer, p...)
+	return n, nil
+}
+
+// Close calls the LineWriter's function with the final line, if it wasn't
+// terminated by a newline.
+func (w *LineWriter) Close() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if len(w.buffer) > 0 {
+		w.fn(string(w.buffer))
+		w.buffer = w.buffer[:0]
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestLineWriter(t *testing.T) {
+	tests := []struct {
+		name     string
+		writes   []string
+		expected []string
+	}{
+		{"single line", []string{"a\n"}, []string{"a"}},
+		{"several lines", []string{"a\nb\nc\n"}, []string{"a", "b", "c"}},
+		{"split lines", []string{"a", "b\nc", "", "d\n"}, []string{"ab", "cd"}},
+		{"empty lines", []string{"\n\na\n"}, []string{"", "", "a"}},
+		{"unterminated", []string{"a\nb"}, []string{"a", "b"}},
+		{"nothing", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lines []string
+			w := NewLineWriter(func(line string) {
+				lines = append(lines, line)
+			})
+			for _, s := range tt.writes {
+				if n, err := w.Write([]byte(s)); n != len(s) || err != nil {
+					t.Errorf("expected %d bytes written, got %d, %v", len(s), n, err)
+				}
+			}
+			w.Close()
+			if !reflect.DeepEqual(tt.expected, lines) {
+				t.Errorf("expected lines %q, got %q", tt.expected, lines)
+			}
+		})
+	}
+}
+
+func TestLineWriterCmd(t *testing.T) {
+	var stdoutLines, stderrLines []string
+	stdout := NewLineWriter(func(line string) {
+		stdoutLines = append(stdoutLines, line)
+	})
+	stderr := NewLineWriter(func(line string) {
+		stderrLines = append(stderrLines, line)
+	})
+	captured := &bytes.Buffer{}
+
+	cmd := New().Command("/bin/sh", "-c", "echo one; echo two; echo err >&2; printf three")
+	cmd.SetStdout(io.MultiWriter(stdout, captured))
+	cmd.SetStderr(stderr)
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	stdout.Close()
+	stderr.Close()
+
+	if e, a := []string{"one", "two", "three"}, stdoutLines; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected stdout lines %q, got %q", e, a)
+	}
+	if e, a := []string{"err"}, stderrLines; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected stderr lines %q, got %q", e, a)
+	}
+	if e, a := "one\ntwo\nthree", captured.String(); e != a {
+		t.Errorf("expected captured output %q, got %q", e, a)
+	}
+}
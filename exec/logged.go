@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// Redacted replaces the arguments hidden by a Redactor in log messages.
+const Redacted = "<redacted>"
+
+// Redactor returns a copy of args with any sensitive arguments replaced by
+// Redacted, for logging.
+type Redactor func(cmd string, args []string) []string
+
+// RedactFlags returns a Redactor hiding the values of the given flags, whether
+// they are passed as "--flag value" or "--flag=value". Flags are given with
+// their leading dashes.
+func RedactFlags(flags ...string) Redactor {
+	return func(cmd string, args []string) []string {
+		redacted := make([]string, len(args))
+		copy(redacted, args)
+		for i := 0; i < len(redacted); i++ {
+			for _, flag := range flags {
+				if redacted[i] == flag && i+1 < len(redacted) {
+					i++
+					redacted[i] = Redacted
+					break
+				}
+				if strings.HasPrefix(redacted[i], flag+"=") {
+					redacted[i] = flag + "=" + Redacted
+					break
+				}
+			}
+		}
+		return redacted
+	}
+}
+
+// NewLoggedInterface returns an Interface which runs commands with inner and
+// logs each of them to log at the given verbosity once it completes, with its
+// arguments, duration, exit code and any error. The arguments are passed
+// through redactors in order before being logged.
+func NewLoggedInterface(inner Interface, log logr.Logger, verbosity int, redactors ...Redactor) Interface {
+	log = log.V(verbosity)
+	return NewIntercepted(inner, Interceptor{
+		After: func(cmd string, args []string, duration time.Duration, err error) {
+			if !log.Enabled() {
+				return
+			}
+			for _, redact := range redactors {
+				args = redact(cmd, args)
+			}
+			kv := []interface{}{"cmd", cmd, "args", args, "duration", duration}
+			var ee ExitError
+			switch {
+			case err == nil:
+				kv = append(kv, "exitCode", 0)
+			case errors.As(err, &ee):
+				kv = append(kv, "exitCode", ee.ExitStatus(), "err", err)
+			default:
+				kv = append(kv, "err", err)
+			}
+			log.Info("Ran command", kv...)
+		},
+	})
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	"k8s.io/utils/exec"
+	testingexec "k8s.io/utils/exec/testing"
+)
+
+func TestRedactFlags(t *testing.T) {
+	redact := exec.RedactFlags("--password", "-t")
+	args := []string{"login", "--password", "secret", "--user=me", "-t=token", "--password"}
+	expected := []string{"login", "--password", "<redacted>", "--user=me", "-t=<redacted>", "--password"}
+	if got := redact("tool", args); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+	if args[2] != "secret" {
+		t.Errorf("expected the arguments not to be modified, got %q", args)
+	}
+}
+
+func TestLoggedInterface(t *testing.T) {
+	tests := []struct {
+		name              string
+		verbosity         int
+		result            error
+		expectedToContain []string
+	}{
+		{
+			name:      "below verbosity",
+			verbosity: 5,
+		},
+		{
+			name:              "success",
+			verbosity:         2,
+			expectedToContain: []string{`"cmd"="mount"`, `"args"=["-o","<redacted>","/dev/sda"]`, `"exitCode"=0`},
+		},
+		{
+			name:              "exit error",
+			verbosity:         2,
+			result:            testingexec.FakeExitError{Status: 32},
+			expectedToContain: []string{`"exitCode"=32`, `"err"="exit 32"`},
+		},
+		{
+			name:              "other error",
+			verbosity:         2,
+			result:            errors.New("not found"),
+			expectedToContain: []string{`"err"="not found"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lines []string
+			log := funcr.New(func(prefix, args string) {
+				lines = append(lines, args)
+			}, funcr.Options{Verbosity: 2})
+			redact := func(cmd string, args []string) []string {
+				return []string{args[0], "<redacted>", args[2]}
+			}
+
+			ex := exec.NewLoggedInterface(scriptedResults(tt.result), log, tt.verbosity, redact)
+			ex.Command("mount", "-o", "password=secret", "/dev/sda").CombinedOutput()
+
+			if len(tt.expectedToContain) == 0 {
+				if len(lines) != 0 {
+					t.Errorf("expected no logs, got %q", lines)
+				}
+				return
+			}
+			if len(lines) != 1 {
+				t.Fatalf("expected one line, got %q", lines)
+			}
+			for _, s := range tt.expectedToContain {
+				if !strings.Contains(lines[0], s) {
+					t.Errorf("expected output to contain %s, got:\n%s", s, lines[0])
+				}
+			}
+			if strings.Contains(lines[0], "secret") {
+				t.Errorf("expected the password to be redacted, got:\n%s", lines[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+//go:build linux && go1.20
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestKillChildrenContext(t *testing.T) {
+	for _, gracePeriod := range []time.Duration{0, time.Minute} {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		cmd := CommandWithOptions(New(), "/bin/sh", Options{Context: ctx, KillChildren: true}, "-c", "trap 'exit 0' TERM; sleep 100 >/dev/null 2>&1 & echo $!; wait")
+		SetGracePeriod(cmd, gracePeriod)
+		time.AfterFunc(200*time.Millisecond, cancel)
+		out, err := cmd.Output()
+		if err == nil {
+			t.Errorf("grace period %v: expected the command to be cancelled", gracePeriod)
+		}
+		child, err := strconv.Atoi(strings.TrimSpace(string(out)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		stopped := false
+		for i := 0; i < 100 && !stopped; i++ {
+			stopped = !running(child)
+			time.Sleep(10 * time.Millisecond)
+		}
+		if !stopped {
+			t.Errorf("grace period %v: expected the child process to be signalled", gracePeriod)
+			syscall.Kill(child, syscall.SIGKILL)
+		}
+	}
+}
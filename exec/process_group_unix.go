@@ -0,0 +1,65 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"os"
+	osexec "os/exec"
+	"sync"
+	"syscall"
+)
+
+// processGroup is the process group a command was started in.
+type processGroup struct {
+	lock sync.Mutex
+	// pgid is zero once closed.
+	pgid int
+}
+
+// prepareProcessGroup makes c start in a new process group.
+func prepareProcessGroup(c *osexec.Cmd) {
+	if c.SysProcAttr == nil {
+		c.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	c.SysProcAttr.Setpgid = true
+}
+
+// startProcessGroup returns the process group of c, once started.
+func startProcessGroup(c *osexec.Cmd) (*processGroup, error) {
+	return &processGroup{pgid: c.Process.Pid}, nil
+}
+
+// signal sends sig to all of the processes in the group. It does nothing once
+// the group is closed, since the process group ID may then be reused.
+func (g *processGroup) signal(sig syscall.Signal) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if g.pgid == 0 {
+		return os.ErrProcessDone
+	}
+	return syscall.Kill(-g.pgid, sig)
+}
+
+// close stops signal from signalling the group, once its leader has been
+// reaped.
+func (g *processGroup) close() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.pgid = 0
+}
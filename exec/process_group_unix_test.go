@@ -0,0 +1,138 @@
+//go:build linux
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// running returns true if pid is running and not a zombie.
+func running(pid int) bool {
+	stat, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat")
+	if err != nil {
+		return false
+	}
+	// The state follows the command name, which is in parentheses.
+	fields := strings.Fields(string(stat[strings.LastIndexByte(string(stat), ')')+1:]))
+	return len(fields) > 0 && fields[0] != "Z"
+}
+
+func TestKillChildren(t *testing.T) {
+	for _, killChildren := range []bool{false, true} {
+		cmd := CommandWithOptions(New(), "/bin/sh", Options{KillChildren: killChildren}, "-c", "sleep 100 >/dev/null & echo $!; wait")
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := cmd.Start(); err != nil {
+			t.Fatal(err)
+		}
+		line, err := bufio.NewReader(stdout).ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		child, err := strconv.Atoi(strings.TrimSpace(line))
+		if err != nil {
+			t.Fatal(err)
+		}
+		cmd.Stop()
+		cmd.Wait()
+
+		stopped := false
+		for i := 0; i < 100 && !stopped; i++ {
+			stopped = !running(child)
+			time.Sleep(10 * time.Millisecond)
+		}
+		if stopped != killChildren {
+			t.Errorf("KillChildren %v: expected child stopped to be %v, got %v", killChildren, killChildren, stopped)
+		}
+		if !stopped {
+			syscall.Kill(child, syscall.SIGKILL)
+		}
+	}
+}
+
+func TestKillChildrenTimeout(t *testing.T) {
+	cmd := CommandWithOptions(New(), "/bin/sh", Options{KillChildren: true}, "-c", "sleep 100 >/dev/null 2>&1 & echo $!; wait")
+	SetTimeout(cmd, 200*time.Millisecond)
+	out, err := cmd.Output()
+	var te *TimeoutError
+	if !errors.As(err, &te) {
+		t.Errorf("expected a TimeoutError, got %v", err)
+	}
+	child, err := strconv.Atoi(strings.TrimSpace(string(out)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stopped := false
+	for i := 0; i < 100 && !stopped; i++ {
+		stopped = !running(child)
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !stopped {
+		t.Errorf("expected the timeout to kill the child process")
+		syscall.Kill(child, syscall.SIGKILL)
+	}
+}
+
+func TestProcessGroupClosed(t *testing.T) {
+	cmd := CommandWithOptions(New(), "sleep", Options{KillChildren: true}, "100").(*cmdWrapper)
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer cmd.Wait()
+	defer cmd.Process.Kill()
+
+	// a closed group is never signalled, since its ID may have been reused
+	cmd.group.close()
+	if err := cmd.group.signal(syscall.SIGKILL); err != os.ErrProcessDone {
+		t.Errorf("expected %v, got %v", os.ErrProcessDone, err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if !running(cmd.Process.Pid) {
+		t.Errorf("expected the process not to be signalled")
+	}
+}
+
+func TestWaitExited(t *testing.T) {
+	cmd := CommandWithOptions(New(), "true", Options{KillChildren: true}).(*cmdWrapper)
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if !waitExited(cmd.Process.Pid) {
+		t.Fatalf("expected to wait for the process")
+	}
+	// the process has exited but is not reaped yet
+	if _, err := os.Stat("/proc/" + strconv.Itoa(cmd.Process.Pid)); err != nil || running(cmd.Process.Pid) {
+		t.Errorf("expected a zombie process, got %v", err)
+	}
+	if err := cmd.Wait(); err != nil {
+		t.Errorf("expected success, got %v", err)
+	}
+	if err := cmd.group.signal(syscall.SIGKILL); err != os.ErrProcessDone {
+		t.Errorf("expected the group to be closed, got %v", err)
+	}
+}
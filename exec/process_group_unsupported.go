@@ -0,0 +1,44 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd && !windows
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"os"
+	osexec "os/exec"
+	"syscall"
+)
+
+// processGroup only holds the process of a command on platforms without
+// support for process groups.
+type processGroup struct {
+	process *os.Process
+}
+
+func prepareProcessGroup(c *osexec.Cmd) {}
+
+func startProcessGroup(c *osexec.Cmd) (*processGroup, error) {
+	return &processGroup{process: c.Process}, nil
+}
+
+// signal sends sig to the process only.
+func (g *processGroup) signal(sig syscall.Signal) error {
+	return g.process.Signal(sig)
+}
+
+func (g *processGroup) close() {}
@@ -0,0 +1,111 @@
+//go:build windows
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"sync"
+	"syscall"
+)
+
+var (
+	modkernel32                  = syscall.NewLazyDLL("kernel32.dll")
+	procCreateJobObjectW         = modkernel32.NewProc("CreateJobObjectW")
+	procAssignProcessToJobObject = modkernel32.NewProc("AssignProcessToJobObject")
+	procTerminateJobObject       = modkernel32.NewProc("TerminateJobObject")
+
+	modntdll            = syscall.NewLazyDLL("ntdll.dll")
+	procNtResumeProcess = modntdll.NewProc("NtResumeProcess")
+)
+
+const (
+	processSetQuota      = 0x0100
+	processTerminate     = 0x0001
+	processSuspendResume = 0x0800
+
+	createSuspended = 0x00000004
+)
+
+// processGroup is the job object a command was assigned to. Processes started
+// by the command are assigned to the job as well.
+type processGroup struct {
+	lock sync.Mutex
+	// job is zero once closed.
+	job syscall.Handle
+}
+
+// prepareProcessGroup makes c start suspended, so that it can be assigned to
+// a job before it starts any process.
+func prepareProcessGroup(c *osexec.Cmd) {
+	if c.SysProcAttr == nil {
+		c.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	c.SysProcAttr.CreationFlags |= createSuspended
+}
+
+// startProcessGroup assigns c, once started suspended, to a new job object and
+// resumes it.
+func startProcessGroup(c *osexec.Cmd) (*processGroup, error) {
+	r, _, err := procCreateJobObjectW.Call(0, 0)
+	if r == 0 {
+		return nil, fmt.Errorf("failed to create job object: %v", err)
+	}
+	job := syscall.Handle(r)
+	process, err := syscall.OpenProcess(processSetQuota|processTerminate|processSuspendResume, false, uint32(c.Process.Pid))
+	if err != nil {
+		syscall.CloseHandle(job)
+		return nil, fmt.Errorf("failed to open process %d: %v", c.Process.Pid, err)
+	}
+	defer syscall.CloseHandle(process)
+	if r, _, err := procAssignProcessToJobObject.Call(uintptr(job), uintptr(process)); r == 0 {
+		syscall.CloseHandle(job)
+		return nil, fmt.Errorf("failed to assign process %d to job object: %v", c.Process.Pid, err)
+	}
+	if status, _, _ := procNtResumeProcess.Call(uintptr(process)); status != 0 {
+		syscall.CloseHandle(job)
+		return nil, fmt.Errorf("failed to resume process %d: NTSTATUS %#x", c.Process.Pid, status)
+	}
+	return &processGroup{job: job}, nil
+}
+
+// signal terminates all of the processes in the job. Windows has no signals,
+// so sig is ignored.
+func (g *processGroup) signal(sig syscall.Signal) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if g.job == 0 {
+		return os.ErrProcessDone
+	}
+	if r, _, err := procTerminateJobObject.Call(uintptr(g.job), jobTerminatedExitCode); r == 0 {
+		return err
+	}
+	return nil
+}
+
+// close releases the job object.
+func (g *processGroup) close() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if g.job != 0 {
+		syscall.CloseHandle(g.job)
+		g.job = 0
+	}
+}
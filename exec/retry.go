@@ -0,0 +1,326 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// RetryPolicy configures how NewRetrying retries commands.
+type RetryPolicy struct {
+	// Attempts is the maximum number of times a command is run, including
+	// the first attempt. Values below 1 mean a single attempt.
+	Attempts int
+	// InitialBackoff is the delay before the first retry. It doubles after
+	// each retry, up to MaxBackoff.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum delay between retries. Zero means no limit.
+	MaxBackoff time.Duration
+	// Jitter adds a random delay of up to Jitter times the backoff to each
+	// retry, as in clock.Jitter.
+	Jitter float64
+	// Retryable returns true if a command which failed with err should be
+	// retried. If it is not set, commands are retried when they exit with
+	// a non-zero status, as reported by an ExitError.
+	Retryable func(err error) bool
+	// Clock is used to wait between retries. Defaults to clock.RealClock.
+	Clock clock.Clock
+}
+
+// RetryOnExitCodes returns a RetryPolicy.Retryable function which retries
+// commands exiting with one of the given codes.
+func RetryOnExitCodes(codes ...int) func(err error) bool {
+	return func(err error) bool {
+		var ee ExitError
+		if !errors.As(err, &ee) {
+			return false
+		}
+		for _, code := range codes {
+			if ee.ExitStatus() == code {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// isExitError returns true if err is an ExitError.
+func isExitError(err error) bool {
+	var ee ExitError
+	return errors.As(err, &ee)
+}
+
+// NewRetrying returns an Interface which runs commands with inner, retrying
+// them with exponential backoff as configured by policy when Run, Output or
+// CombinedOutput fail. Each attempt runs a new Cmd from inner with the same
+// settings; a stdin reader is not rewound between attempts, and stdout and
+// stderr writers receive the output of all attempts. Commands started with
+// Start are not retried. Retries stop if a command's context is done or Stop
+// is called.
+func NewRetrying(inner Interface, policy RetryPolicy) Interface {
+	if policy.Attempts < 1 {
+		policy.Attempts = 1
+	}
+	if policy.Retryable == nil {
+		policy.Retryable = isExitError
+	}
+	if policy.Clock == nil {
+		policy.Clock = clock.RealClock{}
+	}
+	return &retryingExecutor{inner: inner, policy: policy}
+}
+
+type retryingExecutor struct {
+	inner  Interface
+	policy RetryPolicy
+}
+
+var _ CommandWithOptionsInterface = &retryingExecutor{}
+
+// Command is part of the Interface interface.
+func (r *retryingExecutor) Command(cmd string, args ...string) Cmd {
+	return &retryingCmd{executor: r, cmd: cmd, args: args}
+}
+
+// CommandContext is part of the Interface interface.
+func (r *retryingExecutor) CommandContext(ctx context.Context, cmd string, args ...string) Cmd {
+	return &retryingCmd{executor: r, ctx: ctx, cmd: cmd, args: args}
+}
+
+// CommandWithOptions is part of the CommandWithOptionsInterface interface.
+func (r *retryingExecutor) CommandWithOptions(cmd string, opts Options, args ...string) Cmd {
+	return &retryingCmd{executor: r, cmd: cmd, args: args, opts: &opts}
+}
+
+// LookPath is part of the Interface interface.
+func (r *retryingExecutor) LookPath(file string) (string, error) {
+	return r.inner.LookPath(file)
+}
+
+// retryingCmd records the settings of a command, to apply them to the Cmd of
+// each attempt.
+type retryingCmd struct {
+	executor *retryingExecutor
+	ctx      context.Context
+	cmd      string
+	args     []string
+	// opts is set for commands created by CommandWithOptions.
+	opts *Options
+
+	dir     *string
+	env     []string
+	envSet  bool
+	timeout time.Duration
+	grace   time.Duration
+	stdin   io.Reader
+	stdout  io.Writer
+	stderr  io.Writer
+
+	lock sync.Mutex
+	// current is the Cmd of the current or last attempt.
+	current Cmd
+	stopped bool
+	// cancelBackoff interrupts the wait between attempts, if any.
+	cancelBackoff context.CancelFunc
+}
+
+var _ Cmd = &retryingCmd{}
+var _ TimeoutCmd = &retryingCmd{}
+var _ GracePeriodCmd = &retryingCmd{}
+
+// newCmd returns a Cmd for a new attempt.
+func (c *retryingCmd) newCmd() Cmd {
+	var cmd Cmd
+	if c.ctx != nil {
+		cmd = c.executor.inner.CommandContext(c.ctx, c.cmd, c.args...)
+	} else if c.opts != nil {
+		cmd = CommandWithOptions(c.executor.inner, c.cmd, *c.opts, c.args...)
+	} else {
+		cmd = c.executor.inner.Command(c.cmd, c.args...)
+	}
+	if c.dir != nil {
+		cmd.SetDir(*c.dir)
+	}
+	if c.envSet {
+		cmd.SetEnv(c.env)
+	}
+	if c.timeout > 0 {
+		SetTimeout(cmd, c.timeout)
+	}
+	if c.grace > 0 {
+		SetGracePeriod(cmd, c.grace)
+	}
+	if c.stdin != nil {
+		cmd.SetStdin(c.stdin)
+	}
+	if c.stdout != nil {
+		cmd.SetStdout(c.stdout)
+	}
+	if c.stderr != nil {
+		cmd.SetStderr(c.stderr)
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.current = cmd
+	return cmd
+}
+
+// cmdOnce returns the Cmd used for Start, Wait and the pipes, creating it if
+// needed.
+func (c *retryingCmd) cmdOnce() Cmd {
+	c.lock.Lock()
+	current := c.current
+	c.lock.Unlock()
+	if current != nil {
+		return current
+	}
+	return c.newCmd()
+}
+
+// retry calls run with the Cmd of each attempt until it succeeds, fails with
+// an error which isn't retryable, or runs out of attempts.
+func (c *retryingCmd) retry(run func(cmd Cmd) error) error {
+	policy := c.executor.policy
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// Stop cancels backoffCtx to interrupt the wait between attempts.
+	backoffCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	c.lock.Lock()
+	c.cancelBackoff = cancel
+	c.lock.Unlock()
+
+	backoff := policy.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := run(c.newCmd())
+		if err == nil || attempt >= policy.Attempts || !policy.Retryable(err) || c.isStopped() {
+			return err
+		}
+		if sleepErr := clock.SleepContext(backoffCtx, policy.Clock, clock.Jitter(backoff, policy.Jitter)); sleepErr != nil || c.isStopped() {
+			return err
+		}
+		backoff *= 2
+		if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+}
+
+func (c *retryingCmd) isStopped() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.stopped
+}
+
+func (c *retryingCmd) SetDir(dir string) {
+	c.dir = &dir
+}
+
+func (c *retryingCmd) SetStdin(in io.Reader) {
+	c.stdin = in
+}
+
+func (c *retryingCmd) SetStdout(out io.Writer) {
+	c.stdout = out
+}
+
+func (c *retryingCmd) SetStderr(out io.Writer) {
+	c.stderr = out
+}
+
+func (c *retryingCmd) SetEnv(env []string) {
+	c.env = env
+	c.envSet = true
+}
+
+// SetTimeout is part of the TimeoutCmd interface. The timeout applies to each
+// attempt, if the Cmds of the inner Interface support timeouts.
+func (c *retryingCmd) SetTimeout(d time.Duration) {
+	c.timeout = d
+}
+
+// SetGracePeriod is part of the GracePeriodCmd interface.
+func (c *retryingCmd) SetGracePeriod(d time.Duration) {
+	c.grace = d
+}
+
+func (c *retryingCmd) StdoutPipe() (io.ReadCloser, error) {
+	return c.cmdOnce().StdoutPipe()
+}
+
+func (c *retryingCmd) StderrPipe() (io.ReadCloser, error) {
+	return c.cmdOnce().StderrPipe()
+}
+
+func (c *retryingCmd) Start() error {
+	return c.cmdOnce().Start()
+}
+
+func (c *retryingCmd) Wait() error {
+	return c.cmdOnce().Wait()
+}
+
+// Run is part of the Cmd interface.
+func (c *retryingCmd) Run() error {
+	return c.retry(func(cmd Cmd) error {
+		return cmd.Run()
+	})
+}
+
+// CombinedOutput is part of the Cmd interface.
+func (c *retryingCmd) CombinedOutput() ([]byte, error) {
+	var out []byte
+	err := c.retry(func(cmd Cmd) error {
+		var err error
+		out, err = cmd.CombinedOutput()
+		return err
+	})
+	return out, err
+}
+
+// Output is part of the Cmd interface.
+func (c *retryingCmd) Output() ([]byte, error) {
+	var out []byte
+	err := c.retry(func(cmd Cmd) error {
+		var err error
+		out, err = cmd.Output()
+		return err
+	})
+	return out, err
+}
+
+// Stop is part of the Cmd interface. It also prevents any further retries.
+func (c *retryingCmd) Stop() {
+	c.lock.Lock()
+	c.stopped = true
+	current, cancelBackoff := c.current, c.cancelBackoff
+	c.lock.Unlock()
+	if cancelBackoff != nil {
+		cancelBackoff()
+	}
+	if current != nil {
+		current.Stop()
+	}
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"k8s.io/utils/clock"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/exec"
+	testingexec "k8s.io/utils/exec/testing"
+)
+
+// scriptedResults returns a FakeExec whose commands fail or succeed with the
+// given errors in turn.
+func scriptedResults(errs ...error) *testingexec.FakeExec {
+	fe := &testingexec.FakeExec{}
+	for _, err := range errs {
+		err := err
+		fe.CommandScript = append(fe.CommandScript, func(cmd string, args ...string) exec.Cmd {
+			fc := &testingexec.FakeCmd{
+				CombinedOutputScript: []testingexec.FakeAction{
+					func() ([]byte, []byte, error) {
+						return []byte("output"), nil, err
+					},
+				},
+			}
+			return testingexec.InitFakeCmd(fc, cmd, args...)
+		})
+	}
+	return fe
+}
+
+func TestRetrying(t *testing.T) {
+	exit1 := testingexec.FakeExitError{Status: 1}
+	exit2 := testingexec.FakeExitError{Status: 2}
+	otherErr := errors.New("other")
+
+	tests := []struct {
+		name             string
+		policy           exec.RetryPolicy
+		results          []error
+		expectedErr      error
+		expectedCalls    int
+		expectedSleeping time.Duration
+	}{
+		{
+			name:          "success",
+			policy:        exec.RetryPolicy{Attempts: 3, InitialBackoff: time.Second},
+			results:       []error{nil},
+			expectedCalls: 1,
+		},
+		{
+			name:             "retried until success",
+			policy:           exec.RetryPolicy{Attempts: 3, InitialBackoff: time.Second},
+			results:          []error{exit1, exit1, nil},
+			expectedCalls:    3,
+			expectedSleeping: 3 * time.Second,
+		},
+		{
+			name:             "out of attempts",
+			policy:           exec.RetryPolicy{Attempts: 4, InitialBackoff: time.Second, MaxBackoff: 3 * time.Second},
+			results:          []error{exit1, exit1, exit1, exit2},
+			expectedErr:      exit2,
+			expectedCalls:    4,
+			expectedSleeping: 6 * time.Second,
+		},
+		{
+			name:          "not an exit error",
+			policy:        exec.RetryPolicy{Attempts: 3, InitialBackoff: time.Second},
+			results:       []error{otherErr},
+			expectedErr:   otherErr,
+			expectedCalls: 1,
+		},
+		{
+			name:             "retryable exit code",
+			policy:           exec.RetryPolicy{Attempts: 3, InitialBackoff: time.Second, Retryable: exec.RetryOnExitCodes(2)},
+			results:          []error{exit2, exit1},
+			expectedErr:      exit1,
+			expectedCalls:    2,
+			expectedSleeping: time.Second,
+		},
+		{
+			name:          "single attempt",
+			policy:        exec.RetryPolicy{},
+			results:       []error{exit1},
+			expectedErr:   exit1,
+			expectedCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			fakeClock := testingclock.NewFakeClock(start)
+			tt.policy.Clock = fakeClock
+			fe := scriptedResults(tt.results...)
+
+			out, err := exec.NewRetrying(fe, tt.policy).Command("cmd", "arg").CombinedOutput()
+			if err != tt.expectedErr {
+				t.Errorf("expected error %v, got %v", tt.expectedErr, err)
+			}
+			if string(out) != "output" {
+				t.Errorf("expected output of the last attempt, got %q", out)
+			}
+			if fe.CommandCalls != tt.expectedCalls {
+				t.Errorf("expected %d attempts, got %d", tt.expectedCalls, fe.CommandCalls)
+			}
+			if slept := fakeClock.Since(start); slept != tt.expectedSleeping {
+				t.Errorf("expected %v of backoff, got %v", tt.expectedSleeping, slept)
+			}
+		})
+	}
+}
+
+func TestRetryingSettings(t *testing.T) {
+	var cmds []*testingexec.FakeCmd
+	fe := &testingexec.FakeExec{}
+	for i := 0; i < 2; i++ {
+		fe.CommandScript = append(fe.CommandScript, func(cmd string, args ...string) exec.Cmd {
+			fc := &testingexec.FakeCmd{
+				RunScript: []testingexec.FakeAction{
+					func() ([]byte, []byte, error) {
+						return nil, nil, testingexec.FakeExitError{Status: 1}
+					},
+				},
+			}
+			cmds = append(cmds, fc)
+			return testingexec.InitFakeCmd(fc, cmd, args...)
+		})
+	}
+
+	cmd := exec.NewRetrying(fe, exec.RetryPolicy{Attempts: 2, Clock: testingclock.NewFakeClock(time.Now())}).Command("cmd")
+	cmd.SetDir("/dir")
+	cmd.SetEnv([]string{"A=B"})
+	cmd.Run()
+	if len(cmds) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(cmds))
+	}
+	for i, fc := range cmds {
+		if len(fc.Dirs) != 1 || fc.Dirs[0] != "/dir" || len(fc.Env) != 1 || fc.Env[0] != "A=B" {
+			t.Errorf("attempt %d: expected settings to be applied, got dirs %v and env %v", i, fc.Dirs, fc.Env)
+		}
+	}
+}
+
+func TestRetryingCancelled(t *testing.T) {
+	fe := scriptedResults(testingexec.FakeExitError{Status: 1}, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	policy := exec.RetryPolicy{Attempts: 2, InitialBackoff: time.Second, Clock: testingclock.NewFakeClock(time.Now())}
+	_, err := exec.NewRetrying(fe, policy).CommandContext(ctx, "cmd").CombinedOutput()
+	if err == nil {
+		t.Errorf("expected the error of the first attempt")
+	}
+	if fe.CommandCalls != 1 {
+		t.Errorf("expected no retries once the context is done, got %d attempts", fe.CommandCalls)
+	}
+}
+
+func TestRetryingStopDuringBackoff(t *testing.T) {
+	fe := scriptedResults(testingexec.FakeExitError{Status: 1}, nil)
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	// Hide FakeClock's SleepContext, which returns at once, so that the
+	// backoff waits for a timer.
+	policy := exec.RetryPolicy{Attempts: 2, InitialBackoff: time.Hour, Clock: struct{ clock.Clock }{fakeClock}}
+	cmd := exec.NewRetrying(fe, policy).Command("cmd")
+
+	done := make(chan error)
+	go func() {
+		_, err := cmd.CombinedOutput()
+		done <- err
+	}()
+	if err := fakeClock.BlockUntilWaiters(context.Background(), 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cmd.Stop()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Errorf("expected the error of the first attempt")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Stop didn't interrupt the backoff")
+	}
+	if fe.CommandCalls != 1 {
+		t.Errorf("expected no retries after Stop, got %d attempts", fe.CommandCalls)
+	}
+}
+
+func TestRetryingStopAtEndOfBackoff(t *testing.T) {
+	fe := scriptedResults(testingexec.FakeExitError{Status: 1}, nil)
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	cmd := exec.NewRetrying(fe, exec.RetryPolicy{Attempts: 2, InitialBackoff: time.Second, Clock: fakeClock}).Command("cmd")
+	// stop the command while the backoff sleeps, just as it ends
+	fakeClock.AddStepHook(func(oldTime, newTime time.Time) {
+		cmd.Stop()
+	})
+	if _, err := cmd.CombinedOutput(); err == nil {
+		t.Errorf("expected the error of the first attempt")
+	}
+	if fe.CommandCalls != 1 {
+		t.Errorf("expected no retries after Stop, got %d attempts", fe.CommandCalls)
+	}
+}
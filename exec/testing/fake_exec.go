@@ -20,7 +20,10 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"regexp"
+	"strings"
 	"sync"
+	"time"
 
 	"k8s.io/utils/exec"
 )
@@ -28,24 +31,86 @@ import (
 // FakeExec is a simple scripted Interface type.
 type FakeExec struct {
 	CommandScript []FakeCommandAction
-	CommandCalls  int
-	LookPathFunc  func(string) (string, error)
+	// CommandCalls is the number of commands handled by CommandScript, and
+	// the index of the next one to use. Commands handled by CommandMatchers
+	// are not counted; see FakeCommandMatcher.Calls for them.
+	CommandCalls int
+	// CommandMatchers are tried in order before CommandScript, so that
+	// commands can be scripted by their arguments rather than by the order
+	// they are run in. The first one matching a command handles it. Commands
+	// handled by a matcher are neither counted in CommandCalls nor checked
+	// by ExactOrder.
+	CommandMatchers []FakeCommandMatcher
+	LookPathFunc    func(string) (string, error)
 	// ExactOrder enforces that commands are called in the order they are scripted,
-	// and with the exact same arguments
+	// and with the exact same arguments. It only applies to commands handled by
+	// CommandScript, not to those handled by CommandMatchers.
 	ExactOrder bool
 	// DisableScripts removes the requirement that CommandScripts be populated
 	// before calling Command(). This makes Command() and subsequent calls to
 	// Run() or CombinedOutput() always return success and empty output.
 	DisableScripts bool
+	// CommandOptions records the options passed to CommandWithOptions, in
+	// call order.
+	CommandOptions []exec.Options
 
 	mu sync.Mutex
 }
 
 var _ exec.Interface = &FakeExec{}
+var _ exec.CommandWithOptionsInterface = &FakeExec{}
 
 // FakeCommandAction is the function to be executed
 type FakeCommandAction func(cmd string, args ...string) exec.Cmd
 
+// FakeCommandMatcher handles the commands selected by Match with Action.
+type FakeCommandMatcher struct {
+	Match  ArgvMatcher
+	Action FakeCommandAction
+	// Times is the number of commands the matcher handles, after which it
+	// no longer matches. Zero means no limit.
+	Times int
+	// Calls is the number of commands the matcher has handled.
+	Calls int
+}
+
+// ArgvMatcher returns true if a command and its arguments match.
+type ArgvMatcher func(cmd string, args []string) bool
+
+// MatchExact returns an ArgvMatcher matching exactly the given command and
+// arguments.
+func MatchExact(cmd string, args ...string) ArgvMatcher {
+	return func(c string, a []string) bool {
+		if c != cmd || len(a) != len(args) {
+			return false
+		}
+		for i := range args {
+			if a[i] != args[i] {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// MatchPrefix returns an ArgvMatcher matching the given command when its
+// arguments start with the given ones.
+func MatchPrefix(cmd string, args ...string) ArgvMatcher {
+	return func(c string, a []string) bool {
+		return c == cmd && len(a) >= len(args) && MatchExact(cmd, args...)(c, a[:len(args)])
+	}
+}
+
+// MatchRegexp returns an ArgvMatcher matching commands when the command and
+// its arguments, joined by spaces, match the regular expression expr. It
+// panics if expr can't be compiled.
+func MatchRegexp(expr string) ArgvMatcher {
+	re := regexp.MustCompile(expr)
+	return func(c string, a []string) bool {
+		return re.MatchString(strings.Join(append([]string{c}, a...), " "))
+	}
+}
+
 // Command returns the next unexecuted command in CommandScripts.
 // This function is safe for concurrent access as long as the underlying
 // FakeExec struct is not modified during execution.
@@ -54,6 +119,9 @@ func (fake *FakeExec) Command(cmd string, args ...string) exec.Cmd {
 		fakeCmd := &FakeCmd{DisableScripts: true}
 		return InitFakeCmd(fakeCmd, cmd, args...)
 	}
+	if action := fake.matchCommand(cmd, args); action != nil {
+		return action(cmd, args...)
+	}
 	fakeCmd := fake.nextCommand(cmd, args)
 	if fake.ExactOrder {
 		argv := append([]string{cmd}, args...)
@@ -73,6 +141,25 @@ func (fake *FakeExec) Command(cmd string, args ...string) exec.Cmd {
 	return fakeCmd
 }
 
+// matchCommand returns the action of the first of CommandMatchers matching cmd
+// and args, or nil if none does.
+func (fake *FakeExec) matchCommand(cmd string, args []string) FakeCommandAction {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+
+	for i := range fake.CommandMatchers {
+		m := &fake.CommandMatchers[i]
+		if m.Times > 0 && m.Calls >= m.Times {
+			continue
+		}
+		if m.Match(cmd, args) {
+			m.Calls++
+			return m.Action
+		}
+	}
+	return nil
+}
+
 func (fake *FakeExec) nextCommand(cmd string, args []string) exec.Cmd {
 	fake.mu.Lock()
 	defer fake.mu.Unlock()
@@ -90,6 +177,27 @@ func (fake *FakeExec) CommandContext(ctx context.Context, cmd string, args ...st
 	return fake.Command(cmd, args...)
 }
 
+// CommandWithOptions records opts in CommandOptions and returns the command
+// Command would return, with its directory, environment and stdin set from
+// opts.
+func (fake *FakeExec) CommandWithOptions(cmd string, opts exec.Options, args ...string) exec.Cmd {
+	fake.mu.Lock()
+	fake.CommandOptions = append(fake.CommandOptions, opts)
+	fake.mu.Unlock()
+
+	c := fake.Command(cmd, args...)
+	if opts.Dir != "" {
+		c.SetDir(opts.Dir)
+	}
+	if opts.Env != nil {
+		c.SetEnv(opts.Env)
+	}
+	if opts.Stdin != nil {
+		c.SetStdin(opts.Stdin)
+	}
+	return c
+}
+
 // LookPath is for finding the path of a file
 func (fake *FakeExec) LookPath(file string) (string, error) {
 	return fake.LookPathFunc(file)
@@ -112,14 +220,21 @@ type FakeCmd struct {
 	Stdout               io.Writer
 	Stderr               io.Writer
 	Env                  []string
-	StdoutPipeResponse   FakeStdIOPipeResponse
-	StderrPipeResponse   FakeStdIOPipeResponse
-	WaitResponse         error
-	StartResponse        error
-	DisableScripts       bool
+	Timeout              time.Duration
+	GracePeriod          time.Duration
+	// SimulateTimeout makes Run, CombinedOutput and Output fail with an
+	// *exec.TimeoutError after running their script, if a timeout was set.
+	SimulateTimeout    bool
+	StdoutPipeResponse FakeStdIOPipeResponse
+	StderrPipeResponse FakeStdIOPipeResponse
+	WaitResponse       error
+	StartResponse      error
+	DisableScripts     bool
 }
 
 var _ exec.Cmd = &FakeCmd{}
+var _ exec.TimeoutCmd = &FakeCmd{}
+var _ exec.GracePeriodCmd = &FakeCmd{}
 
 // InitFakeCmd is for creating a fake exec.Cmd
 func InitFakeCmd(fake *FakeCmd, cmd string, args ...string) exec.Cmd {
@@ -162,6 +277,27 @@ func (fake *FakeCmd) SetEnv(env []string) {
 	fake.Env = env
 }
 
+// SetTimeout is part of the exec.TimeoutCmd interface. It records the timeout
+// in Timeout.
+func (fake *FakeCmd) SetTimeout(d time.Duration) {
+	fake.Timeout = d
+}
+
+// SetGracePeriod is part of the exec.GracePeriodCmd interface. It records the
+// grace period in GracePeriod.
+func (fake *FakeCmd) SetGracePeriod(d time.Duration) {
+	fake.GracePeriod = d
+}
+
+// timeoutError returns the error to fail with instead of err, if a timeout is
+// being simulated.
+func (fake *FakeCmd) timeoutError(err error) error {
+	if fake.SimulateTimeout && fake.Timeout > 0 {
+		return &exec.TimeoutError{Timeout: fake.Timeout, Err: FakeExitError{Status: -1}}
+	}
+	return err
+}
+
 // StdoutPipe returns an injected ReadCloser & error (via StdoutPipeResponse)
 // to be able to inject an output stream on Stdout
 func (fake *FakeCmd) StdoutPipe() (io.ReadCloser, error) {
@@ -207,7 +343,7 @@ func (fake *FakeCmd) Run() error {
 	if stderr != nil {
 		fake.Stderr.Write(stderr)
 	}
-	return err
+	return fake.timeoutError(err)
 }
 
 // CombinedOutput returns the output from the command
@@ -225,7 +361,7 @@ func (fake *FakeCmd) CombinedOutput() ([]byte, error) {
 	fake.CombinedOutputLog = append(fake.CombinedOutputLog, append([]string{}, fake.Argv...))
 	fake.CombinedOutputCalls++
 	stdout, _, err := fake.CombinedOutputScript[i]()
-	return stdout, err
+	return stdout, fake.timeoutError(err)
 }
 
 // Output is the response from the command
@@ -243,7 +379,7 @@ func (fake *FakeCmd) Output() ([]byte, error) {
 	fake.OutputLog = append(fake.OutputLog, append([]string{}, fake.Argv...))
 	fake.OutputCalls++
 	stdout, _, err := fake.OutputScript[i]()
-	return stdout, err
+	return stdout, fake.timeoutError(err)
 }
 
 // Stop is to stop the process
@@ -17,7 +17,9 @@ limitations under the License.
 package testingexec
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"k8s.io/utils/exec"
 )
@@ -135,3 +137,110 @@ func makeFakeCmd(fakeCmd *FakeCmd, cmd string, args ...string) FakeCommandAction
 		return command
 	}
 }
+
+func TestSimulateTimeout(t *testing.T) {
+	fakeCmd := &FakeCmd{
+		RunScript: []FakeAction{
+			func() ([]byte, []byte, error) { return nil, nil, nil },
+			func() ([]byte, []byte, error) { return nil, nil, nil },
+		},
+		SimulateTimeout: true,
+	}
+	cmd := InitFakeCmd(fakeCmd, "sleep", "10")
+	if err := cmd.Run(); err != nil {
+		t.Errorf("expected no timeout without SetTimeout, got %v", err)
+	}
+
+	exec.SetTimeout(cmd, time.Second)
+	if fakeCmd.Timeout != time.Second {
+		t.Errorf("expected timeout to be recorded, got %v", fakeCmd.Timeout)
+	}
+	err := cmd.Run()
+	if te, ok := err.(*exec.TimeoutError); !ok || te.Timeout != time.Second {
+		t.Errorf("expected a TimeoutError, got %v", err)
+	}
+}
+
+func TestArgvMatchers(t *testing.T) {
+	tests := []struct {
+		name  string
+		match ArgvMatcher
+		cmd   string
+		args  []string
+		want  bool
+	}{
+		{"exact", MatchExact("ip", "link", "show"), "ip", []string{"link", "show"}, true},
+		{"exact extra arg", MatchExact("ip", "link", "show"), "ip", []string{"link", "show", "eth0"}, false},
+		{"exact wrong cmd", MatchExact("ip", "link"), "ss", []string{"link"}, false},
+		{"prefix", MatchPrefix("ip", "link"), "ip", []string{"link", "show", "eth0"}, true},
+		{"prefix equal", MatchPrefix("ip", "link"), "ip", []string{"link"}, true},
+		{"prefix too short", MatchPrefix("ip", "link", "show"), "ip", []string{"link"}, false},
+		{"prefix mismatch", MatchPrefix("ip", "addr"), "ip", []string{"link"}, false},
+		{"regexp", MatchRegexp(`^ip link show eth\d+$`), "ip", []string{"link", "show", "eth0"}, true},
+		{"regexp mismatch", MatchRegexp(`^ip link show eth\d+$`), "ip", []string{"link", "show", "lo"}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.match(tt.cmd, tt.args); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCommandMatchers(t *testing.T) {
+	output := func(out string) FakeCommandAction {
+		return func(cmd string, args ...string) exec.Cmd {
+			return InitFakeCmd(&FakeCmd{
+				OutputScript: []FakeAction{func() ([]byte, []byte, error) { return []byte(out), nil, nil }},
+			}, cmd, args...)
+		}
+	}
+	fe := &FakeExec{
+		ExactOrder: true,
+		CommandMatchers: []FakeCommandMatcher{
+			{Match: MatchExact("ip", "link", "show", "eth0"), Action: output("eth0"), Times: 1},
+			{Match: MatchPrefix("ip", "link"), Action: output("link")},
+		},
+		CommandScript: []FakeCommandAction{output("scripted")},
+	}
+
+	for i, want := range []string{"eth0", "link", "link", "scripted"} {
+		cmd := "ip"
+		args := []string{"link", "show", "eth0"}
+		if want == "scripted" {
+			cmd, args = "ps", nil
+		}
+		out, err := fe.Command(cmd, args...).Output()
+		if err != nil {
+			t.Fatalf("call %d: unexpected error: %v", i, err)
+		}
+		if string(out) != want {
+			t.Errorf("call %d: got %q, want %q", i, out, want)
+		}
+	}
+	if fe.CommandMatchers[0].Calls != 1 || fe.CommandMatchers[1].Calls != 2 {
+		t.Errorf("unexpected matcher calls: %d, %d", fe.CommandMatchers[0].Calls, fe.CommandMatchers[1].Calls)
+	}
+	if fe.CommandCalls != 1 {
+		t.Errorf("expected 1 scripted call, got %d", fe.CommandCalls)
+	}
+}
+
+func TestCommandWithOptions(t *testing.T) {
+	fake := &FakeExec{DisableScripts: true}
+	stdin := strings.NewReader("in")
+	opts := exec.Options{Env: []string{"A=b"}, Dir: "/tmp", Stdin: stdin}
+	cmd := fake.CommandWithOptions("ls", opts, "-l").(*FakeCmd)
+
+	if len(fake.CommandOptions) != 1 || fake.CommandOptions[0].Dir != "/tmp" {
+		t.Errorf("expected the options to be recorded, got %+v", fake.CommandOptions)
+	}
+	if len(cmd.Dirs) != 1 || cmd.Dirs[0] != "/tmp" {
+		t.Errorf("expected dir /tmp, got %v", cmd.Dirs)
+	}
+	if len(cmd.Env) != 1 || cmd.Env[0] != "A=b" {
+		t.Errorf("expected env A=b, got %v", cmd.Env)
+	}
+	if cmd.Stdin != stdin {
+		t.Errorf("expected stdin to be set")
+	}
+}
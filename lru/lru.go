@@ -25,7 +25,8 @@ import (
 type Key = groupcache.Key
 type EvictionFunc = func(key Key, value interface{})
 
-// Cache is a thread-safe fixed size LRU cache.
+// Cache is a thread-safe fixed size LRU cache. It stores keys and values as
+// interfaces; TypedCache avoids this when the types are known.
 type Cache struct {
 	cache *groupcache.Cache
 	lock  sync.RWMutex
@@ -70,6 +71,21 @@ func (c *Cache) Get(key Key) (value interface{}, ok bool) {
 	return c.cache.Get(key)
 }
 
+// Peek looks up a key's value from the cache without marking it as recently
+// used.
+func (c *Cache) Peek(key Key) (value interface{}, ok bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.cache.Peek(key)
+}
+
+// Contains returns true if the key is in the cache, without marking it as
+// recently used.
+func (c *Cache) Contains(key Key) bool {
+	_, ok := c.Peek(key)
+	return ok
+}
+
 // Remove removes the provided key from the cache.
 func (c *Cache) Remove(key Key) {
 	c.lock.Lock()
@@ -91,6 +107,41 @@ func (c *Cache) Len() int {
 	return c.cache.Len()
 }
 
+// Keys returns the keys in the cache, from the most to the least recently used.
+func (c *Cache) Keys() []Key {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	keys := make([]Key, 0, c.cache.Len())
+	c.cache.Range(func(key Key, _ interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Range calls f for each entry in the cache, from the most to the least
+// recently used, until f returns false. It iterates over a snapshot of the
+// cache taken before calling f, so f may use the cache, and doesn't mark any
+// entries as recently used.
+func (c *Cache) Range(f func(key Key, value interface{}) bool) {
+	type kv struct {
+		key   Key
+		value interface{}
+	}
+	c.lock.RLock()
+	entries := make([]kv, 0, c.cache.Len())
+	c.cache.Range(func(key Key, value interface{}) bool {
+		entries = append(entries, kv{key, value})
+		return true
+	})
+	c.lock.RUnlock()
+	for _, e := range entries {
+		if !f(e.key, e.value) {
+			return
+		}
+	}
+}
+
 // Clear purges all stored items from the cache.
 func (c *Cache) Clear() {
 	c.lock.Lock()
@@ -32,6 +32,7 @@ limitations under the License.
 package lru
 
 import (
+	"reflect"
 	"testing"
 	"time"
 )
@@ -158,3 +159,49 @@ func TestSetEviction(t *testing.T) {
 		t.Errorf("expected error but got none")
 	}
 }
+
+func TestPeek(t *testing.T) {
+	lru := New(2)
+	lru.Add(1, 1)
+	lru.Add(2, 2)
+	if val, ok := lru.Peek(1); !ok || val != 1 {
+		t.Errorf("expected 1 to be present, got %v, %v", val, ok)
+	}
+	if !lru.Contains(1) {
+		t.Errorf("expected cache to contain 1")
+	}
+	// peeking doesn't mark 1 as recently used, so it is evicted first
+	lru.Add(3, 3)
+	if lru.Contains(1) {
+		t.Errorf("expected 1 to be evicted")
+	}
+	if _, ok := lru.Peek(1); ok {
+		t.Errorf("expected peek of evicted key to miss")
+	}
+}
+
+func TestKeysAndRange(t *testing.T) {
+	lru := New(0)
+	lru.Add(1, 10)
+	lru.Add(2, 20)
+	lru.Add(3, 30)
+	lru.Get(1)
+
+	if e, a := []Key{1, 3, 2}, lru.Keys(); !reflect.DeepEqual(e, a) {
+		t.Errorf("expected keys %v, got %v", e, a)
+	}
+
+	var seen []interface{}
+	lru.Range(func(key Key, value interface{}) bool {
+		seen = append(seen, value)
+		// the cache may be used during iteration
+		lru.Remove(key)
+		return len(seen) < 2
+	})
+	if e, a := []interface{}{10, 30}, seen; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected values %v, got %v", e, a)
+	}
+	if e, a := []Key{2}, lru.Keys(); !reflect.DeepEqual(e, a) {
+		t.Errorf("expected keys %v, got %v", e, a)
+	}
+}
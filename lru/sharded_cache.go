@@ -0,0 +1,307 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lru
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// ShardedTypedCache is a thread-safe LRU cache which spreads its entries over a
+// number of TypedCaches, each with its own lock and an even share of the size
+// and cost limits, so that goroutines working on different keys rarely contend.
+// Since each shard enforces its own share of MaxCost, an entry which costs more
+// than that share is not kept.
+// It has the same methods as TypedCache except RemoveOldest, since each shard
+// evicts its own least recently used entries and there is no cheap way to find
+// the oldest entry overall.
+type ShardedTypedCache[K comparable, V any] struct {
+	shards []*TypedCache[K, V]
+}
+
+// NewShardedTypedCache creates a ShardedTypedCache with the given number of
+// shards, configured by opts. The number of shards is reduced if necessary so
+// that each shard gets a share of at least 1 of Size and MaxCost.
+func NewShardedTypedCache[K comparable, V any](shards int, opts TypedCacheOptions[K, V]) *ShardedTypedCache[K, V] {
+	if shards < 1 {
+		shards = 1
+	}
+	// A share of 0 would mean no limit, so every shard needs a share of at
+	// least 1.
+	if opts.Size > 0 && shards > opts.Size {
+		shards = opts.Size
+	}
+	if opts.MaxCost > 0 && int64(shards) > opts.MaxCost {
+		shards = int(opts.MaxCost)
+	}
+	c := &ShardedTypedCache[K, V]{shards: make([]*TypedCache[K, V], shards)}
+	for i := range c.shards {
+		shardOpts := opts
+		shardOpts.Size = int(splitLimit(int64(opts.Size), shards, i))
+		shardOpts.MaxCost = splitLimit(opts.MaxCost, shards, i)
+		c.shards[i] = NewTypedCacheWithOptions(shardOpts)
+	}
+	return c
+}
+
+// splitLimit returns the share of limit given to the i'th of the shards. The
+// remainder goes to the first shards, so that the shares add up to limit.
+func splitLimit(limit int64, shards, i int) int64 {
+	share := limit / int64(shards)
+	if int64(i) < limit%int64(shards) {
+		share++
+	}
+	return share
+}
+
+// hashComparable returns a hash of key. It is used to pick the shard of a key
+// before Go 1.24, which lacks maphash.Comparable. Equal keys have equal hashes.
+func hashComparable[K comparable](key K) uint64 {
+	// Go through a pointer so that interface keys keep their interface kind.
+	return mix64(hashValue(14695981039346656037, reflect.ValueOf(&key).Elem()))
+}
+
+// hashValue adds v to the FNV-1a style hash h, following the rules of == for
+// comparable types.
+func hashValue(h uint64, v reflect.Value) uint64 {
+	const prime = 1099511628211
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return (h ^ 1) * prime
+		}
+		return h * prime
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return (h ^ uint64(v.Int())) * prime
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return (h ^ v.Uint()) * prime
+	case reflect.Float32, reflect.Float64:
+		return hashFloat(h, v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		return hashFloat(hashFloat(h, real(c)), imag(c))
+	case reflect.String:
+		s := v.String()
+		for i := 0; i < len(s); i++ {
+			h = (h ^ uint64(s[i])) * prime
+		}
+		return (h ^ uint64(len(s))) * prime
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return (h ^ uint64(v.Pointer())) * prime
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			h = hashValue(h, v.Index(i))
+		}
+		return h
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			// blank fields are ignored by ==
+			if t.Field(i).Name != "_" {
+				h = hashValue(h, v.Field(i))
+			}
+		}
+		return h
+	case reflect.Interface:
+		if v.IsNil() {
+			return h * prime
+		}
+		return hashValue(h, v.Elem())
+	default:
+		return h
+	}
+}
+
+// hashFloat adds f to the hash h.
+func hashFloat(h uint64, f float64) uint64 {
+	if f == 0 {
+		// -0 == 0, so they must hash the same
+		f = 0
+	}
+	return (h ^ math.Float64bits(f)) * 1099511628211
+}
+
+// mix64 is the splitmix64 finalizer, which spreads every bit of x over the
+// whole result.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+func (c *ShardedTypedCache[K, V]) shard(key K) *TypedCache[K, V] {
+	return c.shards[shardOf(key)%uint64(len(c.shards))]
+}
+
+// SetEvictionFunc sets the function called with each entry removed from the
+// cache. It fails if one is already set.
+func (c *ShardedTypedCache[K, V]) SetEvictionFunc(f func(key K, value V)) error {
+	// Hold every shard's lock so that concurrent calls can't both succeed.
+	for _, shard := range c.shards {
+		shard.lock.Lock()
+	}
+	defer func() {
+		for _, shard := range c.shards {
+			shard.lock.Unlock()
+		}
+	}()
+	for _, shard := range c.shards {
+		if shard.onEvicted != nil {
+			return fmt.Errorf("lru cache eviction function is already set")
+		}
+	}
+	for _, shard := range c.shards {
+		shard.onEvicted = f
+	}
+	return nil
+}
+
+// Add adds a value to the cache. It expires after the cache's default TTL, if
+// any.
+func (c *ShardedTypedCache[K, V]) Add(key K, value V) {
+	c.shard(key).Add(key, value)
+}
+
+// AddWithTTL adds a value to the cache which expires after ttl. A ttl of zero
+// means the value never expires.
+func (c *ShardedTypedCache[K, V]) AddWithTTL(key K, value V, ttl time.Duration) {
+	c.shard(key).AddWithTTL(key, value, ttl)
+}
+
+// Get looks up a key's value from the cache. Expired entries are removed
+// instead of being returned.
+func (c *ShardedTypedCache[K, V]) Get(key K) (value V, ok bool) {
+	return c.shard(key).Get(key)
+}
+
+// Peek looks up a key's value from the cache without marking it as recently
+// used or counting it in the cache's Stats. Expired entries are not returned.
+func (c *ShardedTypedCache[K, V]) Peek(key K) (value V, ok bool) {
+	return c.shard(key).Peek(key)
+}
+
+// Contains returns true if the key is in the cache and hasn't expired, without
+// marking it as recently used or counting it in the cache's Stats.
+func (c *ShardedTypedCache[K, V]) Contains(key K) bool {
+	return c.shard(key).Contains(key)
+}
+
+// GetOrCompute looks up a key's value from the cache and, if it is not
+// present, calls compute to produce it and adds the result to the cache.
+// Concurrent calls for the same key share a single call to compute.
+func (c *ShardedTypedCache[K, V]) GetOrCompute(key K, compute func() (V, error)) (V, error) {
+	return c.shard(key).GetOrCompute(key, compute)
+}
+
+// Remove removes the provided key from the cache.
+func (c *ShardedTypedCache[K, V]) Remove(key K) {
+	c.shard(key).Remove(key)
+}
+
+// PurgeExpired removes all expired entries from the cache.
+func (c *ShardedTypedCache[K, V]) PurgeExpired() {
+	for _, shard := range c.shards {
+		shard.PurgeExpired()
+	}
+}
+
+// RunPurger calls PurgeExpired every interval until ctx is done. Without it,
+// expired entries are only removed when they are looked up or evicted.
+func (c *ShardedTypedCache[K, V]) RunPurger(ctx context.Context, interval time.Duration) {
+	ticker := c.shards[0].clock.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			c.PurgeExpired()
+		}
+	}
+}
+
+// Len returns the number of items in the cache, including expired items which
+// have not been removed yet.
+func (c *ShardedTypedCache[K, V]) Len() int {
+	n := 0
+	for _, shard := range c.shards {
+		n += shard.Len()
+	}
+	return n
+}
+
+// Cost returns the total cost of the items in the cache.
+func (c *ShardedTypedCache[K, V]) Cost() int64 {
+	var cost int64
+	for _, shard := range c.shards {
+		cost += shard.Cost()
+	}
+	return cost
+}
+
+// Keys returns the keys in the cache which haven't expired, shard by shard, from
+// the most to the least recently used within each shard.
+func (c *ShardedTypedCache[K, V]) Keys() []K {
+	var keys []K
+	for _, shard := range c.shards {
+		keys = append(keys, shard.Keys()...)
+	}
+	return keys
+}
+
+// Range calls f for each entry in the cache which hasn't expired, shard by
+// shard, from the most to the least recently used within each shard, until f
+// returns false. Each shard is iterated over from a snapshot taken before
+// calling f for its entries, so f may use the cache.
+func (c *ShardedTypedCache[K, V]) Range(f func(key K, value V) bool) {
+	for _, shard := range c.shards {
+		stopped := false
+		shard.Range(func(key K, value V) bool {
+			stopped = !f(key, value)
+			return !stopped
+		})
+		if stopped {
+			return
+		}
+	}
+}
+
+// Stats returns the cache's counters, summed over all shards.
+func (c *ShardedTypedCache[K, V]) Stats() Stats {
+	var stats Stats
+	for _, shard := range c.shards {
+		s := shard.Stats()
+		stats.Hits += s.Hits
+		stats.Misses += s.Misses
+		stats.Evictions += s.Evictions
+		stats.Expirations += s.Expirations
+	}
+	return stats
+}
+
+// Clear purges all stored items from the cache.
+func (c *ShardedTypedCache[K, V]) Clear() {
+	for _, shard := range c.shards {
+		shard.Clear()
+	}
+}
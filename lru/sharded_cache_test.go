@@ -0,0 +1,211 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lru
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestShardedCache(t *testing.T) {
+	var lock sync.Mutex
+	evicted := 0
+	lru := NewShardedTypedCache(4, TypedCacheOptions[string, int]{Size: 100})
+	if err := lru.SetEvictionFunc(func(key string, value int) {
+		lock.Lock()
+		defer lock.Unlock()
+		evicted++
+	}); err != nil {
+		t.Errorf("unexpected error setting eviction function: %v", err)
+	}
+	if err := lru.SetEvictionFunc(func(key string, value int) {}); err == nil {
+		t.Errorf("expected error but got none")
+	}
+
+	for i := 0; i < 50; i++ {
+		lru.Add(fmt.Sprint(i), i)
+	}
+	for i := 0; i < 50; i++ {
+		if value, ok := lru.Get(fmt.Sprint(i)); !ok || value != i {
+			t.Errorf("expected %d to be present, got %v, %v", i, value, ok)
+		}
+	}
+	if value, ok := lru.Peek("1"); !ok || value != 1 || !lru.Contains("1") {
+		t.Errorf("expected 1 to be present, got %v, %v", value, ok)
+	}
+	if keys := lru.Keys(); len(keys) != 50 {
+		t.Errorf("expected 50 keys, got %d", len(keys))
+	}
+	ranged := 0
+	lru.Range(func(key string, value int) bool {
+		ranged++
+		return ranged < 10
+	})
+	if ranged != 10 {
+		t.Errorf("expected Range to stop after 10 entries, got %d", ranged)
+	}
+	lru.Remove("0")
+	if _, ok := lru.Get("0"); ok {
+		t.Errorf("expected 0 to be removed")
+	}
+	if value, err := lru.GetOrCompute("0", func() (int, error) { return 100, nil }); err != nil || value != 100 {
+		t.Errorf("expected 100, got %v, %v", value, err)
+	}
+	if lru.Len() != 50 || lru.Cost() != 50 {
+		t.Errorf("expected 50 entries costing 50, got %d entries costing %d", lru.Len(), lru.Cost())
+	}
+	if e, a := (Stats{Hits: 50, Misses: 2}), lru.Stats(); e != a {
+		t.Errorf("expected %+v, got %+v", e, a)
+	}
+
+	// filling the cache evicts entries to stay within the size limit
+	for i := 50; i < 1000; i++ {
+		lru.Add(fmt.Sprint(i), i)
+	}
+	if lru.Len() > 100 {
+		t.Errorf("expected at most 100 entries, got %d", lru.Len())
+	}
+	lock.Lock()
+	if evicted != 1+1000-lru.Len() {
+		t.Errorf("expected %d evictions, got %d", 1+1000-lru.Len(), evicted)
+	}
+	lock.Unlock()
+
+	lru.Clear()
+	if lru.Len() != 0 {
+		t.Errorf("expected empty cache, got %d entries", lru.Len())
+	}
+}
+
+func TestShardedCacheSmallLimits(t *testing.T) {
+	testCases := []struct {
+		shards          int
+		size            int
+		maxCost         int64
+		expectedShards  int
+		expectedMaxSize int
+	}{
+		{shards: 16, size: 2, expectedShards: 2, expectedMaxSize: 2},
+		{shards: 32, size: 3, expectedShards: 3, expectedMaxSize: 3},
+		{shards: 4, size: 10, expectedShards: 4, expectedMaxSize: 10},
+		{shards: 8, maxCost: 5, expectedShards: 5, expectedMaxSize: 5},
+	}
+	for _, tc := range testCases {
+		lru := NewShardedTypedCache(tc.shards, TypedCacheOptions[int, int]{Size: tc.size, MaxCost: tc.maxCost})
+		if len(lru.shards) != tc.expectedShards {
+			t.Errorf("%d shards, size %d, max cost %d: expected %d shards, got %d", tc.shards, tc.size, tc.maxCost, tc.expectedShards, len(lru.shards))
+		}
+		for i := 0; i < 100; i++ {
+			lru.Add(i, i)
+		}
+		// the shares of the limits add up to the limits, rather than each
+		// shard rounding its share up
+		if lru.Len() > tc.expectedMaxSize {
+			t.Errorf("%d shards, size %d, max cost %d: expected at most %d entries, got %d", tc.shards, tc.size, tc.maxCost, tc.expectedMaxSize, lru.Len())
+		}
+	}
+}
+
+func TestShardedCacheSetEvictionFuncRace(t *testing.T) {
+	lru := NewShardedTypedCache(16, TypedCacheOptions[int, int]{Size: 100})
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	succeeded := 0
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := lru.SetEvictionFunc(func(key, value int) {}); err == nil {
+				lock.Lock()
+				succeeded++
+				lock.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if succeeded != 1 {
+		t.Errorf("expected exactly one SetEvictionFunc call to succeed, got %d", succeeded)
+	}
+}
+
+type hashTestKey struct {
+	s string
+	f float64
+	i int
+	p *int
+	_ int
+}
+
+func TestHashComparable(t *testing.T) {
+	x := 1
+	a := hashTestKey{s: "a", f: 0, i: 1, p: &x}
+	b := hashTestKey{s: "a", f: math.Copysign(0, -1), i: 1, p: &x}
+	if a != b || hashComparable(a) != hashComparable(b) {
+		t.Errorf("expected equal keys to hash the same")
+	}
+
+	// Interface keys need Go 1.20, so hash them with hashValue directly.
+	hashInterface := func(key interface{}) uint64 {
+		return hashValue(0, reflect.ValueOf(&key).Elem())
+	}
+	if hashInterface(nil) != hashInterface(nil) || hashInterface(a) != hashInterface(b) {
+		t.Errorf("expected equal interface keys to hash the same")
+	}
+	if hashInterface(nil) == hashInterface(a) {
+		t.Errorf("expected nil and non-nil interface keys to hash differently")
+	}
+
+	// Sequential keys must be spread over many shards, so that the cache is
+	// sharded on toolchains that use hashComparable.
+	const shards = 16
+	intShards := make(map[uint64]bool)
+	stringShards := make(map[uint64]bool)
+	structShards := make(map[uint64]bool)
+	for i := 0; i < 1000; i++ {
+		intShards[hashComparable(i)%shards] = true
+		stringShards[hashComparable(fmt.Sprint(i))%shards] = true
+		structShards[hashComparable(hashTestKey{s: "key", i: i})%shards] = true
+	}
+	for name, used := range map[string]map[uint64]bool{"int": intShards, "string": stringShards, "struct": structShards} {
+		if len(used) != shards {
+			t.Errorf("expected %s keys to use all %d shards, got %d", name, shards, len(used))
+		}
+	}
+}
+
+func BenchmarkTypedCache(b *testing.B) {
+	lru := NewTypedCache[int, int](1000)
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			lru.Add(i%2000, i)
+			lru.Get(i % 2000)
+		}
+	})
+}
+
+func BenchmarkShardedTypedCache(b *testing.B) {
+	lru := NewShardedTypedCache(16, TypedCacheOptions[int, int]{Size: 1000})
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			lru.Add(i%2000, i)
+			lru.Get(i % 2000)
+		}
+	})
+}
@@ -0,0 +1,548 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lru
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// TypedCache is a thread-safe fixed size LRU cache with typed keys and values.
+// Unlike Cache, it stores keys and values without boxing them in interfaces.
+type TypedCache[K comparable, V any] struct {
+	lock sync.Mutex
+	// maxEntries is the maximum number of entries before an entry is
+	// evicted. Zero means no limit.
+	maxEntries int
+	// maxCost is the maximum total cost of the entries before entries are
+	// evicted. Zero means no limit.
+	maxCost    int64
+	costFunc   func(key K, value V) int64
+	cost       int64
+	defaultTTL time.Duration
+	clock      clock.WithTicker
+	items      map[K]*entry[K, V]
+	// calls holds the GetOrCompute calls in progress.
+	calls     map[K]*call[V]
+	onEvicted func(key K, value V)
+	// pending holds the entries removed while the lock is held, which are
+	// passed to onEvicted once it is released.
+	pending []*entry[K, V]
+	stats   Stats
+	policy  Policy
+	// root is the sentinel of a circular doubly linked list of entries, from
+	// the most recently used (root.next) to the least recently used
+	// (root.prev). With PolicySegmentedLRU, it only holds the probationary
+	// entries.
+	root entry[K, V]
+	// protected is the sentinel of the list of protected entries, with
+	// PolicySegmentedLRU.
+	protected        entry[K, V]
+	protectedEntries int
+	protectedCost    int64
+}
+
+type entry[K comparable, V any] struct {
+	prev, next *entry[K, V]
+	key        K
+	value      V
+	cost       int64
+	// protected is true if the entry is in the protected list.
+	protected bool
+	// expires is the time after which the entry is no longer returned. The
+	// zero value means the entry never expires.
+	expires time.Time
+}
+
+// Policy selects how a TypedCache chooses the entries to evict.
+type Policy int
+
+const (
+	// PolicyLRU evicts the least recently used entries.
+	PolicyLRU Policy = iota
+	// PolicySegmentedLRU splits the cache into a probationary segment, which
+	// new entries are added to, and a protected segment, which entries are
+	// promoted to when they are used again. The protected segment holds up
+	// to 80% of the cache's size and cost, and entries leaving it are
+	// demoted back to the probationary segment. Entries are evicted from the
+	// probationary segment first, so keys which are only used once, such as
+	// during a full scan, don't evict the frequently used ones.
+	PolicySegmentedLRU
+)
+
+// protectedShare is the percentage of the size and cost limits available to
+// the protected segment with PolicySegmentedLRU.
+const protectedShare = 80
+
+// Stats holds the counters of a TypedCache.
+type Stats struct {
+	// Hits is the number of lookups which found their key.
+	Hits uint64
+	// Misses is the number of lookups which didn't find their key, including
+	// those which found it expired.
+	Misses uint64
+	// Evictions is the number of entries evicted to keep the cache within
+	// its size and cost limits.
+	Evictions uint64
+	// Expirations is the number of expired entries removed from the cache.
+	Expirations uint64
+}
+
+// call is a GetOrCompute computation in progress.
+type call[V any] struct {
+	// done is closed once value and err are set.
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// expired returns true if e has expired at now.
+func (e *entry[K, V]) expired(now time.Time) bool {
+	return !e.expires.IsZero() && now.After(e.expires)
+}
+
+// TypedCacheOptions configures a TypedCache.
+type TypedCacheOptions[K comparable, V any] struct {
+	// Size is the maximum number of entries before the least recently used
+	// entry is evicted. Zero means no limit.
+	Size int
+	// MaxCost is the maximum total cost of the entries before the least
+	// recently used entries are evicted. Entries which cost more than MaxCost
+	// on their own are not kept: adding one removes any existing entry for its
+	// key and passes the new entry straight to OnEvicted, leaving the other
+	// entries alone. Zero means no limit.
+	MaxCost int64
+	// Cost returns the cost of an entry, typically its size in bytes. If it
+	// is not set, each entry costs 1.
+	Cost func(key K, value V) int64
+	// Policy selects how entries are evicted. Defaults to PolicyLRU.
+	Policy Policy
+	// DefaultTTL is how long entries added with Add are kept before they
+	// expire. Zero means entries added with Add never expire.
+	DefaultTTL time.Duration
+	// Clock is used to expire entries. Defaults to clock.RealClock.
+	Clock clock.WithTicker
+	// OnEvicted, if set, is called with each entry removed from the cache,
+	// whether it is evicted, expired, removed or cleared. It is called
+	// without holding the cache's lock, so it may use the cache.
+	OnEvicted func(key K, value V)
+}
+
+// NewTypedCache creates a TypedCache of the given size. If size is zero, the
+// cache has no limit and it's assumed that eviction is done by the caller.
+func NewTypedCache[K comparable, V any](size int) *TypedCache[K, V] {
+	return NewTypedCacheWithOptions(TypedCacheOptions[K, V]{Size: size})
+}
+
+// NewTypedCacheWithOptions creates a TypedCache configured by opts.
+func NewTypedCacheWithOptions[K comparable, V any](opts TypedCacheOptions[K, V]) *TypedCache[K, V] {
+	c := &TypedCache[K, V]{
+		maxEntries: opts.Size,
+		maxCost:    opts.MaxCost,
+		costFunc:   opts.Cost,
+		defaultTTL: opts.DefaultTTL,
+		clock:      opts.Clock,
+		onEvicted:  opts.OnEvicted,
+		policy:     opts.Policy,
+		items:      make(map[K]*entry[K, V]),
+	}
+	if c.clock == nil {
+		c.clock = clock.RealClock{}
+	}
+	c.init()
+	return c
+}
+
+// init empties the lists of entries.
+func (c *TypedCache[K, V]) init() {
+	c.root.next = &c.root
+	c.root.prev = &c.root
+	c.protected.next = &c.protected
+	c.protected.prev = &c.protected
+	c.protectedEntries = 0
+	c.protectedCost = 0
+}
+
+// SetEvictionFunc sets the function called with each entry removed from the
+// cache. It fails if one is already set.
+func (c *TypedCache[K, V]) SetEvictionFunc(f func(key K, value V)) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.onEvicted != nil {
+		return fmt.Errorf("lru cache eviction function is already set")
+	}
+	c.onEvicted = f
+	return nil
+}
+
+// unlock releases the lock and then calls the eviction function with the
+// entries removed while it was held.
+func (c *TypedCache[K, V]) unlock() {
+	pending, onEvicted := c.pending, c.onEvicted
+	c.pending = nil
+	c.lock.Unlock()
+	for _, e := range pending {
+		onEvicted(e.key, e.value)
+	}
+}
+
+// pushFront inserts e as the most recently used entry of the list with the
+// given sentinel.
+func (c *TypedCache[K, V]) pushFront(root, e *entry[K, V]) {
+	e.prev = root
+	e.next = root.next
+	e.prev.next = e
+	e.next.prev = e
+}
+
+// unlink removes e from its list.
+func (c *TypedCache[K, V]) unlink(e *entry[K, V]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.prev = nil
+	e.next = nil
+	if e.protected {
+		e.protected = false
+		c.protectedEntries--
+		c.protectedCost -= e.cost
+	}
+}
+
+// moveToFront marks e as the most recently used entry. With
+// PolicySegmentedLRU, this promotes e to the protected segment.
+func (c *TypedCache[K, V]) moveToFront(e *entry[K, V]) {
+	if c.policy != PolicySegmentedLRU {
+		if c.root.next != e {
+			c.unlink(e)
+			c.pushFront(&c.root, e)
+		}
+		return
+	}
+	c.unlink(e)
+	c.pushFront(&c.protected, e)
+	e.protected = true
+	c.protectedEntries++
+	c.protectedCost += e.cost
+	// demote the least recently used protected entries which don't fit
+	for (c.maxEntries != 0 && c.protectedEntries*100 > c.maxEntries*protectedShare) ||
+		(c.maxCost != 0 && c.protectedCost*100 > c.maxCost*protectedShare) {
+		demoted := c.protected.prev
+		c.unlink(demoted)
+		c.pushFront(&c.root, demoted)
+	}
+}
+
+// oldest returns the next entry to evict, or nil if the cache is empty.
+func (c *TypedCache[K, V]) oldest() *entry[K, V] {
+	if e := c.root.prev; e != &c.root {
+		return e
+	}
+	if e := c.protected.prev; e != &c.protected {
+		return e
+	}
+	return nil
+}
+
+// removeEntry removes e from the cache.
+func (c *TypedCache[K, V]) removeEntry(e *entry[K, V]) {
+	c.unlink(e)
+	delete(c.items, e.key)
+	c.cost -= e.cost
+	if c.onEvicted != nil {
+		c.pending = append(c.pending, e)
+	}
+}
+
+// Add adds a value to the cache. It expires after the cache's default TTL, if
+// any.
+func (c *TypedCache[K, V]) Add(key K, value V) {
+	c.AddWithTTL(key, value, c.defaultTTL)
+}
+
+// AddWithTTL adds a value to the cache which expires after ttl. A ttl of zero
+// means the value never expires.
+func (c *TypedCache[K, V]) AddWithTTL(key K, value V, ttl time.Duration) {
+	var expires time.Time
+	if ttl > 0 {
+		expires = c.clock.Now().Add(ttl)
+	}
+	c.lock.Lock()
+	defer c.unlock()
+	cost := c.entryCost(key, value)
+	if c.maxCost != 0 && cost > c.maxCost {
+		// The entry can never fit, so drop it along with any older value for
+		// its key, rather than evicting everything else to make room for it.
+		if e, ok := c.items[key]; ok {
+			c.removeEntry(e)
+			c.stats.Evictions++
+		}
+		if c.onEvicted != nil {
+			c.pending = append(c.pending, &entry[K, V]{key: key, value: value})
+		}
+		c.stats.Evictions++
+		return
+	}
+	if e, ok := c.items[key]; ok {
+		c.moveToFront(e)
+		c.cost -= e.cost
+		// unlink and relink e to update the cost of its segment
+		c.unlink(e)
+		e.value = value
+		e.cost = cost
+		e.expires = expires
+		c.cost += e.cost
+		c.pushFront(&c.root, e)
+		c.moveToFront(e)
+		c.evict()
+		return
+	}
+	e := &entry[K, V]{key: key, value: value, cost: cost, expires: expires}
+	c.pushFront(&c.root, e)
+	c.items[key] = e
+	c.cost += e.cost
+	c.evict()
+}
+
+// entryCost returns the cost of an entry.
+func (c *TypedCache[K, V]) entryCost(key K, value V) int64 {
+	if c.costFunc == nil {
+		return 1
+	}
+	return c.costFunc(key, value)
+}
+
+// evict removes the least recently used entries until the cache is within its
+// size and cost limits.
+func (c *TypedCache[K, V]) evict() {
+	for (c.maxEntries != 0 && len(c.items) > c.maxEntries) || (c.maxCost != 0 && c.cost > c.maxCost) {
+		c.removeEntry(c.oldest())
+		c.stats.Evictions++
+	}
+}
+
+// Get looks up a key's value from the cache. Expired entries are removed
+// instead of being returned.
+func (c *TypedCache[K, V]) Get(key K) (value V, ok bool) {
+	c.lock.Lock()
+	defer c.unlock()
+	return c.get(key)
+}
+
+// get is Get with the lock held.
+func (c *TypedCache[K, V]) get(key K) (value V, ok bool) {
+	if e, hit := c.items[key]; hit {
+		if e.expired(c.clock.Now()) {
+			c.removeEntry(e)
+			c.stats.Expirations++
+			c.stats.Misses++
+			return
+		}
+		c.moveToFront(e)
+		c.stats.Hits++
+		return e.value, true
+	}
+	c.stats.Misses++
+	return
+}
+
+// Peek looks up a key's value from the cache without marking it as recently
+// used or counting it in the cache's Stats. Expired entries are not returned.
+func (c *TypedCache[K, V]) Peek(key K) (value V, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if e, hit := c.items[key]; hit && !e.expired(c.clock.Now()) {
+		return e.value, true
+	}
+	return
+}
+
+// Contains returns true if the key is in the cache and hasn't expired, without
+// marking it as recently used or counting it in the cache's Stats.
+func (c *TypedCache[K, V]) Contains(key K) bool {
+	_, ok := c.Peek(key)
+	return ok
+}
+
+// GetOrCompute looks up a key's value from the cache and, if it is not
+// present, calls compute to produce it and adds the result to the cache.
+// Concurrent calls for the same key share a single call to compute. Errors
+// are returned to all of the callers sharing the call and are not cached.
+func (c *TypedCache[K, V]) GetOrCompute(key K, compute func() (V, error)) (V, error) {
+	c.lock.Lock()
+	if value, ok := c.get(key); ok {
+		c.unlock()
+		return value, nil
+	}
+	if cl, ok := c.calls[key]; ok {
+		c.unlock()
+		<-cl.done
+		return cl.value, cl.err
+	}
+	cl := &call[V]{done: make(chan struct{})}
+	if c.calls == nil {
+		c.calls = make(map[K]*call[V])
+	}
+	c.calls[key] = cl
+	c.unlock()
+
+	defer func() {
+		c.lock.Lock()
+		delete(c.calls, key)
+		c.lock.Unlock()
+		close(cl.done)
+	}()
+	// this error is only seen by the other callers if compute panics
+	cl.err = fmt.Errorf("lru cache computation for key %v panicked", key)
+	cl.value, cl.err = compute()
+	if cl.err == nil {
+		c.Add(key, cl.value)
+	}
+	return cl.value, cl.err
+}
+
+// Remove removes the provided key from the cache.
+func (c *TypedCache[K, V]) Remove(key K) {
+	c.lock.Lock()
+	defer c.unlock()
+	if e, hit := c.items[key]; hit {
+		c.removeEntry(e)
+	}
+}
+
+// RemoveOldest removes the oldest item from the cache.
+func (c *TypedCache[K, V]) RemoveOldest() {
+	c.lock.Lock()
+	defer c.unlock()
+	if e := c.oldest(); e != nil {
+		c.removeEntry(e)
+	}
+}
+
+// PurgeExpired removes all expired entries from the cache.
+func (c *TypedCache[K, V]) PurgeExpired() {
+	c.lock.Lock()
+	defer c.unlock()
+	now := c.clock.Now()
+	for _, root := range []*entry[K, V]{&c.root, &c.protected} {
+		for e := root.prev; e != root; {
+			prev := e.prev
+			if e.expired(now) {
+				c.removeEntry(e)
+				c.stats.Expirations++
+			}
+			e = prev
+		}
+	}
+}
+
+// RunPurger calls PurgeExpired every interval until ctx is done. Without it,
+// expired entries are only removed when they are looked up or evicted.
+func (c *TypedCache[K, V]) RunPurger(ctx context.Context, interval time.Duration) {
+	ticker := c.clock.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			c.PurgeExpired()
+		}
+	}
+}
+
+// Len returns the number of items in the cache, including expired items which
+// have not been removed yet.
+func (c *TypedCache[K, V]) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.items)
+}
+
+// Cost returns the total cost of the items in the cache.
+func (c *TypedCache[K, V]) Cost() int64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.cost
+}
+
+// snapshot returns the entries which haven't expired, from the most to the
+// least recently used. With PolicySegmentedLRU, protected entries come before
+// probationary ones, in the reverse of the order they would be evicted in.
+func (c *TypedCache[K, V]) snapshot() []entry[K, V] {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.clock.Now()
+	entries := make([]entry[K, V], 0, len(c.items))
+	for _, root := range []*entry[K, V]{&c.protected, &c.root} {
+		for e := root.next; e != root; e = e.next {
+			if !e.expired(now) {
+				entries = append(entries, entry[K, V]{key: e.key, value: e.value})
+			}
+		}
+	}
+	return entries
+}
+
+// Keys returns the keys in the cache which haven't expired, from the most to
+// the least recently used.
+func (c *TypedCache[K, V]) Keys() []K {
+	entries := c.snapshot()
+	keys := make([]K, 0, len(entries))
+	for _, e := range entries {
+		keys = append(keys, e.key)
+	}
+	return keys
+}
+
+// Range calls f for each entry in the cache which hasn't expired, from the
+// most to the least recently used, until f returns false. It iterates over a
+// snapshot of the cache taken before calling f, so f may use the cache, and
+// doesn't mark any entries as recently used.
+func (c *TypedCache[K, V]) Range(f func(key K, value V) bool) {
+	for _, e := range c.snapshot() {
+		if !f(e.key, e.value) {
+			return
+		}
+	}
+}
+
+// Stats returns the cache's counters.
+func (c *TypedCache[K, V]) Stats() Stats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.stats
+}
+
+// Clear purges all stored items from the cache.
+func (c *TypedCache[K, V]) Clear() {
+	c.lock.Lock()
+	defer c.unlock()
+	if c.onEvicted != nil {
+		for _, root := range []*entry[K, V]{&c.protected, &c.root} {
+			for e := root.next; e != root; e = e.next {
+				c.pending = append(c.pending, e)
+			}
+		}
+	}
+	c.items = make(map[K]*entry[K, V])
+	c.cost = 0
+	c.init()
+}
@@ -0,0 +1,560 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lru
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+func TestTypedGet(t *testing.T) {
+	tests := []struct {
+		name       string
+		keyToAdd   simpleStruct
+		keyToGet   simpleStruct
+		expectedOk bool
+	}{
+		{"hit", simpleStruct{1, "two"}, simpleStruct{1, "two"}, true},
+		{"miss", simpleStruct{1, "two"}, simpleStruct{0, "noway"}, false},
+	}
+	for _, tt := range tests {
+		lru := NewTypedCache[simpleStruct, int](0)
+		lru.Add(tt.keyToAdd, 1234)
+		val, ok := lru.Get(tt.keyToGet)
+		if ok != tt.expectedOk {
+			t.Fatalf("%s: cache hit = %v; want %v", tt.name, ok, !ok)
+		} else if ok && val != 1234 {
+			t.Fatalf("%s expected get to return 1234 but got %v", tt.name, val)
+		}
+	}
+}
+
+func TestTypedRemove(t *testing.T) {
+	lru := NewTypedCache[string, int](0)
+	lru.Add("myKey", 1234)
+	if val, ok := lru.Get("myKey"); !ok {
+		t.Fatal("TestTypedRemove returned no match")
+	} else if val != 1234 {
+		t.Fatalf("TestTypedRemove failed.  Expected %d, got %v", 1234, val)
+	}
+
+	lru.Remove("myKey")
+	if _, ok := lru.Get("myKey"); ok {
+		t.Fatal("TestTypedRemove returned a removed entry")
+	}
+	if lru.Len() != 0 {
+		t.Errorf("expected empty cache, got %d entries", lru.Len())
+	}
+}
+
+func TestTypedEvictsLeastRecentlyUsed(t *testing.T) {
+	lru := NewTypedCache[int, int](2)
+	lru.Add(1, 1)
+	lru.Add(2, 2)
+	// 1 becomes the most recently used
+	lru.Get(1)
+	lru.Add(3, 3)
+
+	if _, ok := lru.Get(2); ok {
+		t.Errorf("expected 2 to be evicted")
+	}
+	for _, key := range []int{1, 3} {
+		if val, ok := lru.Get(key); !ok || val != key {
+			t.Errorf("expected %d to be present, got %v, %v", key, val, ok)
+		}
+	}
+
+	// updating an existing key does not evict anything
+	lru.Add(1, 10)
+	if lru.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", lru.Len())
+	}
+	if val, _ := lru.Get(1); val != 10 {
+		t.Errorf("expected updated value 10, got %d", val)
+	}
+
+	lru.RemoveOldest()
+	if _, ok := lru.Get(3); ok {
+		t.Errorf("expected 3 to be removed as the oldest entry")
+	}
+
+	lru.Clear()
+	if lru.Len() != 0 {
+		t.Errorf("expected empty cache, got %d entries", lru.Len())
+	}
+	lru.RemoveOldest()
+	lru.Add(4, 4)
+	if val, ok := lru.Get(4); !ok || val != 4 {
+		t.Errorf("expected 4 to be present after Clear, got %v, %v", val, ok)
+	}
+}
+
+func TestTypedGetRace(t *testing.T) {
+	lru := NewTypedCache[int, int](25)
+
+	done := make(chan struct{})
+	for key := 0; key < 50; key++ {
+		go func(key int) {
+			defer func() { done <- struct{}{} }()
+			for i := 0; i < 1000; i++ {
+				lru.Get(key)
+				lru.Add(key, 1)
+				lru.Get(key)
+			}
+		}(key)
+	}
+	for key := 0; key < 50; key++ {
+		<-done
+	}
+	if lru.Len() != 25 {
+		t.Errorf("expected 25 entries, got %d", lru.Len())
+	}
+}
+
+func TestTypedTTL(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	lru := NewTypedCacheWithOptions(TypedCacheOptions[string, int]{
+		DefaultTTL: time.Minute,
+		Clock:      fakeClock,
+	})
+	lru.Add("default", 1)
+	lru.AddWithTTL("short", 2, time.Second)
+	lru.AddWithTTL("forever", 3, 0)
+
+	fakeClock.Step(time.Second)
+	if _, ok := lru.Get("short"); !ok {
+		t.Errorf("expected entry to be present until its TTL has passed")
+	}
+	fakeClock.Step(time.Nanosecond)
+	if _, ok := lru.Get("short"); ok {
+		t.Errorf("expected entry to expire after its TTL")
+	}
+	if lru.Len() != 2 {
+		t.Errorf("expected expired entry to be removed on Get, got %d entries", lru.Len())
+	}
+
+	fakeClock.Step(time.Minute)
+	if _, ok := lru.Get("default"); ok {
+		t.Errorf("expected entry to expire after the default TTL")
+	}
+	if val, ok := lru.Get("forever"); !ok || val != 3 {
+		t.Errorf("expected entry without TTL not to expire, got %v, %v", val, ok)
+	}
+
+	// re-adding an entry resets its TTL
+	lru.AddWithTTL("forever", 4, time.Second)
+	fakeClock.Step(2 * time.Second)
+	if _, ok := lru.Get("forever"); ok {
+		t.Errorf("expected re-added entry to expire after its new TTL")
+	}
+}
+
+func TestTypedPurgeExpired(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	lru := NewTypedCacheWithOptions(TypedCacheOptions[int, int]{Clock: fakeClock})
+	for i := 0; i < 10; i++ {
+		lru.AddWithTTL(i, i, time.Duration(i+1)*time.Second)
+	}
+
+	fakeClock.Step(5 * time.Second)
+	lru.PurgeExpired()
+	if lru.Len() != 6 {
+		t.Errorf("expected 6 entries after purge, got %d", lru.Len())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		lru.RunPurger(ctx, time.Second)
+	}()
+	for !fakeClock.HasWaiters() {
+		time.Sleep(time.Millisecond)
+	}
+	fakeClock.Step(10 * time.Second)
+	for lru.Len() != 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+}
+
+func TestTypedEviction(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	var evicted []int
+	lru := NewTypedCacheWithOptions(TypedCacheOptions[int, int]{
+		Size:  2,
+		Clock: fakeClock,
+		OnEvicted: func(key int, value int) {
+			evicted = append(evicted, key)
+		},
+	})
+	lru.Add(1, 1)
+	lru.Add(2, 2)
+	lru.Add(3, 3) // evicts 1
+	lru.Remove(2)
+	lru.Remove(2) // not present, no callback
+	lru.AddWithTTL(4, 4, time.Second)
+	fakeClock.Step(2 * time.Second)
+	lru.Get(4) // expired
+	lru.Add(5, 5)
+	lru.Add(6, 6)
+	lru.Clear()
+
+	if e, a := []int{1, 2, 4, 3, 6, 5}, evicted; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected evictions %v, got %v", e, a)
+	}
+}
+
+func TestTypedSetEvictionFunc(t *testing.T) {
+	lru := NewTypedCache[int, int](1)
+	var seenKey, seenVal int
+	// the eviction function is called without holding the lock, so it may
+	// use the cache
+	err := lru.SetEvictionFunc(func(key int, value int) {
+		seenKey, seenVal = key, value
+		lru.Len()
+	})
+	if err != nil {
+		t.Errorf("unexpected error setting eviction function: %v", err)
+	}
+
+	lru.Add(1, 2)
+	lru.Add(3, 4)
+	if seenKey != 1 || seenVal != 2 {
+		t.Errorf("unexpected eviction data: key=%v val=%v", seenKey, seenVal)
+	}
+
+	err = lru.SetEvictionFunc(func(key int, value int) {})
+	if err == nil {
+		t.Errorf("expected error but got none")
+	}
+}
+
+func TestTypedCost(t *testing.T) {
+	var evicted []string
+	lru := NewTypedCacheWithOptions(TypedCacheOptions[string, []byte]{
+		MaxCost: 10,
+		Cost: func(key string, value []byte) int64 {
+			return int64(len(value))
+		},
+		OnEvicted: func(key string, value []byte) {
+			evicted = append(evicted, key)
+		},
+	})
+
+	lru.Add("a", make([]byte, 4))
+	lru.Add("b", make([]byte, 4))
+	if lru.Cost() != 8 {
+		t.Errorf("expected cost 8, got %d", lru.Cost())
+	}
+	lru.Get("a")
+	// evicts b, the least recently used entry
+	lru.Add("c", make([]byte, 5))
+	if e, a := []string{"b"}, evicted; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected evictions %v, got %v", e, a)
+	}
+	if lru.Cost() != 9 {
+		t.Errorf("expected cost 9, got %d", lru.Cost())
+	}
+
+	// growing an existing entry evicts others
+	lru.Add("c", make([]byte, 8))
+	if e, a := []string{"b", "a"}, evicted; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected evictions %v, got %v", e, a)
+	}
+
+	// entries larger than the maximum cost are not kept, and don't evict
+	// anything else
+	lru.Add("d", make([]byte, 11))
+	if e, a := []string{"b", "a", "d"}, evicted; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected evictions %v, got %v", e, a)
+	}
+	if lru.Len() != 1 || lru.Cost() != 8 || !lru.Contains("c") {
+		t.Errorf("expected only c to remain, got %v costing %d", lru.Keys(), lru.Cost())
+	}
+
+	// replacing an entry with one that is too large removes the old value
+	lru.Add("c", make([]byte, 11))
+	if e, a := []string{"b", "a", "d", "c", "c"}, evicted; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected evictions %v, got %v", e, a)
+	}
+	if lru.Len() != 0 || lru.Cost() != 0 {
+		t.Errorf("expected empty cache, got %d entries costing %d", lru.Len(), lru.Cost())
+	}
+
+	lru.Add("e", make([]byte, 3))
+	lru.Remove("e")
+	if lru.Cost() != 0 {
+		t.Errorf("expected cost 0 after Remove, got %d", lru.Cost())
+	}
+}
+
+func TestTypedGetOrCompute(t *testing.T) {
+	lru := NewTypedCache[string, int](0)
+	var computations int
+	release := make(chan struct{})
+	compute := func() (int, error) {
+		computations++
+		<-release
+		return 42, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]int, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			value, err := lru.GetOrCompute("key", compute)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			results[i] = value
+		}(i)
+	}
+	// wait for a computation to start and give the other callers a chance
+	// to join it
+	for {
+		lru.lock.Lock()
+		started := lru.calls["key"] != nil
+		lru.lock.Unlock()
+		if started {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if computations != 1 {
+		t.Errorf("expected 1 computation, got %d", computations)
+	}
+	for _, result := range results {
+		if result != 42 {
+			t.Errorf("expected 42, got %d", result)
+		}
+	}
+	if value, ok := lru.Get("key"); !ok || value != 42 {
+		t.Errorf("expected computed value to be cached, got %v, %v", value, ok)
+	}
+	if _, err := lru.GetOrCompute("key", func() (int, error) {
+		t.Errorf("unexpected computation for cached key")
+		return 0, nil
+	}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestTypedGetOrComputeError(t *testing.T) {
+	lru := NewTypedCache[string, int](0)
+	expectedErr := errors.New("failed")
+	if _, err := lru.GetOrCompute("key", func() (int, error) {
+		return 0, expectedErr
+	}); err != expectedErr {
+		t.Errorf("expected %v, got %v", expectedErr, err)
+	}
+	if lru.Len() != 0 {
+		t.Errorf("expected errors not to be cached")
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("expected panic to be propagated")
+			}
+		}()
+		lru.GetOrCompute("key", func() (int, error) {
+			panic("failed")
+		})
+	}()
+	// a panic doesn't leave the key stuck
+	if value, err := lru.GetOrCompute("key", func() (int, error) {
+		return 1, nil
+	}); err != nil || value != 1 {
+		t.Errorf("expected 1, got %v, %v", value, err)
+	}
+}
+
+func TestTypedStats(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	lru := NewTypedCacheWithOptions(TypedCacheOptions[int, int]{Size: 2, Clock: fakeClock})
+	lru.Add(1, 1)
+	lru.Get(1)
+	lru.Get(2)
+	lru.Add(2, 2)
+	lru.Add(3, 3) // evicts 1
+	lru.Get(1)
+	lru.GetOrCompute(3, func() (int, error) { return 3, nil })
+	lru.AddWithTTL(4, 4, time.Second) // evicts 2
+	lru.AddWithTTL(5, 5, time.Second) // evicts 3
+	fakeClock.Step(2 * time.Second)
+	lru.Get(4)
+	lru.PurgeExpired()
+	lru.Remove(1)
+
+	expected := Stats{Hits: 2, Misses: 3, Evictions: 3, Expirations: 2}
+	if actual := lru.Stats(); actual != expected {
+		t.Errorf("expected %+v, got %+v", expected, actual)
+	}
+}
+
+func TestTypedSegmentedLRU(t *testing.T) {
+	tests := []struct {
+		name           string
+		policy         Policy
+		expectedHotHit bool
+	}{
+		{"lru", PolicyLRU, false},
+		{"segmented lru", PolicySegmentedLRU, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lru := NewTypedCacheWithOptions(TypedCacheOptions[int, int]{Size: 5, Policy: tt.policy})
+			for _, key := range []int{1, 2} {
+				lru.Add(key, key)
+				lru.Get(key)
+			}
+			// a scan of keys which are only used once
+			for key := 10; key < 20; key++ {
+				lru.Add(key, key)
+			}
+			for _, key := range []int{1, 2} {
+				if _, ok := lru.Get(key); ok != tt.expectedHotHit {
+					t.Errorf("expected hit for %d to be %v", key, tt.expectedHotHit)
+				}
+			}
+			if lru.Len() != 5 {
+				t.Errorf("expected 5 entries, got %d", lru.Len())
+			}
+		})
+	}
+}
+
+func TestTypedSegmentedLRUDemotion(t *testing.T) {
+	var evicted []int
+	lru := NewTypedCacheWithOptions(TypedCacheOptions[int, int]{
+		Size:   5,
+		Policy: PolicySegmentedLRU,
+		OnEvicted: func(key int, value int) {
+			evicted = append(evicted, key)
+		},
+	})
+	// the protected segment holds up to 4 entries, so promoting 5 entries
+	// demotes the first one
+	for key := 1; key <= 5; key++ {
+		lru.Add(key, key)
+	}
+	for key := 1; key <= 5; key++ {
+		lru.Get(key)
+	}
+	lru.Add(6, 6)
+	lru.Add(7, 7)
+	if e, a := []int{1, 6}, evicted; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected evictions %v, got %v", e, a)
+	}
+
+	lru.RemoveOldest()
+	if _, ok := lru.Get(7); ok {
+		t.Errorf("expected 7 to be removed as the oldest probationary entry")
+	}
+	// with no probationary entries left, the least recently used protected
+	// entry is the oldest
+	lru.RemoveOldest()
+	if _, ok := lru.Get(2); ok {
+		t.Errorf("expected 2 to be removed as the oldest protected entry")
+	}
+	lru.Clear()
+	if lru.Len() != 0 {
+		t.Errorf("expected empty cache, got %d entries", lru.Len())
+	}
+}
+
+func TestTypedPeek(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	lru := NewTypedCacheWithOptions(TypedCacheOptions[int, int]{Size: 2, Clock: fakeClock})
+	lru.Add(1, 1)
+	lru.Add(2, 2)
+	if val, ok := lru.Peek(1); !ok || val != 1 {
+		t.Errorf("expected 1 to be present, got %v, %v", val, ok)
+	}
+	if !lru.Contains(1) || lru.Contains(3) {
+		t.Errorf("unexpected Contains results")
+	}
+	// peeking doesn't mark 1 as recently used, so it is evicted first
+	lru.Add(3, 3)
+	if lru.Contains(1) {
+		t.Errorf("expected 1 to be evicted")
+	}
+	if e, a := (Stats{Evictions: 1}), lru.Stats(); e != a {
+		t.Errorf("expected %+v, got %+v", e, a)
+	}
+
+	lru.AddWithTTL(4, 4, time.Second)
+	fakeClock.Step(2 * time.Second)
+	if lru.Contains(4) {
+		t.Errorf("expected expired entry not to be contained")
+	}
+}
+
+func TestTypedKeysAndRange(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	lru := NewTypedCacheWithOptions(TypedCacheOptions[int, int]{Clock: fakeClock})
+	lru.Add(1, 10)
+	lru.Add(2, 20)
+	lru.Add(3, 30)
+	lru.AddWithTTL(4, 40, time.Second)
+	lru.Get(1)
+	fakeClock.Step(2 * time.Second)
+
+	if e, a := []int{1, 3, 2}, lru.Keys(); !reflect.DeepEqual(e, a) {
+		t.Errorf("expected keys %v, got %v", e, a)
+	}
+
+	var seen []int
+	lru.Range(func(key int, value int) bool {
+		seen = append(seen, value)
+		// the cache may be used during iteration
+		lru.Remove(key)
+		return len(seen) < 2
+	})
+	if e, a := []int{10, 30}, seen; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected values %v, got %v", e, a)
+	}
+	if e, a := []int{2}, lru.Keys(); !reflect.DeepEqual(e, a) {
+		t.Errorf("expected keys %v, got %v", e, a)
+	}
+	if lru.Stats().Hits != 1 {
+		t.Errorf("expected iteration not to count hits, got %+v", lru.Stats())
+	}
+
+	slru := NewTypedCacheWithOptions(TypedCacheOptions[int, int]{Policy: PolicySegmentedLRU})
+	slru.Add(1, 1)
+	slru.Add(2, 2)
+	slru.Add(3, 3)
+	slru.Get(1)
+	if e, a := []int{1, 3, 2}, slru.Keys(); !reflect.DeepEqual(e, a) {
+		t.Errorf("expected keys %v, got %v", e, a)
+	}
+}
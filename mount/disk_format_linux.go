@@ -0,0 +1,168 @@
+//go:build linux
+// +build linux
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mount
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+	utilexec "k8s.io/utils/exec"
+)
+
+// DiskFormat describes what is on a block device, as detected by
+// GetDiskFormat.
+type DiskFormat struct {
+	// Type is the filesystem type, e.g. "ext4", or empty if there is no
+	// filesystem.
+	Type string
+	// PartitionTableType is the type of the partition table, e.g. "gpt", or
+	// empty if there is none.
+	PartitionTableType string
+	// UUID and Label identify the filesystem. Either may be empty.
+	UUID  string
+	Label string
+}
+
+// Unformatted returns true if the device has neither a filesystem nor a
+// partition table, so that it can be formatted without losing data.
+func (f *DiskFormat) Unformatted() bool {
+	return f.Type == "" && f.PartitionTableType == ""
+}
+
+// GetDiskFormat detects the filesystem, partition table, UUID and label of
+// device. It probes the device with blkid, and falls back to lsblk if blkid
+// is not installed or fails for another reason than finding nothing. lsblk
+// reports what udev has recorded, which may lag behind a recent format.
+// Unlike SafeFormatAndMount.GetDiskFormat, it reports a partition table
+// separately instead of as a special filesystem type.
+func GetDiskFormat(exec utilexec.Interface, device string) (*DiskFormat, error) {
+	format, err := getDiskFormatBlkid(exec, device)
+	if err == nil {
+		return format, nil
+	}
+	klog.V(4).Infof("blkid failed to detect the format of %q, trying lsblk: %v", device, err)
+	format, lsblkErr := getDiskFormatLsblk(exec, device)
+	if lsblkErr != nil {
+		return nil, fmt.Errorf("failed to detect the format of %s: blkid: %v, lsblk: %v", device, err, lsblkErr)
+	}
+	return format, nil
+}
+
+// getDiskFormatBlkid detects the format of device with blkid.
+func getDiskFormatBlkid(exec utilexec.Interface, device string) (*DiskFormat, error) {
+	args := []string{"-p", "-s", "TYPE", "-s", "PTTYPE", "-s", "UUID", "-s", "LABEL", "-o", "export", device}
+	output, err := exec.Command("blkid", args...).CombinedOutput()
+	if err != nil {
+		var exitErr utilexec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitStatus() == 2 {
+			// blkid exits with 2 if none of the tags was found, i.e.
+			// the device is unformatted.
+			return &DiskFormat{}, nil
+		}
+		return nil, fmt.Errorf("%v, output: %q", err, string(output))
+	}
+
+	format := &DiskFormat{}
+	for _, line := range strings.Split(string(output), "\n") {
+		if line == "" {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid output: %q", string(output))
+		}
+		format.set(key, unescapeBlkid(value))
+	}
+	return format, nil
+}
+
+// getDiskFormatLsblk detects the format of device with lsblk.
+func getDiskFormatLsblk(exec utilexec.Interface, device string) (*DiskFormat, error) {
+	output, err := exec.Command("lsblk", "--nodeps", "--noheadings", "--pairs", "--output", "FSTYPE,PTTYPE,UUID,LABEL", device).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("%v, output: %q", err, string(output))
+	}
+	format := &DiskFormat{}
+	// The output is a single line like FSTYPE="ext4" PTTYPE="" UUID="..." LABEL="...".
+	rest := strings.TrimSpace(string(output))
+	for rest != "" {
+		key, value, found := strings.Cut(rest, "=\"")
+		if !found {
+			return nil, fmt.Errorf("invalid output: %q", string(output))
+		}
+		value, rest, found = strings.Cut(value, "\"")
+		if !found {
+			return nil, fmt.Errorf("invalid output: %q", string(output))
+		}
+		if key == "FSTYPE" {
+			key = "TYPE"
+		}
+		format.set(key, unescapeLsblk(value))
+		rest = strings.TrimSpace(rest)
+	}
+	return format, nil
+}
+
+// set sets the field of f for the blkid tag key.
+func (f *DiskFormat) set(key, value string) {
+	switch key {
+	case "TYPE":
+		f.Type = value
+	case "PTTYPE":
+		f.PartitionTableType = value
+	case "UUID":
+		f.UUID = value
+	case "LABEL":
+		f.Label = value
+	}
+}
+
+// unescapeBlkid removes the backslashes blkid -o export adds before special
+// characters.
+func unescapeBlkid(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) {
+			i++
+		}
+		b.WriteByte(value[i])
+	}
+	return b.String()
+}
+
+// unescapeLsblk decodes the \xHH escapes lsblk --pairs uses for special
+// characters.
+func unescapeLsblk(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+3 < len(value) && value[i+1] == 'x' {
+			if c, err := strconv.ParseUint(value[i+2:i+4], 16, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(value[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,124 @@
+//go:build linux
+// +build linux
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mount
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	utilexec "k8s.io/utils/exec"
+	testingexec "k8s.io/utils/exec/testing"
+)
+
+func TestGetDiskFormat(t *testing.T) {
+	blkid := []string{"-p", "-s", "TYPE", "-s", "PTTYPE", "-s", "UUID", "-s", "LABEL", "-o", "export", "/dev/foo"}
+	lsblk := []string{"--nodeps", "--noheadings", "--pairs", "--output", "FSTYPE,PTTYPE,UUID,LABEL", "/dev/foo"}
+	tests := []struct {
+		name        string
+		execScripts []ExecArgs
+		expected    *DiskFormat
+		expectError bool
+	}{
+		{
+			name: "ext4 with UUID and label",
+			execScripts: []ExecArgs{
+				{"blkid", blkid, "DEVNAME=/dev/foo\nUUID=1b2f2c3a-8c4d-4e5f-9a6b-7c8d9e0f1a2b\nLABEL=my\\ data\nTYPE=ext4\n", nil},
+			},
+			expected: &DiskFormat{Type: "ext4", UUID: "1b2f2c3a-8c4d-4e5f-9a6b-7c8d9e0f1a2b", Label: "my data"},
+		},
+		{
+			name: "unformatted",
+			execScripts: []ExecArgs{
+				{"blkid", blkid, "", &testingexec.FakeExitError{Status: 2}},
+			},
+			expected: &DiskFormat{},
+		},
+		{
+			name: "partition table",
+			execScripts: []ExecArgs{
+				{"blkid", blkid, "DEVNAME=/dev/foo\nPTTYPE=gpt\n", nil},
+			},
+			expected: &DiskFormat{PartitionTableType: "gpt"},
+		},
+		{
+			name: "invalid blkid output falls back to lsblk",
+			execScripts: []ExecArgs{
+				{"blkid", blkid, "garbage\n", nil},
+				{"lsblk", lsblk, "FSTYPE=\"xfs\" PTTYPE=\"\" UUID=\"abcd\" LABEL=\"\"\n", nil},
+			},
+			expected: &DiskFormat{Type: "xfs", UUID: "abcd"},
+		},
+		{
+			name: "blkid not found falls back to lsblk",
+			execScripts: []ExecArgs{
+				{"blkid", blkid, "", utilexec.ErrExecutableNotFound},
+				{"lsblk", lsblk, "FSTYPE=\"ext4\" PTTYPE=\"\" UUID=\"abcd\" LABEL=\"my\\x20data\"\n", nil},
+			},
+			expected: &DiskFormat{Type: "ext4", UUID: "abcd", Label: "my data"},
+		},
+		{
+			name: "lsblk unformatted",
+			execScripts: []ExecArgs{
+				{"blkid", blkid, "", &testingexec.FakeExitError{Status: 4}},
+				{"lsblk", lsblk, "FSTYPE=\"\" PTTYPE=\"\" UUID=\"\" LABEL=\"\"\n", nil},
+			},
+			expected: &DiskFormat{},
+		},
+		{
+			name: "blkid and lsblk fail",
+			execScripts: []ExecArgs{
+				{"blkid", blkid, "", &testingexec.FakeExitError{Status: 4}},
+				{"lsblk", lsblk, "lsblk: /dev/foo: not a block device", &testingexec.FakeExitError{Status: 32}},
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid lsblk output",
+			execScripts: []ExecArgs{
+				{"blkid", blkid, "", errors.New("failed")},
+				{"lsblk", lsblk, "FSTYPE=\"ext4", nil},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fakeExec := newScriptedExec(t, test.execScripts)
+			format, err := GetDiskFormat(fakeExec, "/dev/foo")
+			if test.expectError {
+				if err == nil {
+					t.Errorf("expected error, got %+v", format)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			} else if !reflect.DeepEqual(format, test.expected) {
+				t.Errorf("expected %+v, got %+v", test.expected, format)
+			}
+			if fakeExec.CommandCalls != len(test.execScripts) {
+				t.Errorf("expected %d commands, got %d", len(test.execScripts), fakeExec.CommandCalls)
+			}
+			if format != nil && format.Unformatted() != (test.expected.Type == "" && test.expected.PartitionTableType == "") {
+				t.Errorf("unexpected Unformatted() %v", format.Unformatted())
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+//go:build !linux
+// +build !linux
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mount
+
+import (
+	"fmt"
+
+	utilexec "k8s.io/utils/exec"
+)
+
+// DiskFormat describes what is on a block device, as detected by
+// GetDiskFormat.
+type DiskFormat struct {
+	Type               string
+	PartitionTableType string
+	UUID               string
+	Label              string
+}
+
+// Unformatted returns true if the device has neither a filesystem nor a
+// partition table.
+func (f *DiskFormat) Unformatted() bool {
+	return f.Type == "" && f.PartitionTableType == ""
+}
+
+// GetDiskFormat always returns an error on unsupported platforms
+func GetDiskFormat(exec utilexec.Interface, device string) (*DiskFormat, error) {
+	return nil, fmt.Errorf("disk format detection is not supported for this build")
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mount
+
+import (
+	"context"
+
+	"k8s.io/klog/v2"
+)
+
+// NewDryRun returns a mounter which only logs the mounts and unmounts it would
+// do, e.g. to preview the cleanup of orphaned volumes before enabling it.
+// Queries such as List and IsLikelyNotMountPoint are passed to inner, so that
+// callers act on the real state of the system. CleanupMountPoint recognizes
+// the mounter, also when wrapped by NewInstrumented or NewRetrying, and only
+// logs the directories it would remove.
+func NewDryRun(inner Interface) ContextInterface {
+	return &dryRunMounter{inner: inner}
+}
+
+// IsDryRun returns true if mounter was created by NewDryRun, or wraps such a
+// mounter, e.g. with NewInstrumented or NewRetrying. Code doing other
+// destructive operations along with mounts can use it to skip them too.
+func IsDryRun(mounter Interface) bool {
+	d, ok := mounter.(dryRunner)
+	return ok && d.isDryRun()
+}
+
+// dryRunner is implemented by the dry run mounter, and by the mounters
+// wrapping another one, which forward isDryRun to it.
+type dryRunner interface {
+	isDryRun() bool
+}
+
+type dryRunMounter struct {
+	inner Interface
+}
+
+var _ ContextInterface = &dryRunMounter{}
+var _ dryRunner = &dryRunMounter{}
+
+func (m *dryRunMounter) isDryRun() bool {
+	return true
+}
+
+// Mount is part of the Interface interface.
+func (m *dryRunMounter) Mount(source string, target string, fstype string, options []string) error {
+	return m.MountSensitive(source, target, fstype, options, nil /* sensitiveOptions */)
+}
+
+// MountSensitive is part of the Interface interface.
+func (m *dryRunMounter) MountSensitive(source string, target string, fstype string, options []string, sensitiveOptions []string) error {
+	klog.Infof("Dry run: would mount %q at %q with type %q and options (%s)", source, target, fstype, sanitizedOptionsForLogging(options, sensitiveOptions))
+	return nil
+}
+
+// MountContext is part of the ContextInterface interface.
+func (m *dryRunMounter) MountContext(ctx context.Context, source string, target string, fstype string, options []string) error {
+	return m.MountSensitiveContext(ctx, source, target, fstype, options, nil /* sensitiveOptions */)
+}
+
+// MountSensitiveContext is part of the ContextInterface interface.
+func (m *dryRunMounter) MountSensitiveContext(ctx context.Context, source string, target string, fstype string, options []string, sensitiveOptions []string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.MountSensitive(source, target, fstype, options, sensitiveOptions)
+}
+
+// Unmount is part of the Interface interface.
+func (m *dryRunMounter) Unmount(target string) error {
+	klog.Infof("Dry run: would unmount %q", target)
+	return nil
+}
+
+// UnmountContext is part of the ContextInterface interface.
+func (m *dryRunMounter) UnmountContext(ctx context.Context, target string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.Unmount(target)
+}
+
+// List is part of the Interface interface.
+func (m *dryRunMounter) List() ([]MountPoint, error) {
+	return m.inner.List()
+}
+
+// ListContext is part of the ContextInterface interface.
+func (m *dryRunMounter) ListContext(ctx context.Context) ([]MountPoint, error) {
+	if inner, ok := m.inner.(ContextInterface); ok {
+		return inner.ListContext(ctx)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.inner.List()
+}
+
+// IsLikelyNotMountPoint is part of the Interface interface.
+func (m *dryRunMounter) IsLikelyNotMountPoint(file string) (bool, error) {
+	return m.inner.IsLikelyNotMountPoint(file)
+}
+
+// GetMountRefs is part of the Interface interface.
+func (m *dryRunMounter) GetMountRefs(pathname string) ([]string, error) {
+	return m.inner.GetMountRefs(pathname)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mount
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDryRunCleanupMountPoint(t *testing.T) {
+	recorder := OperationRecorderFunc(func(operation, fstype string, duration time.Duration, err error) {})
+	wrappers := map[string]func(Interface) Interface{
+		"dry run": func(fake Interface) Interface {
+			return NewDryRun(fake)
+		},
+		"instrumented dry run": func(fake Interface) Interface {
+			return NewInstrumented(NewDryRun(fake), recorder)
+		},
+		"retrying instrumented dry run": func(fake Interface) Interface {
+			return NewRetrying(NewInstrumented(NewDryRun(fake), recorder), RetryPolicy{})
+		},
+	}
+	tests := []struct {
+		name    string
+		mounted bool
+	}{
+		{name: "mount point", mounted: true},
+		{name: "not a mount point"},
+	}
+	for wrapperName, wrap := range wrappers {
+		for _, test := range tests {
+			t.Run(wrapperName+" "+test.name, func(t *testing.T) {
+				dir := filepath.Join(t.TempDir(), "mnt")
+				if err := os.Mkdir(dir, 0750); err != nil {
+					t.Fatal(err)
+				}
+				resolved, err := filepath.EvalSymlinks(dir)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var mps []MountPoint
+				if test.mounted {
+					mps = []MountPoint{{Device: "/dev/foo", Path: resolved}}
+				}
+				fake := NewFakeMounter(mps)
+				mounter := wrap(fake)
+				if !IsDryRun(mounter) {
+					t.Errorf("IsDryRun doesn't recognize the dry run mounter")
+				}
+
+				if err := CleanupMountPoint(dir, mounter, false); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if _, err := os.Stat(dir); err != nil {
+					t.Errorf("expected %s to still exist, got %v", dir, err)
+				}
+				if len(fake.MountPoints) != len(mps) || len(fake.GetLog()) != 0 {
+					t.Errorf("expected no changes to mounts, got mounts %+v and actions %+v", fake.MountPoints, fake.GetLog())
+				}
+			})
+		}
+	}
+
+	fake := NewFakeMounter(nil)
+	for name, mounter := range map[string]Interface{
+		"fake":         fake,
+		"instrumented": NewInstrumented(fake, recorder),
+		"retrying":     NewRetrying(fake, RetryPolicy{}),
+	} {
+		if IsDryRun(mounter) {
+			t.Errorf("IsDryRun reports the %s mounter as a dry run", name)
+		}
+	}
+}
+
+func TestDryRunMount(t *testing.T) {
+	fake := NewFakeMounter(nil)
+	mounter := NewDryRun(fake)
+	if err := mounter.MountSensitive("/dev/foo", "/mnt/foo", "ext4", []string{"ro"}, []string{"secret"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mounter.Unmount("/mnt/foo"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(fake.GetMountCalls()) != 0 || len(fake.GetLog()) != 0 {
+		t.Errorf("expected the inner mounter not to be called, got calls %+v and actions %+v", fake.GetMountCalls(), fake.GetLog())
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package mount
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"sync"
@@ -34,12 +36,34 @@ type FakeMounter struct {
 	// any golang's DATA RACE warnings.
 	mutex       sync.Mutex
 	UnmountFunc UnmountFunc
+	// MountErrors are the errors returned by successive calls to Mount and
+	// MountSensitive: the Nth call fails with MountErrors[N-1] if it is not
+	// nil. Calls beyond the end of the list succeed. A failed call does not
+	// add a mount point.
+	MountErrors []error
+	// UnmountErrors maps targets to the error Unmount returns for them,
+	// leaving the mount point in place.
+	UnmountErrors map[string]error
+	mountCalls    []FakeMountCall
+}
+
+// FakeMountCall records a call to FakeMounter.Mount or MountSensitive.
+type FakeMountCall struct {
+	Source  string
+	Target  string
+	FSType  string
+	Options []string
+	// SensitiveOptions are recorded for tests to check, they are never
+	// logged.
+	SensitiveOptions []string
+	// Err is the error the call returned.
+	Err error
 }
 
 // UnmountFunc is a function callback to be executed during the Unmount() call.
 type UnmountFunc func(path string) error
 
-var _ Interface = &FakeMounter{}
+var _ ContextInterface = &FakeMounter{}
 
 const (
 	// FakeActionMount is the string for specifying mount as FakeAction.Action
@@ -80,6 +104,15 @@ func (f *FakeMounter) GetLog() []FakeAction {
 	return f.log
 }
 
+// GetMountCalls returns the calls to Mount and MountSensitive, including the
+// failed ones.
+func (f *FakeMounter) GetMountCalls() []FakeMountCall {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.mountCalls
+}
+
 // Mount records the mount event and updates the in-memory mount points for FakeMounter
 func (f *FakeMounter) Mount(source string, target string, fstype string, options []string) error {
 	return f.MountSensitive(source, target, fstype, options, nil /* sensitiveOptions */)
@@ -94,11 +127,26 @@ func (f *FakeMounter) MountSensitive(source string, target string, fstype string
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
+	call := FakeMountCall{
+		Source:           source,
+		Target:           target,
+		FSType:           fstype,
+		Options:          append([]string(nil), options...),
+		SensitiveOptions: append([]string(nil), sensitiveOptions...),
+	}
+	if n := len(f.mountCalls); n < len(f.MountErrors) {
+		call.Err = f.MountErrors[n]
+	}
+	f.mountCalls = append(f.mountCalls, call)
+	if call.Err != nil {
+		return call.Err
+	}
+
 	opts := []string{}
 
 	for _, option := range options {
 		// find 'bind' option
-		if option == "bind" {
+		if option == "bind" || option == "rbind" {
 			// This is a bind-mount. In order to mimic linux behaviour, we must
 			// use the original device of the bind-mount as the real source.
 			// E.g. when mounted /dev/sda like this:
@@ -132,6 +180,75 @@ func (f *FakeMounter) MountSensitive(source string, target string, fstype string
 	return nil
 }
 
+// BindMount records a bind mount of source to target. The mount point has the
+// option "bind", or "rbind" if recursive is true, and "ro" if readOnly is true.
+func (f *FakeMounter) BindMount(source string, target string, recursive bool, readOnly bool) error {
+	options := []string{"bind"}
+	if recursive {
+		options = []string{"rbind"}
+	}
+	if readOnly {
+		options = append(options, "ro")
+	}
+	return f.Mount(source, target, "", options)
+}
+
+// Remount updates the options of the last mount point at target, like
+// Mounter.Remount. It fails if target is not a mount point.
+func (f *FakeMounter) Remount(target string, options []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	// If target is a symlink, get its absolute path
+	absTarget, err := filepath.EvalSymlinks(target)
+	if err != nil {
+		absTarget = target
+	}
+	for i := len(f.MountPoints) - 1; i >= 0; i-- {
+		if f.MountPoints[i].Path == absTarget {
+			f.MountPoints[i].Opts = mergeMountOptions(f.MountPoints[i].Opts, options)
+			klog.V(5).Infof("Fake mounter: remounted %s", absTarget)
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is not a mount point", target)
+}
+
+// RemountReadOnly makes the last mount point at target read-only.
+func (f *FakeMounter) RemountReadOnly(target string) error {
+	return f.Remount(target, []string{"ro"})
+}
+
+// MountContext is the same as Mount(), but fails if ctx is done.
+func (f *FakeMounter) MountContext(ctx context.Context, source string, target string, fstype string, options []string) error {
+	return f.MountSensitiveContext(ctx, source, target, fstype, options, nil /* sensitiveOptions */)
+}
+
+// MountSensitiveContext is the same as MountSensitive(), but fails if ctx is
+// done.
+func (f *FakeMounter) MountSensitiveContext(ctx context.Context, source string, target string, fstype string, options []string, sensitiveOptions []string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return f.MountSensitive(source, target, fstype, options, sensitiveOptions)
+}
+
+// UnmountContext is the same as Unmount(), but fails if ctx is done.
+func (f *FakeMounter) UnmountContext(ctx context.Context, target string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return f.Unmount(target)
+}
+
+// ListContext is the same as List(), but fails if ctx is done.
+func (f *FakeMounter) ListContext(ctx context.Context) ([]MountPoint, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.List()
+}
+
 // Unmount records the unmount event and updates the in-memory mount points for FakeMounter
 func (f *FakeMounter) Unmount(target string) error {
 	f.mutex.Lock()
@@ -143,6 +260,13 @@ func (f *FakeMounter) Unmount(target string) error {
 		absTarget = target
 	}
 
+	if err := f.UnmountErrors[target]; err != nil {
+		return err
+	}
+	if err := f.UnmountErrors[absTarget]; err != nil {
+		return err
+	}
+
 	newMountpoints := []MountPoint{}
 	for _, mp := range f.MountPoints {
 		if mp.Path == absTarget {
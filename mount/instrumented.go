@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mount
+
+import (
+	"context"
+	"time"
+)
+
+// Operation names passed to an OperationRecorder.
+const (
+	OperationMount                 = "mount"
+	OperationUnmount               = "unmount"
+	OperationList                  = "list"
+	OperationIsLikelyNotMountPoint = "is_likely_not_mount_point"
+	OperationGetMountRefs          = "get_mount_refs"
+)
+
+// OperationRecorder is told about each operation of a mounter created by
+// NewInstrumented, e.g. to export latency histograms. RecordOperation is
+// called once the operation has completed, with the filesystem type for
+// mounts and an empty fstype otherwise. It may be called concurrently.
+type OperationRecorder interface {
+	RecordOperation(operation string, fstype string, duration time.Duration, err error)
+}
+
+// OperationRecorderFunc is a function implementing OperationRecorder.
+type OperationRecorderFunc func(operation string, fstype string, duration time.Duration, err error)
+
+// RecordOperation calls f.
+func (f OperationRecorderFunc) RecordOperation(operation string, fstype string, duration time.Duration, err error) {
+	f(operation, fstype, duration, err)
+}
+
+// NewInstrumented returns a mounter which performs all operations with inner
+// and reports each of them to recorder. Mount and MountSensitive, and their
+// context variants, are all reported as OperationMount.
+func NewInstrumented(inner Interface, recorder OperationRecorder) ContextInterface {
+	return &instrumentedMounter{inner: inner, recorder: recorder}
+}
+
+type instrumentedMounter struct {
+	inner    Interface
+	recorder OperationRecorder
+}
+
+var _ ContextInterface = &instrumentedMounter{}
+var _ dryRunner = &instrumentedMounter{}
+
+// isDryRun is part of the dryRunner interface.
+func (m *instrumentedMounter) isDryRun() bool {
+	return IsDryRun(m.inner)
+}
+
+// record reports an operation which started at start and failed with err.
+func (m *instrumentedMounter) record(operation string, fstype string, start time.Time, err error) {
+	m.recorder.RecordOperation(operation, fstype, time.Since(start), err)
+}
+
+// Mount is part of the Interface interface.
+func (m *instrumentedMounter) Mount(source string, target string, fstype string, options []string) error {
+	start := time.Now()
+	err := m.inner.Mount(source, target, fstype, options)
+	m.record(OperationMount, fstype, start, err)
+	return err
+}
+
+// MountSensitive is part of the Interface interface.
+func (m *instrumentedMounter) MountSensitive(source string, target string, fstype string, options []string, sensitiveOptions []string) error {
+	start := time.Now()
+	err := m.inner.MountSensitive(source, target, fstype, options, sensitiveOptions)
+	m.record(OperationMount, fstype, start, err)
+	return err
+}
+
+// MountContext is part of the ContextInterface interface.
+func (m *instrumentedMounter) MountContext(ctx context.Context, source string, target string, fstype string, options []string) error {
+	return m.MountSensitiveContext(ctx, source, target, fstype, options, nil /* sensitiveOptions */)
+}
+
+// MountSensitiveContext is part of the ContextInterface interface.
+func (m *instrumentedMounter) MountSensitiveContext(ctx context.Context, source string, target string, fstype string, options []string, sensitiveOptions []string) error {
+	start := time.Now()
+	var err error
+	if inner, ok := m.inner.(ContextInterface); ok {
+		err = inner.MountSensitiveContext(ctx, source, target, fstype, options, sensitiveOptions)
+	} else if err = ctx.Err(); err == nil {
+		err = m.inner.MountSensitive(source, target, fstype, options, sensitiveOptions)
+	}
+	m.record(OperationMount, fstype, start, err)
+	return err
+}
+
+// Unmount is part of the Interface interface.
+func (m *instrumentedMounter) Unmount(target string) error {
+	start := time.Now()
+	err := m.inner.Unmount(target)
+	m.record(OperationUnmount, "", start, err)
+	return err
+}
+
+// UnmountContext is part of the ContextInterface interface.
+func (m *instrumentedMounter) UnmountContext(ctx context.Context, target string) error {
+	start := time.Now()
+	var err error
+	if inner, ok := m.inner.(ContextInterface); ok {
+		err = inner.UnmountContext(ctx, target)
+	} else if err = ctx.Err(); err == nil {
+		err = m.inner.Unmount(target)
+	}
+	m.record(OperationUnmount, "", start, err)
+	return err
+}
+
+// List is part of the Interface interface.
+func (m *instrumentedMounter) List() ([]MountPoint, error) {
+	start := time.Now()
+	mps, err := m.inner.List()
+	m.record(OperationList, "", start, err)
+	return mps, err
+}
+
+// ListContext is part of the ContextInterface interface.
+func (m *instrumentedMounter) ListContext(ctx context.Context) ([]MountPoint, error) {
+	start := time.Now()
+	var mps []MountPoint
+	var err error
+	if inner, ok := m.inner.(ContextInterface); ok {
+		mps, err = inner.ListContext(ctx)
+	} else if err = ctx.Err(); err == nil {
+		mps, err = m.inner.List()
+	}
+	m.record(OperationList, "", start, err)
+	return mps, err
+}
+
+// IsLikelyNotMountPoint is part of the Interface interface.
+func (m *instrumentedMounter) IsLikelyNotMountPoint(file string) (bool, error) {
+	start := time.Now()
+	notMnt, err := m.inner.IsLikelyNotMountPoint(file)
+	m.record(OperationIsLikelyNotMountPoint, "", start, err)
+	return notMnt, err
+}
+
+// GetMountRefs is part of the Interface interface.
+func (m *instrumentedMounter) GetMountRefs(pathname string) ([]string, error) {
+	start := time.Now()
+	refs, err := m.inner.GetMountRefs(pathname)
+	m.record(OperationGetMountRefs, "", start, err)
+	return refs, err
+}
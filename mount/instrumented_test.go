@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mount
+
+import (
+	"context"
+	"reflect"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type recordedOperation struct {
+	operation string
+	fstype    string
+	err       error
+}
+
+func TestInstrumented(t *testing.T) {
+	var recorded []recordedOperation
+	recorder := OperationRecorderFunc(func(operation string, fstype string, duration time.Duration, err error) {
+		if duration < 0 {
+			t.Errorf("%s: negative duration %v", operation, duration)
+		}
+		recorded = append(recorded, recordedOperation{operation, fstype, err})
+	})
+	fake := NewFakeMounter(nil)
+	fake.MountErrors = []error{nil, syscall.EBUSY}
+	mounter := NewInstrumented(fake, recorder)
+
+	mounter.Mount("/dev/sda", "/mnt/a", "ext4", nil)
+	mounter.MountSensitiveContext(context.Background(), "/dev/sdb", "/mnt/b", "xfs", nil, []string{"secret"})
+	mounter.List()
+	mounter.GetMountRefs("/mnt/a")
+	mounter.Unmount("/mnt/a")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mounter.UnmountContext(ctx, "/mnt/a")
+
+	expected := []recordedOperation{
+		{OperationMount, "ext4", nil},
+		{OperationMount, "xfs", syscall.EBUSY},
+		{OperationList, "", nil},
+		{OperationGetMountRefs, "", nil},
+		{OperationUnmount, "", nil},
+		{OperationUnmount, "", context.Canceled},
+	}
+	if !reflect.DeepEqual(recorded, expected) {
+		t.Errorf("expected operations %+v, got %+v", expected, recorded)
+	}
+}
@@ -20,6 +20,7 @@ limitations under the License.
 package mount
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -72,9 +73,25 @@ type Interface interface {
 	GetMountRefs(pathname string) ([]string, error)
 }
 
+// ContextInterface extends Interface with methods which give up once ctx is
+// done, so that a hung mount, such as of an unresponsive NFS server, does not
+// block the caller forever.
+type ContextInterface interface {
+	Interface
+	// MountContext is the same as Mount(), but gives up once ctx is done.
+	MountContext(ctx context.Context, source string, target string, fstype string, options []string) error
+	// MountSensitiveContext is the same as MountSensitive(), but gives up
+	// once ctx is done.
+	MountSensitiveContext(ctx context.Context, source string, target string, fstype string, options []string, sensitiveOptions []string) error
+	// UnmountContext is the same as Unmount(), but gives up once ctx is done.
+	UnmountContext(ctx context.Context, target string) error
+	// ListContext is the same as List(), but gives up once ctx is done.
+	ListContext(ctx context.Context) ([]MountPoint, error)
+}
+
 // Compile-time check to ensure all Mounter implementations satisfy
 // the mount interface.
-var _ Interface = &Mounter{}
+var _ ContextInterface = &Mounter{}
 
 // MountPoint represents a single line in /proc/mounts or /etc/fstab.
 type MountPoint struct { // nolint: golint
@@ -124,6 +141,17 @@ func NewMountError(mountErrorValue MountErrorType, format string, args ...interf
 type SafeFormatAndMount struct {
 	Interface
 	Exec utilexec.Interface
+
+	// FormatOptions holds additional arguments for mkfs.<fstype>, keyed by
+	// fstype, e.g. {"ext4": {"-E", "lazy_itable_init=0"}, "xfs": {"-m", "crc=1"}}.
+	// They are passed after the default arguments and before the device.
+	// FormatOptions are only used on Linux.
+	FormatOptions map[string][]string
+	// SkipDefaultFormatOptions omits the default mkfs arguments ("-F -m0"
+	// for ext3 and ext4), so that only FormatOptions are passed. Callers
+	// must then pass "-F" themselves if mkfs would otherwise prompt, e.g.
+	// when formatting a whole disk.
+	SkipDefaultFormatOptions bool
 }
 
 // FormatAndMount formats the given disk, if needed, and mounts it.
@@ -254,6 +282,197 @@ func IsNotMountPoint(mounter Interface, file string) (bool, error) {
 	return notMnt, nil
 }
 
+// MountMismatch describes how the mount at a path differs from the expected
+// one, as returned by IsMountedAt.
+type MountMismatch struct {
+	// Target is the path the mount was expected at.
+	Target string
+	// NotMounted is true if nothing is mounted at Target. The other fields
+	// are then empty.
+	NotMounted bool
+	// Device and Type are the source and filesystem type of the mount at
+	// Target.
+	Device string
+	Type   string
+	// DeviceMismatch and TypeMismatch are true if Device and Type are not
+	// the expected ones.
+	DeviceMismatch bool
+	TypeMismatch   bool
+	// MissingOptions are the expected options the mount doesn't have.
+	MissingOptions []string
+}
+
+// String describes the mismatch. It only includes expected options, so it does
+// not leak any sensitive options of the mount.
+func (m *MountMismatch) String() string {
+	if m.NotMounted {
+		return fmt.Sprintf("%s is not mounted", m.Target)
+	}
+	var problems []string
+	if m.DeviceMismatch {
+		problems = append(problems, fmt.Sprintf("source is %q", m.Device))
+	}
+	if m.TypeMismatch {
+		problems = append(problems, fmt.Sprintf("filesystem type is %q", m.Type))
+	}
+	if len(m.MissingOptions) > 0 {
+		problems = append(problems, fmt.Sprintf("options %q are missing", m.MissingOptions))
+	}
+	return fmt.Sprintf("%s is mounted but %s", m.Target, strings.Join(problems, ", "))
+}
+
+// IsMountedAt returns true if target is a mount point of source with filesystem
+// type fstype and all of the given options. Empty source and fstype match
+// any. Options are compared with those listed by mounter.List(), which on
+// Linux are the names used by the kernel (e.g. "ro" or "nosuid"). Options only
+// interpreted by mount(8) or fstab, such as "bind", "defaults", "nofail",
+// "_netdev" or "x-*" options, are ignored, and defaults which the kernel
+// doesn't list, such as "exec", are only missing if the option replacing them,
+// e.g. "noexec", is listed. If several filesystems are mounted at target, the
+// last one, which hides the others, is checked. Otherwise it returns a
+// description of the mismatch, so that callers can decide to remount or fail.
+// A target which doesn't exist is reported as not mounted.
+func IsMountedAt(mounter Interface, target, source, fstype string, options []string) (bool, *MountMismatch, error) {
+	// Resolve any symlinks in target, kernel would do the same and use the resolved path in /proc/mounts.
+	resolvedTarget, err := filepath.EvalSymlinks(target)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, &MountMismatch{Target: target, NotMounted: true}, nil
+		}
+		return false, nil, err
+	}
+	mountPoints, err := mounter.List()
+	if err != nil {
+		return false, nil, err
+	}
+
+	var mp *MountPoint
+	for i := range mountPoints {
+		if isMountPointMatch(mountPoints[i], resolvedTarget) {
+			mp = &mountPoints[i]
+		}
+	}
+	if mp == nil {
+		return false, &MountMismatch{Target: target, NotMounted: true}, nil
+	}
+
+	mismatch := &MountMismatch{
+		Target:         target,
+		Device:         mp.Device,
+		Type:           mp.Type,
+		DeviceMismatch: source != "" && source != mp.Device,
+		TypeMismatch:   fstype != "" && fstype != mp.Type,
+	}
+	have := make(map[string]bool, len(mp.Opts))
+	for _, option := range mp.Opts {
+		have[option] = true
+	}
+	for _, option := range options {
+		if isUserspaceMountOption(option) || have[option] {
+			continue
+		}
+		if replacement, ok := unlistedMountOptions[option]; ok && !have[replacement] {
+			continue
+		}
+		mismatch.MissingOptions = append(mismatch.MissingOptions, option)
+	}
+	if mismatch.DeviceMismatch || mismatch.TypeMismatch || len(mismatch.MissingOptions) > 0 {
+		return false, mismatch, nil
+	}
+	return true, nil, nil
+}
+
+// userspaceMountOptions are the options interpreted by mount(8) or read from
+// fstab, which the kernel never lists for a mount.
+var userspaceMountOptions = map[string]bool{
+	"bind":     true,
+	"rbind":    true,
+	"remount":  true,
+	"defaults": true,
+	"auto":     true,
+	"noauto":   true,
+	"_netdev":  true,
+	"nofail":   true,
+	"user":     true,
+	"nouser":   true,
+	"users":    true,
+	"owner":    true,
+	"group":    true,
+	"loop":     true,
+}
+
+// userspaceMountOptionPrefixes are the prefixes of the userspace options
+// which take a value or are namespaced.
+var userspaceMountOptionPrefixes = []string{"x-", "comment=", "loop=", "offset=", "sizelimit=", "user=", "helper=", "uhelper="}
+
+// isUserspaceMountOption returns true if option is only interpreted by mount(8)
+// or fstab.
+func isUserspaceMountOption(option string) bool {
+	if userspaceMountOptions[option] {
+		return true
+	}
+	for _, prefix := range userspaceMountOptionPrefixes {
+		if strings.HasPrefix(option, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// unlistedMountOptions maps the default options which the kernel doesn't list
+// for a mount to the listed options replacing them.
+var unlistedMountOptions = map[string]string{
+	"exec":  "noexec",
+	"suid":  "nosuid",
+	"dev":   "nodev",
+	"async": "sync",
+}
+
+// mountOptionConflicts lists, for each per-mount option, the options it
+// replaces.
+var mountOptionConflicts = map[string][]string{
+	"ro":          {"rw"},
+	"rw":          {"ro"},
+	"nosuid":      {"suid"},
+	"suid":        {"nosuid"},
+	"nodev":       {"dev"},
+	"dev":         {"nodev"},
+	"noexec":      {"exec"},
+	"exec":        {"noexec"},
+	"noatime":     {"atime", "relatime", "strictatime"},
+	"relatime":    {"atime", "noatime", "strictatime"},
+	"strictatime": {"atime", "noatime", "relatime"},
+	"nodiratime":  {"diratime"},
+	"diratime":    {"nodiratime"},
+}
+
+// mergeMountOptions returns current with options added, dropping the options
+// of current which options replace, e.g. "rw" for "ro". "remount" and "bind"
+// in options are ignored.
+func mergeMountOptions(current []string, options []string) []string {
+	replaced := map[string]bool{}
+	for _, option := range options {
+		replaced[option] = true
+		for _, conflict := range mountOptionConflicts[option] {
+			replaced[conflict] = true
+		}
+	}
+	merged := []string{}
+	for _, option := range current {
+		if !replaced[option] {
+			merged = append(merged, option)
+		}
+	}
+	for _, option := range options {
+		switch option {
+		case "remount", "bind":
+			continue
+		}
+		merged = append(merged, option)
+	}
+	return merged
+}
+
 // MakeBindOpts detects whether a bind mount is being requested and makes the remount options to
 // use in case of bind mount, due to the fact that bind mount doesn't respect mount options.
 // The list equals:
@@ -47,7 +47,9 @@ func CleanupMountPoint(mountPath string, mounter Interface, extensiveMountPointC
 // IsNotMountPoint is more expensive but properly handles bind mounts within the same fs.
 // if corruptedMnt is true, it means that the mountPath is a corrupted mountpoint, and the mount point check
 // will be skipped
+// if mounter is a dry run mounter, the directory is not deleted, only logged.
 func doCleanupMountPoint(mountPath string, mounter Interface, extensiveMountPointCheck bool, corruptedMnt bool) error {
+	dryRun := IsDryRun(mounter)
 	var notMnt bool
 	var err error
 	if !corruptedMnt {
@@ -63,7 +65,7 @@ func doCleanupMountPoint(mountPath string, mounter Interface, extensiveMountPoin
 
 		if notMnt {
 			klog.Warningf("Warning: %q is not a mountpoint, deleting", mountPath)
-			return os.Remove(mountPath)
+			return removeMountPath(mountPath, dryRun)
 		}
 	}
 
@@ -72,6 +74,10 @@ func doCleanupMountPoint(mountPath string, mounter Interface, extensiveMountPoin
 	if err := mounter.Unmount(mountPath); err != nil {
 		return err
 	}
+	if dryRun {
+		// Nothing was unmounted, so mountPath is still a mount point.
+		return removeMountPath(mountPath, dryRun)
+	}
 
 	if extensiveMountPointCheck {
 		notMnt, err = IsNotMountPoint(mounter, mountPath)
@@ -83,11 +89,21 @@ func doCleanupMountPoint(mountPath string, mounter Interface, extensiveMountPoin
 	}
 	if notMnt {
 		klog.V(4).Infof("%q is unmounted, deleting the directory", mountPath)
-		return os.Remove(mountPath)
+		return removeMountPath(mountPath, dryRun)
 	}
 	return fmt.Errorf("Failed to unmount path %v", mountPath)
 }
 
+// removeMountPath deletes the directory mountPath, or only logs it if dryRun
+// is true.
+func removeMountPath(mountPath string, dryRun bool) error {
+	if dryRun {
+		klog.Infof("Dry run: would delete directory %q", mountPath)
+		return nil
+	}
+	return os.Remove(mountPath)
+}
+
 // PathExists returns true if the specified path exists.
 // TODO: clean this up to use pkg/util/file/FileExists
 func PathExists(path string) (bool, error) {
@@ -21,6 +21,7 @@ package mount
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
@@ -90,6 +91,22 @@ func ParseMountInfo(filename string) ([]MountInfo, error) {
 	if err != nil {
 		return []MountInfo{}, err
 	}
+	return parseMountInfo(content)
+}
+
+// ParseMountInfoReader parses the contents of a /proc/xxx/mountinfo file read
+// from r. Unlike ParseMountInfo, it does not retry to get a consistent read,
+// so it's up to the caller to provide the whole content at once.
+func ParseMountInfoReader(r io.Reader) ([]MountInfo, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return parseMountInfo(content)
+}
+
+// parseMountInfo parses the content of a /proc/xxx/mountinfo file.
+func parseMountInfo(content []byte) ([]MountInfo, error) {
 	contentStr := string(content)
 	infos := []MountInfo{}
 
@@ -24,6 +24,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -315,3 +316,69 @@ func TestBadParseMountInfo(t *testing.T) {
 		}
 	}
 }
+
+func TestParseMountInfoReader(t *testing.T) {
+	info := `62 0 253:0 / / rw,relatime shared:1 - ext4 /dev/mapper/ssd-root rw,seclabel,data=ordered
+83 63 0:44 / /var/lib/bar rw,relatime - tmpfs tmpfs rw
+`
+	infos, err := ParseMountInfoReader(strings.NewReader(info))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []MountInfo{
+		{
+			ID:             62,
+			ParentID:       0,
+			Major:          253,
+			Minor:          0,
+			Root:           "/",
+			Source:         "/dev/mapper/ssd-root",
+			MountPoint:     "/",
+			OptionalFields: []string{"shared:1"},
+			FsType:         "ext4",
+			MountOptions:   []string{"rw", "relatime"},
+			SuperOptions:   []string{"rw", "seclabel", "data=ordered"},
+		},
+		{
+			ID:           83,
+			ParentID:     63,
+			Major:        0,
+			Minor:        44,
+			Root:         "/",
+			Source:       "tmpfs",
+			MountPoint:   "/var/lib/bar",
+			FsType:       "tmpfs",
+			MountOptions: []string{"rw", "relatime"},
+			SuperOptions: []string{"rw"},
+		},
+	}
+	if !reflect.DeepEqual(infos, expected) {
+		t.Errorf("expected:\n%+v\ngot:\n%+v", expected, infos)
+	}
+
+	if _, err := ParseMountInfoReader(strings.NewReader("62 0 253:0 / / rw,relatime shared:1 ext4 /dev/sda rw\n")); err == nil {
+		t.Errorf("expected error for line without separator")
+	}
+}
+
+func FuzzParseMountInfoReader(f *testing.F) {
+	f.Add("62 0 253:0 / / rw,relatime shared:1 - ext4 /dev/mapper/ssd-root rw,seclabel,data=ordered\n")
+	f.Add("224 62 253:0 /var/lib/docker /var/lib/docker rw,relatime master:1 shared:44 - ext4 /dev/sda rw\n")
+	f.Add("83 63 0:44 / /var/lib/bar rw,relatime - tmpfs tmpfs rw\n83 63 0:44 / /var/lib/foo\\040(deleted) rw - tmpfs tmpfs rw")
+	f.Add("224 62 253: / / rw - ext4 /dev/sda rw\n")
+	f.Add("1 2 3:4 / / rw a b c d e f -")
+	f.Fuzz(func(t *testing.T, content string) {
+		infos, err := ParseMountInfoReader(strings.NewReader(content))
+		if err != nil {
+			return
+		}
+		if lines := strings.Count(content, "\n") + 1; len(infos) > lines {
+			t.Errorf("got %d entries from %d lines", len(infos), lines)
+		}
+		for _, info := range infos {
+			if info.MountPoint == "" || info.FsType == "" || info.Source == "" {
+				t.Errorf("incomplete entry %+v parsed from %q", info, content)
+			}
+		}
+	})
+}
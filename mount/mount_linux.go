@@ -20,6 +20,8 @@ limitations under the License.
 package mount
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -73,22 +75,35 @@ func (mounter *Mounter) Mount(source string, target string, fstype string, optio
 	return mounter.MountSensitive(source, target, fstype, options, nil)
 }
 
+// MountContext is the same as Mount(), but gives up once ctx is done. The mount
+// command is then killed along with any processes it started, such as mount
+// helpers.
+func (mounter *Mounter) MountContext(ctx context.Context, source string, target string, fstype string, options []string) error {
+	return mounter.MountSensitiveContext(ctx, source, target, fstype, options, nil)
+}
+
 // MountSensitive is the same as Mount() but this method allows
 // sensitiveOptions to be passed in a separate parameter from the normal
 // mount options and ensures the sensitiveOptions are never logged. This
 // method should be used by callers that pass sensitive material (like
 // passwords) as mount options.
 func (mounter *Mounter) MountSensitive(source string, target string, fstype string, options []string, sensitiveOptions []string) error {
+	return mounter.MountSensitiveContext(context.Background(), source, target, fstype, options, sensitiveOptions)
+}
+
+// MountSensitiveContext is the same as MountSensitive(), but gives up once ctx
+// is done, like MountContext().
+func (mounter *Mounter) MountSensitiveContext(ctx context.Context, source string, target string, fstype string, options []string, sensitiveOptions []string) error {
 	// Path to mounter binary if containerized mounter is needed. Otherwise, it is set to empty.
 	// All Linux distros are expected to be shipped with a mount utility that a support bind mounts.
 	mounterPath := ""
 	bind, bindOpts, bindRemountOpts, bindRemountOptsSensitive := MakeBindOptsSensitive(options, sensitiveOptions)
 	if bind {
-		err := mounter.doMount(mounterPath, defaultMountCommand, source, target, fstype, bindOpts, bindRemountOptsSensitive)
+		err := mounter.doMount(ctx, mounterPath, defaultMountCommand, source, target, fstype, bindOpts, bindRemountOptsSensitive)
 		if err != nil {
 			return err
 		}
-		return mounter.doMount(mounterPath, defaultMountCommand, source, target, fstype, bindRemountOpts, bindRemountOptsSensitive)
+		return mounter.doMount(ctx, mounterPath, defaultMountCommand, source, target, fstype, bindRemountOpts, bindRemountOptsSensitive)
 	}
 	// The list of filesystems that require containerized mounter on GCI image cluster
 	fsTypesNeedMounter := map[string]struct{}{
@@ -100,12 +115,12 @@ func (mounter *Mounter) MountSensitive(source string, target string, fstype stri
 	if _, ok := fsTypesNeedMounter[fstype]; ok {
 		mounterPath = mounter.mounterPath
 	}
-	return mounter.doMount(mounterPath, defaultMountCommand, source, target, fstype, options, sensitiveOptions)
+	return mounter.doMount(ctx, mounterPath, defaultMountCommand, source, target, fstype, options, sensitiveOptions)
 }
 
 // doMount runs the mount command. mounterPath is the path to mounter binary if containerized mounter is used.
 // sensitiveOptions is an extension of options except they will not be logged (because they may contain sensitive material)
-func (mounter *Mounter) doMount(mounterPath string, mountCmd string, source string, target string, fstype string, options []string, sensitiveOptions []string) error {
+func (mounter *Mounter) doMount(ctx context.Context, mounterPath string, mountCmd string, source string, target string, fstype string, options []string, sensitiveOptions []string) error {
 	mountArgs, mountArgsLogStr := MakeMountArgsSensitive(source, target, fstype, options, sensitiveOptions)
 	if len(mounterPath) > 0 {
 		mountArgs = append([]string{mountCmd}, mountArgs...)
@@ -145,8 +160,7 @@ func (mounter *Mounter) doMount(mounterPath string, mountCmd string, source stri
 
 	// Logging with sensitive mount options removed.
 	klog.V(4).Infof("Mounting cmd (%s) with arguments (%s)", mountCmd, mountArgsLogStr)
-	command := exec.Command(mountCmd, mountArgs...)
-	output, err := command.CombinedOutput()
+	output, err := combinedOutputContext(ctx, mountCmd, mountArgs...)
 	if err != nil {
 		klog.Errorf("Mount failed: %v\nMounting command: %s\nMounting arguments: %s\nOutput: %s\n", err, mountCmd, mountArgsLogStr, string(output))
 		return fmt.Errorf("mount failed: %v\nMounting command: %s\nMounting arguments: %s\nOutput: %s",
@@ -155,6 +169,48 @@ func (mounter *Mounter) doMount(mounterPath string, mountCmd string, source stri
 	return err
 }
 
+// combinedOutputContext runs cmd with args and returns its combined output. If
+// ctx is done before cmd completes, cmd is killed along with the processes it
+// started, and the error of ctx is returned right away: a process stuck in the
+// kernel, as happens with hung NFS servers, may not die until much later. cmd
+// is then reaped in the background.
+func combinedOutputContext(ctx context.Context, cmd string, args ...string) ([]byte, error) {
+	if ctx.Done() == nil {
+		return utilexec.New().Command(cmd, args...).CombinedOutput()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// The context kills the whole process group of cmd, until it is reaped.
+	c := utilexec.CommandWithOptions(utilexec.New(), cmd, utilexec.Options{Context: ctx, KillChildren: true}, args...)
+	var b bytes.Buffer
+	c.SetStdout(&b)
+	c.SetStderr(&b)
+	if err := c.Start(); err != nil {
+		return nil, err
+	}
+	waitErr := make(chan error, 1)
+	go func() {
+		waitErr <- c.Wait()
+	}()
+	select {
+	case err := <-waitErr:
+		return b.Bytes(), err
+	case <-ctx.Done():
+	}
+	// cmd may have completed just as ctx was done.
+	select {
+	case err := <-waitErr:
+		return b.Bytes(), err
+	default:
+	}
+	go func() {
+		klog.V(4).Infof("%s reaped after its context was done: %v", cmd, <-waitErr)
+	}()
+	return nil, ctx.Err()
+}
+
 // detectSystemd returns true if OS runs with systemd as init. When not sure
 // (permission errors, ...), it returns false.
 // There may be different ways how to detect systemd, this one makes sure that
@@ -236,20 +292,143 @@ func AddSystemdScopeSensitive(systemdRunPath, mountName, command string, args []
 
 // Unmount unmounts the target.
 func (mounter *Mounter) Unmount(target string) error {
+	return mounter.UnmountContext(context.Background(), target)
+}
+
+// UnmountContext is the same as Unmount(), but gives up once ctx is done. The
+// umount command is then killed along with any processes it started.
+func (mounter *Mounter) UnmountContext(ctx context.Context, target string) error {
 	klog.V(4).Infof("Unmounting %s", target)
-	command := exec.Command("umount", target)
-	output, err := command.CombinedOutput()
+	output, err := combinedOutputContext(ctx, "umount", target)
 	if err != nil {
 		return fmt.Errorf("unmount failed: %v\nUnmounting arguments: %s\nOutput: %s", err, target, string(output))
 	}
 	return nil
 }
 
+// BindMount bind mounts source to target. If recursive is true, the mounts
+// below source are bind mounted too ("rbind"). If readOnly is true, the bind
+// mount is remounted read-only, since the kernel ignores "ro" when creating a
+// bind mount; with recursive, every mount below target is remounted too. Only
+// the bind mounts are made read-only, source itself is not affected. If the
+// remount fails, target is unmounted again, so that it's never left writable.
+func (mounter *Mounter) BindMount(source string, target string, recursive bool, readOnly bool) error {
+	bindOpt := "bind"
+	if recursive {
+		bindOpt = "rbind"
+	}
+	if err := mounter.doMount(context.Background(), "", defaultMountCommand, source, target, "", []string{bindOpt}, nil); err != nil {
+		return err
+	}
+	if !readOnly {
+		return nil
+	}
+	if err := mounter.remountReadOnly(target, recursive); err != nil {
+		args := []string{target}
+		if recursive {
+			args = []string{"-R", target}
+		}
+		if output, umountErr := exec.Command("umount", args...).CombinedOutput(); umountErr != nil {
+			klog.Errorf("Failed to unmount %s after failing to make it read-only: %v\nOutput: %s", target, umountErr, string(output))
+		}
+		return err
+	}
+	return nil
+}
+
+// remountReadOnly remounts the bind mount at target read-only. If recursive is
+// true, the mounts below target are remounted too, parents first.
+func (mounter *Mounter) remountReadOnly(target string, recursive bool) error {
+	// Resolve any symlinks in target, kernel would do the same and use the resolved path in /proc/self/mountinfo.
+	resolvedTarget, err := filepath.EvalSymlinks(target)
+	if err != nil {
+		return err
+	}
+	mis, err := ParseMountInfo(procMountInfoPath)
+	if err != nil {
+		return err
+	}
+	// mountinfo lists mounts in the order they were created, so each mount
+	// comes after the one it is mounted on. If several mounts share a mount
+	// point, the last one is the visible one.
+	var paths []string
+	current := map[string][]string{}
+	for _, mi := range mis {
+		if mi.MountPoint != resolvedTarget && (!recursive || !PathWithinBase(mi.MountPoint, resolvedTarget)) {
+			continue
+		}
+		if _, found := current[mi.MountPoint]; !found {
+			paths = append(paths, mi.MountPoint)
+		}
+		current[mi.MountPoint] = mi.MountOptions
+	}
+	if _, found := current[resolvedTarget]; !found {
+		return fmt.Errorf("bind mount at %s not found in mount table", target)
+	}
+	for _, path := range paths {
+		if err := mounter.remount(path, current[path], []string{"ro"}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Remount changes the options of the mount at target, e.g. to "ro" or
+// "nosuid". The current per-mount options, read from /proc/self/mountinfo,
+// are kept unless options override them, since the kernel resets those not
+// passed to a remount. The mount is remounted as a bind mount: only this
+// mount changes, other mounts of the same filesystem are not affected.
+func (mounter *Mounter) Remount(target string, options []string) error {
+	// Resolve any symlinks in target, kernel would do the same and use the resolved path in /proc/self/mountinfo.
+	resolvedTarget, err := filepath.EvalSymlinks(target)
+	if err != nil {
+		return err
+	}
+	mis, err := ParseMountInfo(procMountInfoPath)
+	if err != nil {
+		return err
+	}
+	var current []string
+	found := false
+	for _, mi := range mis {
+		// The last mount at target is the visible one.
+		if mi.MountPoint == resolvedTarget {
+			current = mi.MountOptions
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("%s is not a mount point", target)
+	}
+	return mounter.remount(target, current, options)
+}
+
+// RemountReadOnly makes the mount at target read-only, keeping its other
+// options. See Remount().
+func (mounter *Mounter) RemountReadOnly(target string) error {
+	return mounter.Remount(target, []string{"ro"})
+}
+
+// remount remounts target, which currently has the per-mount options current.
+func (mounter *Mounter) remount(target string, current []string, options []string) error {
+	remountOpts := append([]string{"remount", "bind"}, mergeMountOptions(current, options)...)
+	return mounter.doMount(context.Background(), "", defaultMountCommand, "", target, "", remountOpts, nil)
+}
+
 // List returns a list of all mounted filesystems.
 func (*Mounter) List() ([]MountPoint, error) {
 	return ListProcMounts(procMountsPath)
 }
 
+// ListContext is the same as List(). Reading the list of mounts from /proc
+// does not block on unresponsive filesystems, so ctx is only checked before.
+func (mounter *Mounter) ListContext(ctx context.Context) ([]MountPoint, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return mounter.List()
+}
+
 // IsLikelyNotMountPoint determines if a directory is not a mountpoint.
 // It is fast but not necessarily ALWAYS correct. If the path is in fact
 // a bind mount from one part of a mount to another it will not be detected.
@@ -355,14 +534,15 @@ func (mounter *SafeFormatAndMount) formatAndMountSensitive(source string, target
 		}
 
 		// Disk is unformatted so format it.
-		args := []string{source}
-		if fstype == "ext4" || fstype == "ext3" {
+		args := []string{}
+		if (fstype == "ext4" || fstype == "ext3") && !mounter.SkipDefaultFormatOptions {
 			args = []string{
 				"-F",  // Force flag
 				"-m0", // Zero blocks reserved for super-user
-				source,
 			}
 		}
+		args = append(args, mounter.FormatOptions[fstype]...)
+		args = append(args, source)
 
 		klog.Infof("Disk %q appears to be unformatted, attempting to format as type: %q with options: %v", source, fstype, args)
 		output, err := mounter.Exec.Command("mkfs."+fstype, args...).CombinedOutput()
@@ -508,45 +688,12 @@ func parseProcMounts(content []byte) ([]MountPoint, error) {
 // it's possible to mount a non-root path of a filesystem, so we need to use
 // root path and major:minor to represent mount source uniquely.
 // This implementation is shared between Linux and NsEnterMounter
+// It reads mountInfoPath on every call; use a MountInfoSnapshot to answer many
+// queries from a single read.
 func SearchMountPoints(hostSource, mountInfoPath string) ([]string, error) {
 	mis, err := ParseMountInfo(mountInfoPath)
 	if err != nil {
 		return nil, err
 	}
-
-	mountID := 0
-	rootPath := ""
-	major := -1
-	minor := -1
-
-	// Finding the underlying root path and major:minor if possible.
-	// We need search in backward order because it's possible for later mounts
-	// to overlap earlier mounts.
-	for i := len(mis) - 1; i >= 0; i-- {
-		if hostSource == mis[i].MountPoint || PathWithinBase(hostSource, mis[i].MountPoint) {
-			// If it's a mount point or path under a mount point.
-			mountID = mis[i].ID
-			rootPath = filepath.Join(mis[i].Root, strings.TrimPrefix(hostSource, mis[i].MountPoint))
-			major = mis[i].Major
-			minor = mis[i].Minor
-			break
-		}
-	}
-
-	if rootPath == "" || major == -1 || minor == -1 {
-		return nil, fmt.Errorf("failed to get root path and major:minor for %s", hostSource)
-	}
-
-	var refs []string
-	for i := range mis {
-		if mis[i].ID == mountID {
-			// Ignore mount entry for mount source itself.
-			continue
-		}
-		if mis[i].Root == rootPath && mis[i].Major == major && mis[i].Minor == minor {
-			refs = append(refs, mis[i].MountPoint)
-		}
-	}
-
-	return refs, nil
+	return newMountInfoIndex(mis).searchMountPoints(hostSource)
 }
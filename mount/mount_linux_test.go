@@ -20,11 +20,14 @@ limitations under the License.
 package mount
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestReadProcMountsFrom(t *testing.T) {
@@ -515,3 +518,44 @@ func contains(slice []string, str string, t *testing.T) bool {
 
 	return strings.Contains(slice[optionsIndex], str)
 }
+
+func TestCombinedOutputContext(t *testing.T) {
+	out, err := combinedOutputContext(context.Background(), "/bin/sh", "-c", "echo out; echo err >&2")
+	if err != nil || string(out) != "out\nerr\n" {
+		t.Errorf("expected the combined output, got %q, %v", out, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	out, err = combinedOutputContext(ctx, "/bin/sh", "-c", "echo out; exit 1")
+	if err == nil || string(out) != "out\n" {
+		t.Errorf("expected the output of a failed command, got %q, %v", out, err)
+	}
+
+	// The grandchild writes its PID to a file and keeps the output open.
+	pidFile := filepath.Join(t.TempDir(), "pid")
+	ctx, cancel = context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = combinedOutputContext(ctx, "/bin/sh", "-c", "sh -c 'echo $$ > "+pidFile+"; exec sleep 100' & wait")
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected the context error, got %v", err)
+	}
+	if d := time.Since(start); d > 10*time.Second {
+		t.Errorf("expected to return once the context is done, took %v", d)
+	}
+	pid, err := os.ReadFile(pidFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; ; i++ {
+		stat, err := os.ReadFile("/proc/" + strings.TrimSpace(string(pid)) + "/stat")
+		if err != nil || strings.Contains(string(stat), ") Z ") {
+			break
+		}
+		if i == 100 {
+			t.Fatalf("expected the grandchild to be killed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
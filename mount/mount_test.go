@@ -17,8 +17,11 @@ limitations under the License.
 package mount
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
+	"syscall"
 	"testing"
 )
 
@@ -182,3 +185,229 @@ func TestOptionsForLogging(t *testing.T) {
 		}
 	}
 }
+
+func TestIsMountedAt(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target")
+	if err := os.Mkdir(target, 0755); err != nil {
+		t.Fatal(err)
+	}
+	resolved, err := filepath.EvalSymlinks(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mounter := NewFakeMounter([]MountPoint{
+		{Device: "/dev/sda", Path: resolved, Type: "ext4", Opts: []string{"ro", "relatime"}},
+		{Device: "/dev/sdb", Path: resolved, Type: "xfs", Opts: []string{"rw", "nosuid"}},
+	})
+
+	tests := []struct {
+		name             string
+		target           string
+		source           string
+		fstype           string
+		options          []string
+		expectedMounted  bool
+		expectedMismatch *MountMismatch
+	}{
+		{
+			name:            "match",
+			target:          target,
+			source:          "/dev/sdb",
+			fstype:          "xfs",
+			options:         []string{"bind", "nosuid", "defaults"},
+			expectedMounted: true,
+		},
+		{
+			name:            "any source and type",
+			target:          target,
+			expectedMounted: true,
+		},
+		{
+			name:    "mismatch",
+			target:  target,
+			source:  "/dev/sda",
+			fstype:  "ext4",
+			options: []string{"ro", "nosuid"},
+			expectedMismatch: &MountMismatch{
+				Target:         target,
+				Device:         "/dev/sdb",
+				Type:           "xfs",
+				DeviceMismatch: true,
+				TypeMismatch:   true,
+				MissingOptions: []string{"ro"},
+			},
+		},
+		{
+			name:            "userspace options",
+			target:          target,
+			options:         []string{"nosuid", "_netdev", "nofail", "noauto", "auto", "user", "loop", "x-systemd.automount", "comment=foo"},
+			expectedMounted: true,
+		},
+		{
+			name:            "unlisted defaults",
+			target:          target,
+			options:         []string{"exec", "dev", "async"},
+			expectedMounted: true,
+		},
+		{
+			name:    "replaced defaults",
+			target:  target,
+			options: []string{"suid", "exec"},
+			expectedMismatch: &MountMismatch{
+				Target:         target,
+				Device:         "/dev/sdb",
+				Type:           "xfs",
+				MissingOptions: []string{"suid"},
+			},
+		},
+		{
+			name:             "not mounted",
+			target:           dir,
+			expectedMismatch: &MountMismatch{Target: dir, NotMounted: true},
+		},
+		{
+			name:             "missing target",
+			target:           filepath.Join(dir, "missing"),
+			expectedMismatch: &MountMismatch{Target: filepath.Join(dir, "missing"), NotMounted: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mounted, mismatch, err := IsMountedAt(mounter, tt.target, tt.source, tt.fstype, tt.options)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if mounted != tt.expectedMounted {
+				t.Errorf("expected mounted to be %v, got %v", tt.expectedMounted, mounted)
+			}
+			if !reflect.DeepEqual(mismatch, tt.expectedMismatch) {
+				t.Errorf("expected mismatch %+v, got %+v", tt.expectedMismatch, mismatch)
+			}
+		})
+	}
+
+	_, mismatch, _ := IsMountedAt(mounter, target, "/dev/sda", "", []string{"ro"})
+	if expected := target + ` is mounted but source is "/dev/sdb", options ["ro"] are missing`; mismatch.String() != expected {
+		t.Errorf("expected %q, got %q", expected, mismatch.String())
+	}
+}
+
+func TestFakeBindMount(t *testing.T) {
+	tests := []struct {
+		name         string
+		recursive    bool
+		readOnly     bool
+		expectedOpts []string
+	}{
+		{
+			name:         "bind",
+			expectedOpts: []string{"bind"},
+		},
+		{
+			name:         "read-only rbind",
+			recursive:    true,
+			readOnly:     true,
+			expectedOpts: []string{"rbind", "ro"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mounter := NewFakeMounter([]MountPoint{{Device: "/dev/sda", Path: "/mnt/source", Type: "ext4"}})
+			if err := mounter.BindMount("/mnt/source", "/mnt/target", tt.recursive, tt.readOnly); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			expected := MountPoint{Device: "/dev/sda", Path: "/mnt/target", Opts: tt.expectedOpts}
+			if mp := mounter.MountPoints[1]; !reflect.DeepEqual(mp, expected) {
+				t.Errorf("expected mount point %+v, got %+v", expected, mp)
+			}
+		})
+	}
+}
+
+func TestFakeMounterErrors(t *testing.T) {
+	mounter := NewFakeMounter(nil)
+	mounter.MountErrors = []error{nil, syscall.EBUSY}
+	mounter.UnmountErrors = map[string]error{"/mnt/busy": syscall.EBUSY}
+
+	if err := mounter.Mount("/dev/sda", "/mnt/busy", "ext4", []string{"ro"}); err != nil {
+		t.Errorf("expected first mount to succeed, got %v", err)
+	}
+	if err := mounter.MountSensitive("/dev/sdb", "/mnt/other", "ext4", []string{"ro"}, []string{"secret"}); err != syscall.EBUSY {
+		t.Errorf("expected second mount to fail with EBUSY, got %v", err)
+	}
+	if err := mounter.Mount("/dev/sdb", "/mnt/other", "ext4", nil); err != nil {
+		t.Errorf("expected third mount to succeed, got %v", err)
+	}
+	if err := mounter.Unmount("/mnt/busy"); err != syscall.EBUSY {
+		t.Errorf("expected unmount to fail with EBUSY, got %v", err)
+	}
+	if err := mounter.Unmount("/mnt/other"); err != nil {
+		t.Errorf("expected unmount to succeed, got %v", err)
+	}
+
+	expectedMountPoints := []MountPoint{{Device: "/dev/sda", Path: "/mnt/busy", Type: "ext4"}}
+	if !reflect.DeepEqual(mounter.MountPoints, expectedMountPoints) {
+		t.Errorf("expected mount points %+v, got %+v", expectedMountPoints, mounter.MountPoints)
+	}
+	expectedCalls := []FakeMountCall{
+		{Source: "/dev/sda", Target: "/mnt/busy", FSType: "ext4", Options: []string{"ro"}},
+		{Source: "/dev/sdb", Target: "/mnt/other", FSType: "ext4", Options: []string{"ro"}, SensitiveOptions: []string{"secret"}, Err: syscall.EBUSY},
+		{Source: "/dev/sdb", Target: "/mnt/other", FSType: "ext4"},
+	}
+	if calls := mounter.GetMountCalls(); !reflect.DeepEqual(calls, expectedCalls) {
+		t.Errorf("expected mount calls %+v, got %+v", expectedCalls, calls)
+	}
+}
+
+func TestMergeMountOptions(t *testing.T) {
+	tests := []struct {
+		current  []string
+		options  []string
+		expected []string
+	}{
+		{
+			current:  []string{"rw", "nosuid", "nodev", "relatime"},
+			options:  []string{"ro"},
+			expected: []string{"nosuid", "nodev", "relatime", "ro"},
+		},
+		{
+			current:  []string{"ro", "nosuid", "relatime"},
+			options:  []string{"remount", "bind", "rw", "suid", "noatime"},
+			expected: []string{"rw", "suid", "noatime"},
+		},
+		{
+			current:  []string{"rw", "noexec"},
+			options:  []string{"noexec"},
+			expected: []string{"rw", "noexec"},
+		},
+		{
+			options:  []string{"ro"},
+			expected: []string{"ro"},
+		},
+	}
+	for _, test := range tests {
+		if merged := mergeMountOptions(test.current, test.options); !reflect.DeepEqual(merged, test.expected) {
+			t.Errorf("mergeMountOptions(%q, %q): expected %q, got %q", test.current, test.options, test.expected, merged)
+		}
+	}
+}
+
+func TestFakeRemount(t *testing.T) {
+	mounter := NewFakeMounter([]MountPoint{
+		{Device: "/dev/sda", Path: "/mnt/a", Type: "ext4", Opts: []string{"rw"}},
+		{Device: "tmpfs", Path: "/mnt/a", Type: "tmpfs", Opts: []string{"rw", "nosuid"}},
+	})
+	if err := mounter.RemountReadOnly("/mnt/a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts := mounter.MountPoints[0].Opts; !reflect.DeepEqual(opts, []string{"rw"}) {
+		t.Errorf("expected hidden mount to keep its options, got %q", opts)
+	}
+	if opts := mounter.MountPoints[1].Opts; !reflect.DeepEqual(opts, []string{"nosuid", "ro"}) {
+		t.Errorf("expected options [nosuid ro], got %q", opts)
+	}
+	if err := mounter.Remount("/mnt/b", []string{"ro"}); err == nil {
+		t.Errorf("expected remount of a path which is not mounted to fail")
+	}
+}
@@ -20,6 +20,7 @@ limitations under the License.
 package mount
 
 import (
+	"context"
 	"errors"
 )
 
@@ -49,16 +50,51 @@ func (mounter *Mounter) MountSensitive(source string, target string, fstype stri
 	return errUnsupported
 }
 
+// MountContext always returns an error on unsupported platforms
+func (mounter *Mounter) MountContext(ctx context.Context, source string, target string, fstype string, options []string) error {
+	return errUnsupported
+}
+
+// MountSensitiveContext always returns an error on unsupported platforms
+func (mounter *Mounter) MountSensitiveContext(ctx context.Context, source string, target string, fstype string, options []string, sensitiveOptions []string) error {
+	return errUnsupported
+}
+
 // Unmount always returns an error on unsupported platforms
 func (mounter *Mounter) Unmount(target string) error {
 	return errUnsupported
 }
 
+// UnmountContext always returns an error on unsupported platforms
+func (mounter *Mounter) UnmountContext(ctx context.Context, target string) error {
+	return errUnsupported
+}
+
+// BindMount always returns an error on unsupported platforms
+func (mounter *Mounter) BindMount(source string, target string, recursive bool, readOnly bool) error {
+	return errUnsupported
+}
+
+// Remount always returns an error on unsupported platforms
+func (mounter *Mounter) Remount(target string, options []string) error {
+	return errUnsupported
+}
+
+// RemountReadOnly always returns an error on unsupported platforms
+func (mounter *Mounter) RemountReadOnly(target string) error {
+	return errUnsupported
+}
+
 // List always returns an error on unsupported platforms
 func (mounter *Mounter) List() ([]MountPoint, error) {
 	return []MountPoint{}, errUnsupported
 }
 
+// ListContext always returns an error on unsupported platforms
+func (mounter *Mounter) ListContext(ctx context.Context) ([]MountPoint, error) {
+	return []MountPoint{}, errUnsupported
+}
+
 // IsLikelyNotMountPoint always returns an error on unsupported platforms
 func (mounter *Mounter) IsLikelyNotMountPoint(file string) (bool, error) {
 	return true, errUnsupported
@@ -20,6 +20,7 @@ limitations under the License.
 package mount
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -27,6 +28,7 @@ import (
 	"strings"
 
 	"k8s.io/klog/v2"
+	utilexec "k8s.io/utils/exec"
 	"k8s.io/utils/keymutex"
 )
 
@@ -98,32 +100,8 @@ func (mounter *Mounter) MountSensitive(source string, target string, fstype stri
 			return fmt.Errorf("only cifs mount is supported now, fstype: %q, mounting source (%q), target (%q), with options (%q)", fstype, source, target, sanitizedOptionsForLogging)
 		}
 
-		// lock smb mount for the same source
-		getSMBMountMutex.LockKey(source)
-		defer getSMBMountMutex.UnlockKey(source)
-
-		username := allOptions[0]
-		password := allOptions[1]
-		if output, err := newSMBMapping(username, password, source); err != nil {
-			klog.Warningf("SMB Mapping(%s) returned with error(%v), output(%s)", source, err, string(output))
-			if isSMBMappingExist(source) {
-				valid, err := isValidPath(source)
-				if !valid {
-					if err == nil || isAccessDeniedError(err) {
-						klog.V(2).Infof("SMB Mapping(%s) already exists while it's not valid, return error: %v, now begin to remove and remount", source, err)
-						if output, err = removeSMBMapping(source); err != nil {
-							return fmt.Errorf("Remove-SmbGlobalMapping failed: %v, output: %q", err, output)
-						}
-						if output, err := newSMBMapping(username, password, source); err != nil {
-							return fmt.Errorf("New-SmbGlobalMapping(%s) failed: %v, output: %q", source, err, output)
-						}
-					}
-				} else {
-					klog.V(2).Infof("SMB Mapping(%s) already exists and is still valid, skip error(%v)", source, err)
-				}
-			} else {
-				return fmt.Errorf("New-SmbGlobalMapping(%s) failed: %v, output: %q", source, err, output)
-			}
+		if err := mapSMB(utilexec.New(), allOptions[0], allOptions[1], source); err != nil {
+			return err
 		}
 	}
 
@@ -137,9 +115,41 @@ func (mounter *Mounter) MountSensitive(source string, target string, fstype stri
 	return nil
 }
 
+// mapSMB maps the SMB share at remotepath with the given credentials. If a
+// mapping already exists but the share can't be accessed, the mapping is
+// removed and created again.
+func mapSMB(executor utilexec.Interface, username, password, remotepath string) error {
+	// lock smb mount for the same source
+	getSMBMountMutex.LockKey(remotepath)
+	defer getSMBMountMutex.UnlockKey(remotepath)
+
+	if output, err := newSMBMapping(executor, username, password, remotepath); err != nil {
+		klog.Warningf("SMB Mapping(%s) returned with error(%v), output(%s)", remotepath, err, string(output))
+		if isSMBMappingExist(executor, remotepath) {
+			valid, err := isValidPath(executor, remotepath)
+			if !valid {
+				if err == nil || isAccessDeniedError(err) {
+					klog.V(2).Infof("SMB Mapping(%s) already exists while it's not valid, return error: %v, now begin to remove and remount", remotepath, err)
+					if output, err = removeSMBMapping(executor, remotepath); err != nil {
+						return fmt.Errorf("Remove-SmbGlobalMapping failed: %v, output: %q", err, output)
+					}
+					if output, err := newSMBMapping(executor, username, password, remotepath); err != nil {
+						return fmt.Errorf("New-SmbGlobalMapping(%s) failed: %v, output: %q", remotepath, err, output)
+					}
+				}
+			} else {
+				klog.V(2).Infof("SMB Mapping(%s) already exists and is still valid, skip error(%v)", remotepath, err)
+			}
+		} else {
+			return fmt.Errorf("New-SmbGlobalMapping(%s) failed: %v, output: %q", remotepath, err, output)
+		}
+	}
+	return nil
+}
+
 // do the SMB mount with username, password, remotepath
 // return (output, error)
-func newSMBMapping(username, password, remotepath string) (string, error) {
+func newSMBMapping(executor utilexec.Interface, username, password, remotepath string) (string, error) {
 	if username == "" || password == "" || remotepath == "" {
 		return "", fmt.Errorf("invalid parameter(username: %s, password: %s, remoteapth: %s)", username, sensitiveOptionsRemoved, remotepath)
 	}
@@ -149,29 +159,29 @@ func newSMBMapping(username, password, remotepath string) (string, error) {
 	cmdLine := `$PWord = ConvertTo-SecureString -String $Env:smbpassword -AsPlainText -Force` +
 		`;$Credential = New-Object -TypeName System.Management.Automation.PSCredential -ArgumentList $Env:smbuser, $PWord` +
 		`;New-SmbGlobalMapping -RemotePath $Env:smbremotepath -Credential $Credential`
-	cmd := exec.Command("powershell", "/c", cmdLine)
-	cmd.Env = append(os.Environ(),
+	cmd := executor.Command("powershell", "/c", cmdLine)
+	cmd.SetEnv(append(os.Environ(),
 		fmt.Sprintf("smbuser=%s", username),
 		fmt.Sprintf("smbpassword=%s", password),
-		fmt.Sprintf("smbremotepath=%s", remotepath))
+		fmt.Sprintf("smbremotepath=%s", remotepath)))
 
 	output, err := cmd.CombinedOutput()
 	return string(output), err
 }
 
 // check whether remotepath is already mounted
-func isSMBMappingExist(remotepath string) bool {
-	cmd := exec.Command("powershell", "/c", `Get-SmbGlobalMapping -RemotePath $Env:smbremotepath`)
-	cmd.Env = append(os.Environ(), fmt.Sprintf("smbremotepath=%s", remotepath))
+func isSMBMappingExist(executor utilexec.Interface, remotepath string) bool {
+	cmd := executor.Command("powershell", "/c", `Get-SmbGlobalMapping -RemotePath $Env:smbremotepath`)
+	cmd.SetEnv(append(os.Environ(), fmt.Sprintf("smbremotepath=%s", remotepath)))
 	_, err := cmd.CombinedOutput()
 	return err == nil
 }
 
 // check whether remotepath is valid
 // return (true, nil) if remotepath is valid
-func isValidPath(remotepath string) (bool, error) {
-	cmd := exec.Command("powershell", "/c", `Test-Path $Env:remoteapth`)
-	cmd.Env = append(os.Environ(), fmt.Sprintf("remoteapth=%s", remotepath))
+func isValidPath(executor utilexec.Interface, remotepath string) (bool, error) {
+	cmd := executor.Command("powershell", "/c", `Test-Path $Env:remoteapth`)
+	cmd.SetEnv(append(os.Environ(), fmt.Sprintf("remoteapth=%s", remotepath)))
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return false, fmt.Errorf("returned output: %s, error: %v", string(output), err)
@@ -185,9 +195,9 @@ func isAccessDeniedError(err error) bool {
 }
 
 // remove SMB mapping
-func removeSMBMapping(remotepath string) (string, error) {
-	cmd := exec.Command("powershell", "/c", `Remove-SmbGlobalMapping -RemotePath $Env:smbremotepath -Force`)
-	cmd.Env = append(os.Environ(), fmt.Sprintf("smbremotepath=%s", remotepath))
+func removeSMBMapping(executor utilexec.Interface, remotepath string) (string, error) {
+	cmd := executor.Command("powershell", "/c", `Remove-SmbGlobalMapping -RemotePath $Env:smbremotepath -Force`)
+	cmd.SetEnv(append(os.Environ(), fmt.Sprintf("smbremotepath=%s", remotepath)))
 	output, err := cmd.CombinedOutput()
 	return string(output), err
 }
@@ -203,11 +213,63 @@ func (mounter *Mounter) Unmount(target string) error {
 	return nil
 }
 
+// BindMount links target to source, see Mount(). A read-only bind mount is not
+// supported on Windows. recursive is ignored, the link always exposes the
+// whole tree below source.
+func (mounter *Mounter) BindMount(source string, target string, recursive bool, readOnly bool) error {
+	if readOnly {
+		return fmt.Errorf("read-only bind mount of %q to %q is not supported on Windows", source, target)
+	}
+	return mounter.Mount(source, target, "", []string{"bind"})
+}
+
+// Remount is not supported on Windows.
+func (mounter *Mounter) Remount(target string, options []string) error {
+	return fmt.Errorf("remount of %q is not supported on Windows", target)
+}
+
+// RemountReadOnly is not supported on Windows.
+func (mounter *Mounter) RemountReadOnly(target string) error {
+	return mounter.Remount(target, []string{"ro"})
+}
+
 // List returns a list of all mounted filesystems. todo
 func (mounter *Mounter) List() ([]MountPoint, error) {
 	return []MountPoint{}, nil
 }
 
+// MountContext is the same as Mount(). On Windows, ctx is only checked before
+// mounting.
+func (mounter *Mounter) MountContext(ctx context.Context, source string, target string, fstype string, options []string) error {
+	return mounter.MountSensitiveContext(ctx, source, target, fstype, options, nil /* sensitiveOptions */)
+}
+
+// MountSensitiveContext is the same as MountSensitive(). On Windows, ctx is
+// only checked before mounting.
+func (mounter *Mounter) MountSensitiveContext(ctx context.Context, source string, target string, fstype string, options []string, sensitiveOptions []string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mounter.MountSensitive(source, target, fstype, options, sensitiveOptions)
+}
+
+// UnmountContext is the same as Unmount(). On Windows, ctx is only checked
+// before unmounting.
+func (mounter *Mounter) UnmountContext(ctx context.Context, target string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return mounter.Unmount(target)
+}
+
+// ListContext is the same as List().
+func (mounter *Mounter) ListContext(ctx context.Context) ([]MountPoint, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return mounter.List()
+}
+
 // IsLikelyNotMountPoint determines if a directory is not a mountpoint.
 func (mounter *Mounter) IsLikelyNotMountPoint(file string) (bool, error) {
 	stat, err := os.Lstat(file)
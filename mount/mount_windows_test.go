@@ -27,6 +27,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	utilexec "k8s.io/utils/exec"
 	testingexec "k8s.io/utils/exec/testing"
 )
 
@@ -340,7 +341,7 @@ func TestNewSMBMapping(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		_, err := newSMBMapping(test.username, test.password, test.remotepath)
+		_, err := newSMBMapping(utilexec.New(), test.username, test.password, test.remotepath)
 		if test.expectError {
 			if err == nil {
 				t.Fatalf("expected error, got none during newSMBMapping(%s, %s, %s)", test.username, test.password, test.remotepath)
@@ -372,7 +373,7 @@ func TestIsValidPath(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		result, err := isValidPath(test.remotepath)
+		result, err := isValidPath(utilexec.New(), test.remotepath)
 		if result != test.expectedResult {
 			t.Fatalf("Expect result not equal with isValidPath(%s) return: %v, expected: %v, error: %v", test.remotepath, result, test.expectedResult, err)
 		}
@@ -0,0 +1,167 @@
+//go:build linux
+// +build linux
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mount
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"k8s.io/klog/v2"
+)
+
+// MountInfoSnapshot is a parsed and indexed copy of a mountinfo file. It
+// answers any number of queries after reading the file once, which matters on
+// nodes with thousands of mounts, where each call to Mounter.GetMountRefs
+// reads and parses the whole file. The snapshot is only updated by Refresh,
+// so callers decide how stale the answers may be. It is safe for concurrent
+// use.
+type MountInfoSnapshot struct {
+	mountInfoPath string
+
+	lock  sync.RWMutex
+	index *mountInfoIndex
+}
+
+// NewMountInfoSnapshot returns a snapshot of the mounts of the current
+// process, as listed in /proc/self/mountinfo.
+func NewMountInfoSnapshot() (*MountInfoSnapshot, error) {
+	return NewMountInfoSnapshotFromFile(procMountInfoPath)
+}
+
+// NewMountInfoSnapshotFromFile returns a snapshot of the mountinfo file at
+// mountInfoPath, e.g. /proc/<pid>/mountinfo for another mount namespace.
+func NewMountInfoSnapshotFromFile(mountInfoPath string) (*MountInfoSnapshot, error) {
+	s := &MountInfoSnapshot{mountInfoPath: mountInfoPath}
+	if err := s.Refresh(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Refresh reads the mountinfo file again. If it fails, the snapshot is left
+// unchanged.
+func (s *MountInfoSnapshot) Refresh() error {
+	mis, err := ParseMountInfo(s.mountInfoPath)
+	if err != nil {
+		return err
+	}
+	index := newMountInfoIndex(mis)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.index = index
+	return nil
+}
+
+// MountInfos returns the entries of the snapshot, in the order of the file.
+// The returned slice must not be modified.
+func (s *MountInfoSnapshot) MountInfos() []MountInfo {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.index.infos
+}
+
+// GetMountRefs is the same as Mounter.GetMountRefs, but looks up the mounts in
+// the snapshot.
+func (s *MountInfoSnapshot) GetMountRefs(pathname string) ([]string, error) {
+	pathExists, pathErr := PathExists(pathname)
+	if !pathExists {
+		return []string{}, nil
+	} else if IsCorruptedMnt(pathErr) {
+		klog.Warningf("GetMountRefs found corrupted mount at %s, treating as unmounted path", pathname)
+		return []string{}, nil
+	} else if pathErr != nil {
+		return nil, fmt.Errorf("error checking path %s: %v", pathname, pathErr)
+	}
+	realpath, err := filepath.EvalSymlinks(pathname)
+	if err != nil {
+		return nil, err
+	}
+	return s.SearchMountPoints(realpath)
+}
+
+// SearchMountPoints is the same as the SearchMountPoints function, but looks
+// up the mounts in the snapshot.
+func (s *MountInfoSnapshot) SearchMountPoints(hostSource string) ([]string, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.index.searchMountPoints(hostSource)
+}
+
+// mountSourceKey identifies the source of a mount: the path within the
+// filesystem that is mounted and the device of the filesystem.
+type mountSourceKey struct {
+	root         string
+	major, minor int
+}
+
+// mountInfoIndex indexes mountinfo entries by mount point and by source.
+type mountInfoIndex struct {
+	infos []MountInfo
+	// byMountPoint and bySource hold indices into infos, in ascending order.
+	byMountPoint map[string][]int
+	bySource     map[mountSourceKey][]int
+}
+
+func newMountInfoIndex(infos []MountInfo) *mountInfoIndex {
+	index := &mountInfoIndex{
+		infos:        infos,
+		byMountPoint: make(map[string][]int, len(infos)),
+		bySource:     make(map[mountSourceKey][]int, len(infos)),
+	}
+	for i, info := range infos {
+		index.byMountPoint[info.MountPoint] = append(index.byMountPoint[info.MountPoint], i)
+		key := mountSourceKey{root: info.Root, major: info.Major, minor: info.Minor}
+		index.bySource[key] = append(index.bySource[key], i)
+	}
+	return index
+}
+
+// searchMountPoints implements SearchMountPoints.
+func (index *mountInfoIndex) searchMountPoints(hostSource string) ([]string, error) {
+	// Find the mount hostSource is on: the last entry whose mount point is
+	// hostSource or one of its parents, since later mounts may overlap
+	// earlier ones.
+	found := -1
+	for dir := hostSource; ; dir = filepath.Dir(dir) {
+		if indices := index.byMountPoint[dir]; len(indices) > 0 && indices[len(indices)-1] > found {
+			found = indices[len(indices)-1]
+		}
+		if parent := filepath.Dir(dir); parent == dir {
+			break
+		}
+	}
+	if found < 0 {
+		return nil, fmt.Errorf("failed to get root path and major:minor for %s", hostSource)
+	}
+	mi := index.infos[found]
+	rootPath := filepath.Join(mi.Root, strings.TrimPrefix(hostSource, mi.MountPoint))
+
+	var refs []string
+	for _, i := range index.bySource[mountSourceKey{root: rootPath, major: mi.Major, minor: mi.Minor}] {
+		if index.infos[i].ID == mi.ID {
+			// Ignore mount entry for mount source itself.
+			continue
+		}
+		refs = append(refs, index.infos[i].MountPoint)
+	}
+	return refs, nil
+}
@@ -0,0 +1,164 @@
+//go:build linux
+// +build linux
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mount
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const snapshotMountInfo = `25 0 252:0 / / rw,relatime shared:1 - ext4 /dev/sda1 rw
+58 25 7:1 / /mnt/disks/blkvol1 rw,relatime shared:38 - ext4 /dev/loop1 rw
+70 25 7:1 / /var/lib/kubelet/plugins/vol1 rw,relatime shared:38 - ext4 /dev/loop1 rw
+71 25 7:1 /dir /var/lib/kubelet/pods/pod1/volumes/vol1 rw,relatime shared:38 - ext4 /dev/loop1 rw
+72 25 7:1 /dir /var/lib/kubelet/pods/pod2/volumes/vol1 rw,relatime shared:38 - ext4 /dev/loop1 rw
+80 58 0:40 / /mnt/disks/blkvol1 rw,relatime shared:40 - tmpfs tmpfs rw
+`
+
+func writeMountInfo(t testing.TB, content string) string {
+	filename := filepath.Join(t.TempDir(), "mountinfo")
+	if err := os.WriteFile(filename, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestMountInfoSnapshotSearchMountPoints(t *testing.T) {
+	filename := writeMountInfo(t, snapshotMountInfo)
+	snapshot, err := NewMountInfoSnapshotFromFile(filename)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := len(snapshot.MountInfos()); n != 6 {
+		t.Errorf("expected 6 entries, got %d", n)
+	}
+
+	tests := []struct {
+		source       string
+		expectedRefs []string
+	}{
+		{
+			source:       "/var/lib/kubelet/plugins/vol1",
+			expectedRefs: []string{"/mnt/disks/blkvol1"},
+		},
+		{
+			source:       "/var/lib/kubelet/plugins/vol1/dir",
+			expectedRefs: []string{"/var/lib/kubelet/pods/pod1/volumes/vol1", "/var/lib/kubelet/pods/pod2/volumes/vol1"},
+		},
+		{
+			source:       "/var/lib/kubelet/pods/pod1/volumes/vol1",
+			expectedRefs: []string{"/var/lib/kubelet/pods/pod2/volumes/vol1"},
+		},
+		{
+			// The tmpfs mounted last hides the loop device.
+			source: "/mnt/disks/blkvol1",
+		},
+		{
+			source: "/var/lib/other",
+		},
+	}
+	for _, test := range tests {
+		refs, err := snapshot.SearchMountPoints(test.source)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.source, err)
+		}
+		if !reflect.DeepEqual(refs, test.expectedRefs) {
+			t.Errorf("%s: expected refs %q, got %q", test.source, test.expectedRefs, refs)
+		}
+		// The snapshot must agree with reading the file every time.
+		fileRefs, err := SearchMountPoints(test.source, filename)
+		if err != nil || !reflect.DeepEqual(refs, fileRefs) {
+			t.Errorf("%s: SearchMountPoints returned %q, %v, snapshot returned %q", test.source, fileRefs, err, refs)
+		}
+	}
+}
+
+func TestMountInfoSnapshotRefresh(t *testing.T) {
+	filename := writeMountInfo(t, snapshotMountInfo)
+	snapshot, err := NewMountInfoSnapshotFromFile(filename)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	extra := "90 25 7:1 / /mnt/extra rw,relatime - ext4 /dev/loop1 rw\n"
+	if err := os.WriteFile(filename, []byte(snapshotMountInfo+extra), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"/mnt/disks/blkvol1"}
+	if refs, _ := snapshot.SearchMountPoints("/var/lib/kubelet/plugins/vol1"); !reflect.DeepEqual(refs, expected) {
+		t.Errorf("expected refs %q before Refresh, got %q", expected, refs)
+	}
+	if err := snapshot.Refresh(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = append(expected, "/mnt/extra")
+	if refs, _ := snapshot.SearchMountPoints("/var/lib/kubelet/plugins/vol1"); !reflect.DeepEqual(refs, expected) {
+		t.Errorf("expected refs %q after Refresh, got %q", expected, refs)
+	}
+
+	if err := os.WriteFile(filename, []byte("invalid"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := snapshot.Refresh(); err == nil {
+		t.Errorf("expected Refresh to fail")
+	}
+	if n := len(snapshot.MountInfos()); n != 7 {
+		t.Errorf("expected a failed Refresh to keep 7 entries, got %d", n)
+	}
+}
+
+// largeMountInfo returns the content of a mountinfo file with n pod volume
+// mounts, as found on busy nodes.
+func largeMountInfo(n int) string {
+	var b strings.Builder
+	b.WriteString("25 0 252:0 / / rw,relatime shared:1 - ext4 /dev/sda1 rw\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "%d 25 8:%d / /var/lib/kubelet/pods/pod%d/volumes/vol rw,relatime shared:%d - ext4 /dev/sd%d rw\n", 100+i, i%256, i, 100+i, i)
+	}
+	return b.String()
+}
+
+func BenchmarkSearchMountPoints(b *testing.B) {
+	filename := writeMountInfo(b, largeMountInfo(5000))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := SearchMountPoints(fmt.Sprintf("/var/lib/kubelet/pods/pod%d/volumes/vol", i%5000), filename); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMountInfoSnapshotSearchMountPoints(b *testing.B) {
+	filename := writeMountInfo(b, largeMountInfo(5000))
+	snapshot, err := NewMountInfoSnapshotFromFile(filename)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := snapshot.SearchMountPoints(fmt.Sprintf("/var/lib/kubelet/pods/pod%d/volumes/vol", i%5000)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
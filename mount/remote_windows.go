@@ -0,0 +1,249 @@
+//go:build windows
+// +build windows
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mount
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"k8s.io/klog/v2"
+	utilexec "k8s.io/utils/exec"
+)
+
+// SMBMounter mounts SMB shares on Windows. The share is mapped for the whole
+// node with New-SmbGlobalMapping and target becomes a symbolic link to it. The
+// first two mount options are the user name and the password; pass the
+// password in sensitiveOptions of MountSensitive so that it is never logged.
+// All input of the PowerShell commands is passed in environment variables, and
+// the link is created without a shell, so user names, passwords and paths need
+// no escaping. Unmount removes the link,
+// but not the mapping, which other targets may use.
+type SMBMounter struct {
+	mounter *Mounter
+	exec    utilexec.Interface
+}
+
+var _ Interface = &SMBMounter{}
+
+// NewSMBMounter returns an SMBMounter which runs commands with exec.
+func NewSMBMounter(exec utilexec.Interface) *SMBMounter {
+	return &SMBMounter{mounter: &Mounter{}, exec: exec}
+}
+
+// Mount maps the SMB share source, e.g. \\server\share, and links target to
+// it. fstype must be empty, "smb" or "cifs".
+func (m *SMBMounter) Mount(source string, target string, fstype string, options []string) error {
+	return m.MountSensitive(source, target, fstype, options, nil /* sensitiveOptions */)
+}
+
+// MountSensitive is the same as Mount() but this method allows
+// sensitiveOptions to be passed in a separate parameter from the normal
+// mount options and ensures the sensitiveOptions are never logged.
+func (m *SMBMounter) MountSensitive(source string, target string, fstype string, options []string, sensitiveOptions []string) error {
+	switch strings.ToLower(fstype) {
+	case "", "smb", "cifs":
+	default:
+		return fmt.Errorf("SMBMounter can't mount filesystem type %q", fstype)
+	}
+	remotePath, err := uncPath(source)
+	if err != nil {
+		return err
+	}
+	allOptions := append(append([]string{}, options...), sensitiveOptions...)
+	if len(allOptions) < 2 {
+		return fmt.Errorf("mount options(%q) should have at least 2 options, the user name and the password, current number:%d, source:%q, target:%q",
+			sanitizedOptionsForLogging(options, sensitiveOptions), len(allOptions), source, target)
+	}
+	klog.V(4).Infof("Mounting SMB share %q at %q", remotePath, target)
+	if err := mapSMB(m.exec, allOptions[0], allOptions[1], remotePath); err != nil {
+		return err
+	}
+	return linkRemotePath(remotePath, target)
+}
+
+// Unmount removes the link at target.
+func (m *SMBMounter) Unmount(target string) error {
+	return unlinkRemotePath(target)
+}
+
+// RemoveMapping removes the global mapping of the SMB share remotePath. It
+// should only be called once no target links to the share anymore.
+func (m *SMBMounter) RemoveMapping(remotePath string) error {
+	if output, err := removeSMBMapping(m.exec, remotePath); err != nil {
+		return fmt.Errorf("Remove-SmbGlobalMapping(%s) failed: %v, output: %q", remotePath, err, output)
+	}
+	return nil
+}
+
+// List is the same as Mounter.List().
+func (m *SMBMounter) List() ([]MountPoint, error) {
+	return m.mounter.List()
+}
+
+// IsLikelyNotMountPoint is the same as Mounter.IsLikelyNotMountPoint().
+func (m *SMBMounter) IsLikelyNotMountPoint(file string) (bool, error) {
+	return m.mounter.IsLikelyNotMountPoint(file)
+}
+
+// GetMountRefs is the same as Mounter.GetMountRefs().
+func (m *SMBMounter) GetMountRefs(pathname string) ([]string, error) {
+	return m.mounter.GetMountRefs(pathname)
+}
+
+// NFSMounter mounts NFS exports on Windows, using the Client for NFS feature.
+// target becomes a symbolic link to the UNC path of the export, which the
+// client serves directly. The client has no per-mount options: options must be
+// empty, and settings such as anonymous access or the mount type are
+// configured for the node with Set-NfsClientConfiguration. Unmount removes
+// the link.
+type NFSMounter struct {
+	mounter *Mounter
+	exec    utilexec.Interface
+}
+
+var _ Interface = &NFSMounter{}
+
+// NewNFSMounter returns an NFSMounter which runs commands with exec.
+func NewNFSMounter(exec utilexec.Interface) *NFSMounter {
+	return &NFSMounter{mounter: &Mounter{}, exec: exec}
+}
+
+// Mount links target to the NFS export source, given as server:/path or as
+// a UNC path. fstype must be empty or "nfs". It fails if the export can't be
+// accessed.
+func (m *NFSMounter) Mount(source string, target string, fstype string, options []string) error {
+	return m.MountSensitive(source, target, fstype, options, nil /* sensitiveOptions */)
+}
+
+// MountSensitive is the same as Mount(). Since options are not supported,
+// sensitiveOptions must be empty too.
+func (m *NFSMounter) MountSensitive(source string, target string, fstype string, options []string, sensitiveOptions []string) error {
+	if fstype != "" && strings.ToLower(fstype) != "nfs" {
+		return fmt.Errorf("NFSMounter can't mount filesystem type %q", fstype)
+	}
+	if len(options) > 0 || len(sensitiveOptions) > 0 {
+		return fmt.Errorf("mount options(%q) are not supported for NFS mounts on Windows, configure the NFS client instead",
+			sanitizedOptionsForLogging(options, sensitiveOptions))
+	}
+	remotePath, err := nfsRemotePath(source)
+	if err != nil {
+		return err
+	}
+	klog.V(4).Infof("Mounting NFS export %q at %q", remotePath, target)
+	valid, err := isValidPath(m.exec, remotePath)
+	if err != nil {
+		return fmt.Errorf("failed to access NFS export %s: %v", remotePath, err)
+	}
+	if !valid {
+		return fmt.Errorf("NFS export %s does not exist or can't be accessed", remotePath)
+	}
+	return linkRemotePath(remotePath, target)
+}
+
+// Unmount removes the link at target.
+func (m *NFSMounter) Unmount(target string) error {
+	return unlinkRemotePath(target)
+}
+
+// List is the same as Mounter.List().
+func (m *NFSMounter) List() ([]MountPoint, error) {
+	return m.mounter.List()
+}
+
+// IsLikelyNotMountPoint is the same as Mounter.IsLikelyNotMountPoint().
+func (m *NFSMounter) IsLikelyNotMountPoint(file string) (bool, error) {
+	return m.mounter.IsLikelyNotMountPoint(file)
+}
+
+// GetMountRefs is the same as Mounter.GetMountRefs().
+func (m *NFSMounter) GetMountRefs(pathname string) ([]string, error) {
+	return m.mounter.GetMountRefs(pathname)
+}
+
+// uncPath returns path with forward slashes replaced by backslashes, or an
+// error if it is not a UNC path such as \\server\share.
+func uncPath(path string) (string, error) {
+	unc := strings.ReplaceAll(path, "/", "\\")
+	if !strings.HasPrefix(unc, "\\\\") || len(strings.TrimLeft(unc, "\\")) == 0 {
+		return "", fmt.Errorf("invalid remote path %q: expected a UNC path like \\\\server\\share", path)
+	}
+	return unc, nil
+}
+
+// nfsRemotePath returns the UNC path of the NFS export source, which is either
+// a UNC path or of the form server:/path.
+func nfsRemotePath(source string) (string, error) {
+	// A single letter before ":/" is a drive letter.
+	if i := strings.Index(source, ":/"); i > 1 {
+		server, path := source[:i], strings.Trim(source[i+2:], "/")
+		if path == "" {
+			return "", fmt.Errorf("invalid NFS export %q: missing path", source)
+		}
+		return "\\\\" + server + "\\" + strings.ReplaceAll(path, "/", "\\"), nil
+	}
+	return uncPath(source)
+}
+
+const (
+	symbolicLinkFlagDirectory               = 0x1
+	symbolicLinkFlagAllowUnprivilegedCreate = 0x2
+
+	errorInvalidParameter = syscall.Errno(87)
+)
+
+// linkRemotePath creates target as a directory symbolic link to remotePath.
+// Unlike os.Symlink, it doesn't need remotePath to be accessible to create a
+// directory link.
+func linkRemotePath(remotePath string, target string) error {
+	target = NormalizeWindowsPath(target)
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	link, err := syscall.UTF16PtrFromString(target)
+	if err != nil {
+		return err
+	}
+	dest, err := syscall.UTF16PtrFromString(remotePath)
+	if err != nil {
+		return err
+	}
+	err = syscall.CreateSymbolicLink(link, dest, symbolicLinkFlagDirectory|symbolicLinkFlagAllowUnprivilegedCreate)
+	if err == errorInvalidParameter {
+		// Windows before 10 1703 doesn't know the unprivileged flag.
+		err = syscall.CreateSymbolicLink(link, dest, symbolicLinkFlagDirectory)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to link %q to %q: %v", target, remotePath, err)
+	}
+	klog.V(2).Infof("Linked %q to %q", target, remotePath)
+	return nil
+}
+
+// unlinkRemotePath removes the directory symbolic link target.
+func unlinkRemotePath(target string) error {
+	target = NormalizeWindowsPath(target)
+	if err := os.Remove(target); err != nil {
+		return fmt.Errorf("failed to remove link %q: %v", target, err)
+	}
+	return nil
+}
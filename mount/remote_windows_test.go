@@ -0,0 +1,177 @@
+//go:build windows
+// +build windows
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mount
+
+import (
+	"path/filepath"
+	"testing"
+
+	testingexec "k8s.io/utils/exec/testing"
+)
+
+func TestNFSRemotePath(t *testing.T) {
+	tests := []struct {
+		source      string
+		expected    string
+		expectError bool
+	}{
+		{source: "server:/export/dir", expected: `\\server\export\dir`},
+		{source: "server:/export/", expected: `\\server\export`},
+		{source: `\\server\export`, expected: `\\server\export`},
+		{source: "//server/export", expected: `\\server\export`},
+		{source: "server:/", expectError: true},
+		{source: "c:/export", expectError: true},
+		{source: "export", expectError: true},
+	}
+	for _, test := range tests {
+		path, err := nfsRemotePath(test.source)
+		if (err != nil) != test.expectError {
+			t.Errorf("%s: expected error: %v, got %v", test.source, test.expectError, err)
+		}
+		if path != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.source, test.expected, path)
+		}
+	}
+}
+
+func TestSMBMounter(t *testing.T) {
+	tests := []struct {
+		name             string
+		source           string
+		fstype           string
+		options          []string
+		sensitiveOptions []string
+		execScripts      []ExecArgs
+		expectError      bool
+	}{
+		{
+			name:             "new mapping",
+			source:           "//server/share",
+			fstype:           "cifs",
+			options:          []string{"user"},
+			sensitiveOptions: []string{"password"},
+			execScripts: []ExecArgs{
+				{"powershell", nil, "", nil},
+			},
+		},
+		{
+			name:             "existing valid mapping",
+			source:           `\\server\share`,
+			options:          []string{"user"},
+			sensitiveOptions: []string{"password"},
+			execScripts: []ExecArgs{
+				{"powershell", nil, "mapping exists", &testingexec.FakeExitError{Status: 1}},
+				{"powershell", nil, "", nil},
+				{"powershell", nil, "True", nil},
+			},
+		},
+		{
+			name:        "missing credentials",
+			source:      `\\server\share`,
+			options:     []string{"user"},
+			expectError: true,
+		},
+		{
+			name:             "not a UNC path",
+			source:           "share",
+			options:          []string{"user"},
+			sensitiveOptions: []string{"password"},
+			expectError:      true,
+		},
+		{
+			name:             "wrong filesystem type",
+			source:           `\\server\share`,
+			fstype:           "nfs",
+			options:          []string{"user"},
+			sensitiveOptions: []string{"password"},
+			expectError:      true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fakeExec := newScriptedExec(t, test.execScripts)
+			mounter := NewSMBMounter(fakeExec)
+			target := NormalizeWindowsPath(filepath.Join(t.TempDir(), "link"))
+			err := mounter.MountSensitive(test.source, target, test.fstype, test.options, test.sensitiveOptions)
+			if (err != nil) != test.expectError {
+				t.Errorf("expected error: %v, got %v", test.expectError, err)
+			}
+			if fakeExec.CommandCalls != len(test.execScripts) {
+				t.Errorf("expected %d commands, got %d", len(test.execScripts), fakeExec.CommandCalls)
+			}
+			if err == nil {
+				if err := mounter.Unmount(target); err != nil {
+					t.Errorf("expected the link to be removed, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestNFSMounter(t *testing.T) {
+	tests := []struct {
+		name        string
+		source      string
+		options     []string
+		execScripts []ExecArgs
+		expectError bool
+	}{
+		{
+			name:   "mount",
+			source: "server:/export",
+			execScripts: []ExecArgs{
+				{"powershell", []string{"/c", `Test-Path $Env:remoteapth`}, "True", nil},
+			},
+		},
+		{
+			name:   "inaccessible export",
+			source: "server:/export",
+			execScripts: []ExecArgs{
+				{"powershell", []string{"/c", `Test-Path $Env:remoteapth`}, "False", nil},
+			},
+			expectError: true,
+		},
+		{
+			name:        "options",
+			source:      "server:/export",
+			options:     []string{"nolock"},
+			expectError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fakeExec := newScriptedExec(t, test.execScripts)
+			mounter := NewNFSMounter(fakeExec)
+			target := NormalizeWindowsPath(filepath.Join(t.TempDir(), "link"))
+			err := mounter.Mount(test.source, target, "nfs", test.options)
+			if (err != nil) != test.expectError {
+				t.Errorf("expected error: %v, got %v", test.expectError, err)
+			}
+			if fakeExec.CommandCalls != len(test.execScripts) {
+				t.Errorf("expected %d commands, got %d", len(test.execScripts), fakeExec.CommandCalls)
+			}
+			if err == nil {
+				if err := mounter.Unmount(target); err != nil {
+					t.Errorf("expected the link to be removed, got %v", err)
+				}
+			}
+		})
+	}
+}
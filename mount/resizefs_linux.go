@@ -0,0 +1,241 @@
+//go:build linux
+// +build linux
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mount
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+	utilexec "k8s.io/utils/exec"
+)
+
+// ResizeFs resizes filesystems to fill the devices they are on, e.g. after a
+// volume has been expanded.
+type ResizeFs struct {
+	exec utilexec.Interface
+}
+
+// NewResizeFs returns a new ResizeFs which runs the resize tools with exec.
+func NewResizeFs(exec utilexec.Interface) *ResizeFs {
+	return &ResizeFs{exec: exec}
+}
+
+// Resize grows the filesystem on devicePath, mounted at deviceMountPath, to
+// the size of the device. ext3 and ext4 filesystems are resized with
+// resize2fs, xfs with xfs_growfs and btrfs with "btrfs filesystem resize",
+// the latter two require the filesystem to be mounted. It returns true if the
+// filesystem was resized and false if the device is not formatted, in which
+// case there is nothing to resize.
+func (resizefs *ResizeFs) Resize(devicePath string, deviceMountPath string) (bool, error) {
+	format, err := resizefs.getDiskFormat(devicePath)
+	if err != nil {
+		return false, fmt.Errorf("error checking format of device %s: %v", devicePath, err)
+	}
+	// mkfs uses the whole device anyway, so there's nothing to resize.
+	if format == "" {
+		return false, nil
+	}
+
+	klog.V(3).Infof("Expanding filesystem %s on device %s", format, devicePath)
+	switch format {
+	case "ext3", "ext4":
+		return resizefs.run(devicePath, "resize2fs", devicePath)
+	case "xfs":
+		return resizefs.run(devicePath, "xfs_growfs", "-d", deviceMountPath)
+	case "btrfs":
+		return resizefs.run(devicePath, "btrfs", "filesystem", "resize", "max", deviceMountPath)
+	}
+	return false, fmt.Errorf("resize of format %s is not supported for device %s mounted at %s", format, devicePath, deviceMountPath)
+}
+
+// run runs the resize command for devicePath.
+func (resizefs *ResizeFs) run(devicePath string, cmd string, args ...string) (bool, error) {
+	output, err := resizefs.exec.Command(cmd, args...).CombinedOutput()
+	if err != nil {
+		return false, fmt.Errorf("resize of device %s failed: %v. %s output: %s", devicePath, err, cmd, string(output))
+	}
+	klog.V(2).Infof("Device %s resized successfully", devicePath)
+	return true, nil
+}
+
+// NeedResize returns true if the filesystem on devicePath, mounted at
+// deviceMountPath, is smaller than the device. A difference of up to one
+// filesystem block is tolerated. It returns false for read-only and
+// unformatted devices.
+func (resizefs *ResizeFs) NeedResize(devicePath string, deviceMountPath string) (bool, error) {
+	readOnly, err := resizefs.getDeviceRO(devicePath)
+	if err != nil {
+		return false, err
+	}
+	if readOnly {
+		klog.V(3).Infof("Device %s is read-only, not resizing it", devicePath)
+		return false, nil
+	}
+	deviceSize, err := resizefs.getDeviceSize(devicePath)
+	if err != nil {
+		return false, err
+	}
+	format, err := resizefs.getDiskFormat(devicePath)
+	if err != nil {
+		return false, fmt.Errorf("error checking format of device %s: %v", devicePath, err)
+	}
+	if format == "" {
+		return false, nil
+	}
+
+	var blockSize, fsSize uint64
+	switch format {
+	case "ext3", "ext4":
+		blockSize, fsSize, err = resizefs.getExtSize(devicePath)
+	case "xfs":
+		blockSize, fsSize, err = resizefs.getXFSSize(deviceMountPath)
+	case "btrfs":
+		blockSize, fsSize, err = resizefs.getBtrfsSize(devicePath)
+	default:
+		return false, fmt.Errorf("can't get size of filesystem %s on device %s: supported filesystems are ext3, ext4, xfs and btrfs", format, devicePath)
+	}
+	if err != nil {
+		return false, err
+	}
+	klog.V(5).Infof("Device %s has size %d, its %s filesystem has size %d and block size %d", devicePath, deviceSize, format, fsSize, blockSize)
+	// Tolerate one block difference, just in case of rounding errors somewhere.
+	return deviceSize > fsSize+blockSize, nil
+}
+
+func (resizefs *ResizeFs) getDiskFormat(devicePath string) (string, error) {
+	return (&SafeFormatAndMount{Exec: resizefs.exec}).GetDiskFormat(devicePath)
+}
+
+// getDeviceSize returns the size of devicePath in bytes.
+func (resizefs *ResizeFs) getDeviceSize(devicePath string) (uint64, error) {
+	output, err := resizefs.exec.Command("blockdev", "--getsize64", devicePath).CombinedOutput()
+	outStr := strings.TrimSpace(string(output))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read size of device %s: %v: %s", devicePath, err, outStr)
+	}
+	size, err := strconv.ParseUint(outStr, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse size of device %s %s: %v", devicePath, outStr, err)
+	}
+	return size, nil
+}
+
+// getDeviceRO returns true if devicePath is read-only.
+func (resizefs *ResizeFs) getDeviceRO(devicePath string) (bool, error) {
+	output, err := resizefs.exec.Command("blockdev", "--getro", devicePath).CombinedOutput()
+	outStr := strings.TrimSpace(string(output))
+	if err != nil {
+		return false, fmt.Errorf("failed to get read-only bit from device %s: %v: %s", devicePath, err, outStr)
+	}
+	switch outStr {
+	case "0":
+		return false, nil
+	case "1":
+		return true, nil
+	default:
+		return false, fmt.Errorf("failed to get read-only bit from device %s: unexpected output %q", devicePath, outStr)
+	}
+}
+
+// getExtSize returns the block size and size in bytes of the ext filesystem on
+// devicePath.
+func (resizefs *ResizeFs) getExtSize(devicePath string) (uint64, uint64, error) {
+	output, err := resizefs.exec.Command("dumpe2fs", "-h", devicePath).CombinedOutput()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read size of filesystem on %s: %v: %s", devicePath, err, string(output))
+	}
+	blockSize, blockCount, err := parseFsInfoOutput(string(output), ":", "block size", "block count")
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read size of filesystem on %s: %v", devicePath, err)
+	}
+	return blockSize, blockSize * blockCount, nil
+}
+
+// getXFSSize returns the block size and size in bytes of the xfs filesystem
+// mounted at deviceMountPath.
+func (resizefs *ResizeFs) getXFSSize(deviceMountPath string) (uint64, uint64, error) {
+	output, err := resizefs.exec.Command("xfs_io", "-c", "statfs", deviceMountPath).CombinedOutput()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read size of filesystem mounted at %s: %v: %s", deviceMountPath, err, string(output))
+	}
+	blockSize, blockCount, err := parseFsInfoOutput(string(output), "=", "geom.bsize", "geom.datablocks")
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read size of filesystem mounted at %s: %v", deviceMountPath, err)
+	}
+	return blockSize, blockSize * blockCount, nil
+}
+
+// getBtrfsSize returns the sector size of the btrfs filesystem on devicePath
+// and the size in bytes it uses of devicePath. total_bytes is the size of the
+// whole filesystem, which may span several devices.
+func (resizefs *ResizeFs) getBtrfsSize(devicePath string) (uint64, uint64, error) {
+	output, err := resizefs.exec.Command("btrfs", "inspect-internal", "dump-super", "-f", devicePath).CombinedOutput()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read size of filesystem on %s: %v: %s", devicePath, err, string(output))
+	}
+	blockSize, totalBytes, err := parseFsInfoOutput(string(output), "", "sectorsize", "dev_item.total_bytes")
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read size of filesystem on %s: %v", devicePath, err)
+	}
+	return blockSize, totalBytes, nil
+}
+
+// parseFsInfoOutput returns the values of blockSizeKey and blockCountKey in
+// the output of a filesystem info tool, which has one "key<separator>value"
+// pair per line. Keys are compared case-insensitively. An empty separator
+// splits at whitespace.
+func parseFsInfoOutput(cmdOutput string, separator string, blockSizeKey string, blockCountKey string) (uint64, uint64, error) {
+	var blockSize, blockCount uint64
+	for _, line := range strings.Split(cmdOutput, "\n") {
+		var tokens []string
+		if separator == "" {
+			tokens = strings.Fields(line)
+		} else {
+			tokens = strings.SplitN(line, separator, 2)
+		}
+		if len(tokens) != 2 {
+			continue
+		}
+		key, value := strings.ToLower(strings.TrimSpace(tokens[0])), strings.TrimSpace(tokens[1])
+		var target *uint64
+		switch key {
+		case blockSizeKey:
+			target = &blockSize
+		case blockCountKey:
+			target = &blockCount
+		default:
+			continue
+		}
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to parse %s %q: %v", key, value, err)
+		}
+		*target = n
+	}
+	if blockSize == 0 {
+		return 0, 0, fmt.Errorf("could not find %s", blockSizeKey)
+	}
+	if blockCount == 0 {
+		return 0, 0, fmt.Errorf("could not find %s", blockCountKey)
+	}
+	return blockSize, blockCount, nil
+}
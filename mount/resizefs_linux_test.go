@@ -0,0 +1,189 @@
+//go:build linux
+// +build linux
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mount
+
+import (
+	"testing"
+
+	testingexec "k8s.io/utils/exec/testing"
+)
+
+var blkidArgs = []string{"-p", "-s", "TYPE", "-s", "PTTYPE", "-o", "export", "/dev/foo"}
+
+func TestNeedResize(t *testing.T) {
+	tests := []struct {
+		name        string
+		execScripts []ExecArgs
+		expected    bool
+		expectError bool
+	}{
+		{
+			name: "ext4 smaller than device",
+			execScripts: []ExecArgs{
+				{"blockdev", []string{"--getro", "/dev/foo"}, "0", nil},
+				{"blockdev", []string{"--getsize64", "/dev/foo"}, "2147483648\n", nil},
+				{"blkid", blkidArgs, "DEVNAME=/dev/foo\nTYPE=ext4\n", nil},
+				{"dumpe2fs", []string{"-h", "/dev/foo"}, "Filesystem volume name:   <none>\nBlock count:              262144\nBlock size:               4096\n", nil},
+			},
+			expected: true,
+		},
+		{
+			name: "ext4 within one block of device",
+			execScripts: []ExecArgs{
+				{"blockdev", []string{"--getro", "/dev/foo"}, "0", nil},
+				{"blockdev", []string{"--getsize64", "/dev/foo"}, "1073745920", nil},
+				{"blkid", blkidArgs, "DEVNAME=/dev/foo\nTYPE=ext4\n", nil},
+				{"dumpe2fs", []string{"-h", "/dev/foo"}, "Block count:              262144\nBlock size:               4096\n", nil},
+			},
+		},
+		{
+			name: "xfs smaller than device",
+			execScripts: []ExecArgs{
+				{"blockdev", []string{"--getro", "/dev/foo"}, "0", nil},
+				{"blockdev", []string{"--getsize64", "/dev/foo"}, "2147483648", nil},
+				{"blkid", blkidArgs, "DEVNAME=/dev/foo\nTYPE=xfs\n", nil},
+				{"xfs_io", []string{"-c", "statfs", "/mnt/foo"}, "fd.path = \"/mnt/foo\"\ngeom.bsize = 4096\ngeom.datablocks = 262144\n", nil},
+			},
+			expected: true,
+		},
+		{
+			name: "btrfs same size as device",
+			execScripts: []ExecArgs{
+				{"blockdev", []string{"--getro", "/dev/foo"}, "0", nil},
+				{"blockdev", []string{"--getsize64", "/dev/foo"}, "1073741824", nil},
+				{"blkid", blkidArgs, "DEVNAME=/dev/foo\nTYPE=btrfs\n", nil},
+				{"btrfs", []string{"inspect-internal", "dump-super", "-f", "/dev/foo"}, "csum_type\t\t0 (crc32c)\ntotal_bytes\t\t2147483648\nsectorsize\t\t4096\ndev_item.total_bytes\t1073741824\n", nil},
+			},
+		},
+		{
+			name: "btrfs smaller than device",
+			execScripts: []ExecArgs{
+				{"blockdev", []string{"--getro", "/dev/foo"}, "0", nil},
+				{"blockdev", []string{"--getsize64", "/dev/foo"}, "2147483648", nil},
+				{"blkid", blkidArgs, "DEVNAME=/dev/foo\nTYPE=btrfs\n", nil},
+				{"btrfs", []string{"inspect-internal", "dump-super", "-f", "/dev/foo"}, "total_bytes\t\t2147483648\nsectorsize\t\t4096\ndev_item.total_bytes\t1073741824\n", nil},
+			},
+			expected: true,
+		},
+		{
+			name: "read-only device",
+			execScripts: []ExecArgs{
+				{"blockdev", []string{"--getro", "/dev/foo"}, "1", nil},
+			},
+		},
+		{
+			name: "unformatted device",
+			execScripts: []ExecArgs{
+				{"blockdev", []string{"--getro", "/dev/foo"}, "0", nil},
+				{"blockdev", []string{"--getsize64", "/dev/foo"}, "1073741824", nil},
+				{"blkid", blkidArgs, "", &testingexec.FakeExitError{Status: 2}},
+			},
+		},
+		{
+			name: "missing block count",
+			execScripts: []ExecArgs{
+				{"blockdev", []string{"--getro", "/dev/foo"}, "0", nil},
+				{"blockdev", []string{"--getsize64", "/dev/foo"}, "1073741824", nil},
+				{"blkid", blkidArgs, "DEVNAME=/dev/foo\nTYPE=ext4\n", nil},
+				{"dumpe2fs", []string{"-h", "/dev/foo"}, "Block size:               4096\n", nil},
+			},
+			expectError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resizefs := NewResizeFs(newScriptedExec(t, test.execScripts))
+			needResize, err := resizefs.NeedResize("/dev/foo", "/mnt/foo")
+			if (err != nil) != test.expectError {
+				t.Fatalf("expected error: %v, got %v", test.expectError, err)
+			}
+			if needResize != test.expected {
+				t.Errorf("expected NeedResize to return %v, got %v", test.expected, needResize)
+			}
+		})
+	}
+}
+
+func TestResize(t *testing.T) {
+	tests := []struct {
+		name        string
+		execScripts []ExecArgs
+		expected    bool
+		expectError bool
+	}{
+		{
+			name: "ext4",
+			execScripts: []ExecArgs{
+				{"blkid", blkidArgs, "DEVNAME=/dev/foo\nTYPE=ext4\n", nil},
+				{"resize2fs", []string{"/dev/foo"}, "", nil},
+			},
+			expected: true,
+		},
+		{
+			name: "xfs",
+			execScripts: []ExecArgs{
+				{"blkid", blkidArgs, "DEVNAME=/dev/foo\nTYPE=xfs\n", nil},
+				{"xfs_growfs", []string{"-d", "/mnt/foo"}, "", nil},
+			},
+			expected: true,
+		},
+		{
+			name: "btrfs",
+			execScripts: []ExecArgs{
+				{"blkid", blkidArgs, "DEVNAME=/dev/foo\nTYPE=btrfs\n", nil},
+				{"btrfs", []string{"filesystem", "resize", "max", "/mnt/foo"}, "", nil},
+			},
+			expected: true,
+		},
+		{
+			name: "unformatted device",
+			execScripts: []ExecArgs{
+				{"blkid", blkidArgs, "", &testingexec.FakeExitError{Status: 2}},
+			},
+		},
+		{
+			name: "unsupported filesystem",
+			execScripts: []ExecArgs{
+				{"blkid", blkidArgs, "DEVNAME=/dev/foo\nTYPE=vfat\n", nil},
+			},
+			expectError: true,
+		},
+		{
+			name: "resize2fs fails",
+			execScripts: []ExecArgs{
+				{"blkid", blkidArgs, "DEVNAME=/dev/foo\nTYPE=ext4\n", nil},
+				{"resize2fs", []string{"/dev/foo"}, "", &testingexec.FakeExitError{Status: 1}},
+			},
+			expectError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resizefs := NewResizeFs(newScriptedExec(t, test.execScripts))
+			resized, err := resizefs.Resize("/dev/foo", "/mnt/foo")
+			if (err != nil) != test.expectError {
+				t.Fatalf("expected error: %v, got %v", test.expectError, err)
+			}
+			if resized != test.expected {
+				t.Errorf("expected Resize to return %v, got %v", test.expected, resized)
+			}
+		})
+	}
+}
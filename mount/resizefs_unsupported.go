@@ -0,0 +1,47 @@
+//go:build !linux
+// +build !linux
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mount
+
+import (
+	"fmt"
+
+	utilexec "k8s.io/utils/exec"
+)
+
+// ResizeFs resizes filesystems to fill the devices they are on, e.g. after a
+// volume has been expanded.
+type ResizeFs struct {
+	exec utilexec.Interface
+}
+
+// NewResizeFs returns a new ResizeFs which runs the resize tools with exec.
+func NewResizeFs(exec utilexec.Interface) *ResizeFs {
+	return &ResizeFs{exec: exec}
+}
+
+// Resize always returns an error on unsupported platforms
+func (resizefs *ResizeFs) Resize(devicePath string, deviceMountPath string) (bool, error) {
+	return false, fmt.Errorf("resize is not supported for this build")
+}
+
+// NeedResize always returns an error on unsupported platforms
+func (resizefs *ResizeFs) NeedResize(devicePath string, deviceMountPath string) (bool, error) {
+	return false, fmt.Errorf("resize is not supported for this build")
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mount
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"syscall"
+	"time"
+
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+)
+
+// RetryPolicy configures how NewRetrying retries mounts and unmounts.
+type RetryPolicy struct {
+	// Attempts is the maximum number of times an operation is tried,
+	// including the first attempt. Values below 1 mean a single attempt.
+	Attempts int
+	// InitialBackoff is the delay before the first retry. It doubles after
+	// each retry, up to MaxBackoff.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum delay between retries. Zero means no limit.
+	MaxBackoff time.Duration
+	// Jitter adds a random delay of up to Jitter times the backoff to each
+	// retry, as in clock.Jitter.
+	Jitter float64
+	// Timeout bounds the time spent on an operation, including all of its
+	// attempts. Zero means no limit. A running mount or unmount is only
+	// interrupted if the inner mounter implements ContextInterface.
+	Timeout time.Duration
+	// Retryable returns true if an operation which failed with err should
+	// be retried. Defaults to IsTransientMountError.
+	Retryable func(err error) bool
+	// Clock is used to wait between retries. Defaults to clock.RealClock.
+	Clock clock.Clock
+}
+
+// IsTransientMountError returns true if err is EBUSY or EAGAIN, or if its
+// message says so, as happens when the error comes from the output of the
+// mount or umount commands.
+func IsTransientMountError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, syscall.EBUSY) || errors.Is(err, syscall.EAGAIN) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "resource busy") || strings.Contains(msg, "resource temporarily unavailable")
+}
+
+// RetryError is returned by a mounter created by NewRetrying when an
+// operation still fails after it has been retried.
+type RetryError struct {
+	// Op is the operation, "mount" or "unmount".
+	Op string
+	// Target is the mount point the operation was for.
+	Target string
+	// Attempts is the number of times the operation was tried.
+	Attempts int
+	// Err is the error of the last attempt.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("%s of %s failed after %d attempts: %v", e.Op, e.Target, e.Attempts, e.Err)
+}
+
+// Unwrap returns the error of the last attempt.
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// NewRetrying returns a mounter which mounts and unmounts with inner, retrying
+// failed operations with exponential backoff as configured by policy. When an
+// operation has been retried and still fails, the error is a *RetryError.
+// Operations which fail with an error that isn't retryable return that error
+// as is. The other methods are passed through to inner.
+func NewRetrying(inner Interface, policy RetryPolicy) ContextInterface {
+	if policy.Attempts < 1 {
+		policy.Attempts = 1
+	}
+	if policy.Retryable == nil {
+		policy.Retryable = IsTransientMountError
+	}
+	if policy.Clock == nil {
+		policy.Clock = clock.RealClock{}
+	}
+	return &retryingMounter{inner: inner, policy: policy}
+}
+
+type retryingMounter struct {
+	inner  Interface
+	policy RetryPolicy
+}
+
+var _ ContextInterface = &retryingMounter{}
+var _ dryRunner = &retryingMounter{}
+
+// isDryRun is part of the dryRunner interface.
+func (m *retryingMounter) isDryRun() bool {
+	return IsDryRun(m.inner)
+}
+
+// retry calls op until it succeeds, fails with an error which isn't
+// retryable, runs out of attempts or ctx is done.
+func (m *retryingMounter) retry(ctx context.Context, opName string, target string, op func(ctx context.Context) error) error {
+	policy := m.policy
+	var deadline time.Time
+	if policy.Timeout > 0 {
+		deadline = policy.Clock.Now().Add(policy.Timeout)
+		var cancel context.CancelFunc
+		ctx, cancel = clock.WithDeadline(ctx, policy.Clock, deadline)
+		defer cancel()
+	}
+	backoff := policy.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := op(ctx)
+		if err == nil || !policy.Retryable(err) {
+			return err
+		}
+		if attempt >= policy.Attempts {
+			return &RetryError{Op: opName, Target: target, Attempts: attempt, Err: err}
+		}
+		delay := clock.Jitter(backoff, policy.Jitter)
+		if !deadline.IsZero() && !policy.Clock.Now().Add(delay).Before(deadline) {
+			// The next attempt would start too late.
+			return &RetryError{Op: opName, Target: target, Attempts: attempt, Err: err}
+		}
+		klog.V(4).Infof("Retrying %s of %s after error: %v", opName, target, err)
+		if sleepErr := clock.SleepContext(ctx, policy.Clock, delay); sleepErr != nil {
+			return &RetryError{Op: opName, Target: target, Attempts: attempt, Err: err}
+		}
+		backoff *= 2
+		if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+}
+
+// Mount is part of the Interface interface.
+func (m *retryingMounter) Mount(source string, target string, fstype string, options []string) error {
+	return m.MountSensitiveContext(context.Background(), source, target, fstype, options, nil /* sensitiveOptions */)
+}
+
+// MountSensitive is part of the Interface interface.
+func (m *retryingMounter) MountSensitive(source string, target string, fstype string, options []string, sensitiveOptions []string) error {
+	return m.MountSensitiveContext(context.Background(), source, target, fstype, options, sensitiveOptions)
+}
+
+// MountContext is part of the ContextInterface interface.
+func (m *retryingMounter) MountContext(ctx context.Context, source string, target string, fstype string, options []string) error {
+	return m.MountSensitiveContext(ctx, source, target, fstype, options, nil /* sensitiveOptions */)
+}
+
+// MountSensitiveContext is part of the ContextInterface interface.
+func (m *retryingMounter) MountSensitiveContext(ctx context.Context, source string, target string, fstype string, options []string, sensitiveOptions []string) error {
+	return m.retry(ctx, "mount", target, func(ctx context.Context) error {
+		if inner, ok := m.inner.(ContextInterface); ok {
+			return inner.MountSensitiveContext(ctx, source, target, fstype, options, sensitiveOptions)
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return m.inner.MountSensitive(source, target, fstype, options, sensitiveOptions)
+	})
+}
+
+// Unmount is part of the Interface interface.
+func (m *retryingMounter) Unmount(target string) error {
+	return m.UnmountContext(context.Background(), target)
+}
+
+// UnmountContext is part of the ContextInterface interface.
+func (m *retryingMounter) UnmountContext(ctx context.Context, target string) error {
+	return m.retry(ctx, "unmount", target, func(ctx context.Context) error {
+		if inner, ok := m.inner.(ContextInterface); ok {
+			return inner.UnmountContext(ctx, target)
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return m.inner.Unmount(target)
+	})
+}
+
+// List is part of the Interface interface.
+func (m *retryingMounter) List() ([]MountPoint, error) {
+	return m.inner.List()
+}
+
+// ListContext is part of the ContextInterface interface.
+func (m *retryingMounter) ListContext(ctx context.Context) ([]MountPoint, error) {
+	if inner, ok := m.inner.(ContextInterface); ok {
+		return inner.ListContext(ctx)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.inner.List()
+}
+
+// IsLikelyNotMountPoint is part of the Interface interface.
+func (m *retryingMounter) IsLikelyNotMountPoint(file string) (bool, error) {
+	return m.inner.IsLikelyNotMountPoint(file)
+}
+
+// GetMountRefs is part of the Interface interface.
+func (m *retryingMounter) GetMountRefs(pathname string) ([]string, error) {
+	return m.inner.GetMountRefs(pathname)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mount
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+func TestIsTransientMountError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected bool
+	}{
+		{nil, false},
+		{syscall.EBUSY, true},
+		{fmt.Errorf("unmount: %w", syscall.EAGAIN), true},
+		{errors.New("umount: /mnt/foo: target is busy (device or resource busy)"), true},
+		{errors.New("mount: /mnt/foo: special device /dev/foo does not exist"), false},
+	}
+	for _, test := range tests {
+		if got := IsTransientMountError(test.err); got != test.expected {
+			t.Errorf("IsTransientMountError(%v): expected %v, got %v", test.err, test.expected, got)
+		}
+	}
+}
+
+func TestRetryingUnmount(t *testing.T) {
+	busy := errors.New("target is busy: device or resource busy")
+	tests := []struct {
+		name             string
+		errs             []error
+		expectedAttempts int
+		expectedErr      error
+		expectRetryError bool
+	}{
+		{
+			name:             "success",
+			expectedAttempts: 1,
+		},
+		{
+			name:             "success after retries",
+			errs:             []error{busy, syscall.EBUSY},
+			expectedAttempts: 3,
+		},
+		{
+			name:             "not retryable",
+			errs:             []error{syscall.EINVAL},
+			expectedAttempts: 1,
+			expectedErr:      syscall.EINVAL,
+		},
+		{
+			name:             "out of attempts",
+			errs:             []error{busy, busy, busy, busy},
+			expectedAttempts: 3,
+			expectedErr:      busy,
+			expectRetryError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attempts := 0
+			fake := NewFakeMounter([]MountPoint{{Device: "/dev/foo", Path: "/mnt/foo"}})
+			fake.UnmountFunc = func(path string) error {
+				attempts++
+				if attempts <= len(test.errs) {
+					return test.errs[attempts-1]
+				}
+				return nil
+			}
+			mounter := NewRetrying(fake, RetryPolicy{Attempts: 3})
+			err := mounter.Unmount("/mnt/foo")
+			if attempts != test.expectedAttempts {
+				t.Errorf("expected %d attempts, got %d", test.expectedAttempts, attempts)
+			}
+			if !errors.Is(err, test.expectedErr) {
+				t.Errorf("expected error %v, got %v", test.expectedErr, err)
+			}
+			var retryErr *RetryError
+			if errors.As(err, &retryErr) != test.expectRetryError {
+				t.Errorf("expected RetryError: %v, got %v", test.expectRetryError, err)
+			} else if retryErr != nil && (retryErr.Attempts != test.expectedAttempts || retryErr.Op != "unmount" || retryErr.Target != "/mnt/foo") {
+				t.Errorf("unexpected RetryError %+v", retryErr)
+			}
+		})
+	}
+}
+
+func TestRetryingTimeout(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	attempts := 0
+	fake := NewFakeMounter([]MountPoint{{Device: "/dev/foo", Path: "/mnt/foo"}})
+	fake.UnmountFunc = func(path string) error {
+		attempts++
+		fakeClock.Step(time.Minute)
+		return syscall.EBUSY
+	}
+	mounter := NewRetrying(fake, RetryPolicy{Attempts: 10, InitialBackoff: time.Second, Timeout: 30 * time.Second, Clock: fakeClock})
+	err := mounter.Unmount("/mnt/foo")
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) || !errors.Is(err, syscall.EBUSY) {
+		t.Fatalf("expected RetryError wrapping EBUSY, got %v", err)
+	}
+	if attempts != 1 || retryErr.Attempts != 1 {
+		t.Errorf("expected a single attempt, got %d (%d in error)", attempts, retryErr.Attempts)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"reflect"
 	"runtime"
 	"strings"
 	"testing"
@@ -54,6 +55,26 @@ type ExecArgs struct {
 	err     error
 }
 
+// newScriptedExec returns a FakeExec that expects the commands in scripts to
+// be run in order, and returns their output. The arguments of scripts with nil
+// args are not checked.
+func newScriptedExec(t *testing.T, scripts []ExecArgs) *testingexec.FakeExec {
+	fakeExec := &testingexec.FakeExec{}
+	for _, script := range scripts {
+		script := script
+		fakeExec.CommandScript = append(fakeExec.CommandScript, func(cmd string, args ...string) exec.Cmd {
+			if cmd != script.command || (script.args != nil && !reflect.DeepEqual(args, script.args)) {
+				t.Errorf("expected command %s %q, got %s %q", script.command, script.args, cmd, args)
+			}
+			fakeCmd := &testingexec.FakeCmd{
+				CombinedOutputScript: []testingexec.FakeAction{makeFakeOutput(script.output, script.err)},
+			}
+			return testingexec.InitFakeCmd(fakeCmd, cmd, args...)
+		})
+	}
+	return fakeExec
+}
+
 func TestSafeFormatAndMount(t *testing.T) {
 	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
 		t.Skipf("not supported on GOOS=%s", runtime.GOOS)
@@ -295,3 +316,78 @@ func makeFakeOutput(output string, err error) testingexec.FakeAction {
 		return []byte(o), nil, err
 	}
 }
+
+func TestSafeFormatAndMountFormatOptions(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skipf("not supported on GOOS=%s", runtime.GOOS)
+	}
+	tests := []struct {
+		description  string
+		fstype       string
+		options      map[string][]string
+		skipDefaults bool
+		expectedArgs []string
+	}{
+		{
+			description:  "default ext4 options",
+			fstype:       "ext4",
+			expectedArgs: []string{"-F", "-m0", "/dev/foo"},
+		},
+		{
+			description:  "extra ext4 options",
+			fstype:       "ext4",
+			options:      map[string][]string{"ext4": {"-E", "lazy_itable_init=0"}, "xfs": {"-m", "crc=1"}},
+			expectedArgs: []string{"-F", "-m0", "-E", "lazy_itable_init=0", "/dev/foo"},
+		},
+		{
+			description:  "extra xfs options",
+			fstype:       "xfs",
+			options:      map[string][]string{"ext4": {"-E", "lazy_itable_init=0"}, "xfs": {"-m", "crc=1"}},
+			expectedArgs: []string{"-m", "crc=1", "/dev/foo"},
+		},
+		{
+			description:  "ext4 options without defaults",
+			fstype:       "ext4",
+			options:      map[string][]string{"ext4": {"-F", "-E", "lazy_itable_init=0"}},
+			skipDefaults: true,
+			expectedArgs: []string{"-F", "-E", "lazy_itable_init=0", "/dev/foo"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var mkfsArgs []string
+			fakeExec := &testingexec.FakeExec{}
+			fakeExec.CommandScript = []testingexec.FakeCommandAction{
+				func(cmd string, args ...string) exec.Cmd {
+					fakeCmd := &testingexec.FakeCmd{
+						CombinedOutputScript: []testingexec.FakeAction{makeFakeOutput("", &testingexec.FakeExitError{Status: 2})},
+					}
+					return testingexec.InitFakeCmd(fakeCmd, cmd, args...)
+				},
+				func(cmd string, args ...string) exec.Cmd {
+					if cmd != "mkfs."+test.fstype {
+						t.Errorf("expected mkfs.%s to be run, got %s", test.fstype, cmd)
+					}
+					mkfsArgs = args
+					fakeCmd := &testingexec.FakeCmd{
+						CombinedOutputScript: []testingexec.FakeAction{makeFakeOutput("", nil)},
+					}
+					return testingexec.InitFakeCmd(fakeCmd, cmd, args...)
+				},
+			}
+			mounter := SafeFormatAndMount{
+				Interface:                NewFakeMounter(nil),
+				Exec:                     fakeExec,
+				FormatOptions:            test.options,
+				SkipDefaultFormatOptions: test.skipDefaults,
+			}
+			if err := mounter.FormatAndMount("/dev/foo", t.TempDir(), test.fstype, nil); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(mkfsArgs, test.expectedArgs) {
+				t.Errorf("expected mkfs arguments %q, got %q", test.expectedArgs, mkfsArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"net"
+	"net/netip"
+	"sort"
+)
+
+// maxSplitBits is the largest number of additional prefix bits accepted by
+// SplitCIDR, bounding the result to 65536 subnets.
+const maxSplitBits = 16
+
+// SplitCIDR subdivides cidr into 2^newBits subnets, each with a prefix length
+// of newBits more than cidr's, and returns them in ascending order. For
+// example, splitting "10.0.0.0/16" with newBits 8 returns the 256 /24 subnets
+// "10.0.0.0/24" through "10.0.255.0/24". newBits must be between 0 and 16, and
+// must not extend the prefix length beyond the size of the address.
+func SplitCIDR(cidr *net.IPNet, newBits int) ([]*net.IPNet, error) {
+	if cidr == nil {
+		return nil, fmt.Errorf("invalid CIDR: nil")
+	}
+	ones, bits := cidr.Mask.Size()
+	if bits == 0 {
+		return nil, fmt.Errorf("invalid CIDR %q: non-canonical mask", cidr)
+	}
+	if newBits < 0 || newBits > maxSplitBits {
+		return nil, fmt.Errorf("invalid number of new bits %d: must be between 0 and %d", newBits, maxSplitBits)
+	}
+	if ones+newBits > bits {
+		return nil, fmt.Errorf("can't split CIDR %q by %d bits: prefix length would exceed %d", cidr, newBits, bits)
+	}
+
+	ip := cidr.IP.Mask(cidr.Mask)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid CIDR %q: IP and mask length mismatch", cidr)
+	}
+	newOnes := ones + newBits
+	mask := net.CIDRMask(newOnes, bits)
+	base := big.NewInt(0).SetBytes(ip)
+	step := big.NewInt(0).Lsh(big.NewInt(1), uint(bits-newOnes))
+
+	subnets := make([]*net.IPNet, 0, 1<<uint(newBits))
+	for i := 0; i < 1<<uint(newBits); i++ {
+		subnetIP := make(net.IP, len(ip))
+		base.FillBytes(subnetIP)
+		subnets = append(subnets, &net.IPNet{IP: subnetIP, Mask: mask})
+		base.Add(base, step)
+	}
+	return subnets, nil
+}
+
+// SplitCIDRString is like SplitCIDR but takes and returns CIDR strings.
+func SplitCIDRString(cidr string, newBits int) ([]string, error) {
+	_, parsed, err := ParseCIDRSloppy(cidr)
+	if err != nil {
+		return nil, err
+	}
+	subnets, err := SplitCIDR(parsed, newBits)
+	if err != nil {
+		return nil, err
+	}
+	strs := make([]string, 0, len(subnets))
+	for _, subnet := range subnets {
+		strs = append(strs, subnet.String())
+	}
+	return strs, nil
+}
+
+// SplitPrefix is like SplitCIDR but takes and returns netip.Prefix values.
+// IPv4-mapped IPv6 prefixes are treated as IPv4, so they are split into IPv4
+// prefixes.
+func SplitPrefix(prefix netip.Prefix, newBits int) ([]netip.Prefix, error) {
+	cidr := ipNetFromPrefix(prefix)
+	if cidr == nil {
+		return nil, fmt.Errorf("invalid prefix %q", prefix)
+	}
+	subnets, err := SplitCIDR(cidr, newBits)
+	if err != nil {
+		return nil, err
+	}
+	prefixes := make([]netip.Prefix, 0, len(subnets))
+	for _, subnet := range subnets {
+		prefixes = append(prefixes, prefixFromIPNet(subnet))
+	}
+	return prefixes, nil
+}
+
+// cidrPrefixLen returns the prefix length of cidr relative to its IP family,
+// so that an IPv4 CIDR expressed with a 16-byte mask (as happens with
+// IPv4-mapped IPv6 CIDRs) is treated the same as its 4-byte equivalent.
+func cidrPrefixLen(cidr *net.IPNet) int {
+	ones, bits := cidr.Mask.Size()
+	if bits == 8*net.IPv6len && IsIPv4CIDR(cidr) {
+		ones -= 8 * (net.IPv6len - net.IPv4len)
+		if ones < 0 {
+			ones = 0
+		}
+	}
+	return ones
+}
+
+// CIDRContainsIP returns true if ip is inside cidr. IPv4-mapped IPv6 addresses
+// are considered to be IPv4, so an IPv4 CIDR contains the mapped form of any
+// of its addresses and an IPv6 CIDR never contains an IPv4 address. It returns
+// false if either argument is nil or invalid.
+func CIDRContainsIP(cidr *net.IPNet, ip net.IP) bool {
+	if cidr == nil || IPFamilyOf(ip) == IPFamilyUnknown {
+		return false
+	}
+	return cidr.Contains(ip)
+}
+
+// CIDRContainsCIDR returns true if every address in inner is also in outer.
+// CIDRs of different IP families never contain each other; IPv4-mapped IPv6
+// CIDRs are treated as IPv4 as in CIDRContainsIP. It returns false if either
+// argument is nil or invalid.
+func CIDRContainsCIDR(outer, inner *net.IPNet) bool {
+	if outer == nil || inner == nil {
+		return false
+	}
+	if IPFamilyOfCIDR(outer) != IPFamilyOfCIDR(inner) {
+		return false
+	}
+	return cidrPrefixLen(outer) <= cidrPrefixLen(inner) && outer.Contains(inner.IP)
+}
+
+// CIDRsOverlap returns true if a and b have at least one address in common,
+// which is the case exactly when one of them contains the other. It returns
+// false if either argument is nil or invalid.
+func CIDRsOverlap(a, b *net.IPNet) bool {
+	return CIDRContainsCIDR(a, b) || CIDRContainsCIDR(b, a)
+}
+
+// PrefixContainsAddr is like CIDRContainsIP but takes a netip.Prefix and a
+// netip.Addr. It returns false if either argument is invalid.
+func PrefixContainsAddr(prefix netip.Prefix, addr netip.Addr) bool {
+	return CIDRContainsIP(ipNetFromPrefix(prefix), ipFromNetipAddr(addr))
+}
+
+// PrefixContainsPrefix is like CIDRContainsCIDR but takes netip.Prefix values.
+// It returns false if either argument is invalid.
+func PrefixContainsPrefix(outer, inner netip.Prefix) bool {
+	return CIDRContainsCIDR(ipNetFromPrefix(outer), ipNetFromPrefix(inner))
+}
+
+// PrefixesOverlap is like CIDRsOverlap but takes netip.Prefix values. It
+// returns false if either argument is invalid.
+func PrefixesOverlap(a, b netip.Prefix) bool {
+	return CIDRsOverlap(ipNetFromPrefix(a), ipNetFromPrefix(b))
+}
+
+// SummarizeCIDRs returns the smallest list of CIDRs that covers exactly the
+// same addresses as cidrs. CIDRs contained in other CIDRs are dropped and
+// adjacent CIDRs are merged into their common supernet where possible. The
+// result is sorted with IPv4 CIDRs first, then by address. nil and invalid
+// entries are ignored, and IPv4-mapped IPv6 CIDRs are treated as IPv4.
+func SummarizeCIDRs(cidrs []*net.IPNet) []*net.IPNet {
+	type prefix struct {
+		ip   net.IP
+		ones int
+	}
+
+	prefixes := make([]prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		var ip net.IP
+		var bits int
+		switch IPFamilyOfCIDR(cidr) {
+		case IPv4:
+			ip, bits = cidr.IP.To4(), 8*net.IPv4len
+		case IPv6:
+			ip, bits = cidr.IP.To16(), 8*net.IPv6len
+		default:
+			continue
+		}
+		if _, maskBits := cidr.Mask.Size(); maskBits == 0 {
+			continue
+		}
+		ones := cidrPrefixLen(cidr)
+		prefixes = append(prefixes, prefix{ip: ip.Mask(net.CIDRMask(ones, bits)), ones: ones})
+	}
+
+	// Sort IPv4 before IPv6, then by address, then with shorter prefixes first
+	// so that any CIDR contained in another immediately follows it.
+	sort.Slice(prefixes, func(i, j int) bool {
+		a, b := prefixes[i], prefixes[j]
+		if len(a.ip) != len(b.ip) {
+			return len(a.ip) < len(b.ip)
+		}
+		if c := bytes.Compare(a.ip, b.ip); c != 0 {
+			return c < 0
+		}
+		return a.ones < b.ones
+	})
+
+	merged := make([]prefix, 0, len(prefixes))
+	for _, p := range prefixes {
+		if n := len(merged); n > 0 {
+			last := merged[n-1]
+			bits := 8 * len(last.ip)
+			if len(last.ip) == len(p.ip) && last.ip.Equal(p.ip.Mask(net.CIDRMask(last.ones, bits))) {
+				// p is contained in last
+				continue
+			}
+		}
+		merged = append(merged, p)
+
+		// Merge the top of the stack with its sibling for as long as possible.
+		for len(merged) >= 2 {
+			a, b := merged[len(merged)-2], merged[len(merged)-1]
+			if len(a.ip) != len(b.ip) || a.ones != b.ones || a.ones == 0 {
+				break
+			}
+			bits := 8 * len(a.ip)
+			parent := a.ip.Mask(net.CIDRMask(a.ones-1, bits))
+			if !parent.Equal(a.ip) || !parent.Equal(b.ip.Mask(net.CIDRMask(a.ones-1, bits))) {
+				break
+			}
+			merged = merged[:len(merged)-2]
+			merged = append(merged, prefix{ip: parent, ones: a.ones - 1})
+		}
+	}
+
+	result := make([]*net.IPNet, 0, len(merged))
+	for _, p := range merged {
+		result = append(result, &net.IPNet{IP: p.ip, Mask: net.CIDRMask(p.ones, 8*len(p.ip))})
+	}
+	return result
+}
+
+// SummarizePrefixes is like SummarizeCIDRs but takes and returns netip.Prefix
+// values. Invalid entries are ignored.
+func SummarizePrefixes(prefixes []netip.Prefix) []netip.Prefix {
+	cidrs := make([]*net.IPNet, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		cidrs = append(cidrs, ipNetFromPrefix(prefix))
+	}
+	summarized := SummarizeCIDRs(cidrs)
+	result := make([]netip.Prefix, 0, len(summarized))
+	for _, cidr := range summarized {
+		result = append(result, prefixFromIPNet(cidr))
+	}
+	return result
+}
@@ -0,0 +1,456 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"net"
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+func TestSplitCIDR(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cidr        string
+		newBits     int
+		expected    []string
+		expectError bool
+	}{
+		{
+			name:     "IPv4 split in two",
+			cidr:     "10.0.0.0/16",
+			newBits:  1,
+			expected: []string{"10.0.0.0/17", "10.0.128.0/17"},
+		},
+		{
+			name:     "IPv4 split in four",
+			cidr:     "192.168.1.0/24",
+			newBits:  2,
+			expected: []string{"192.168.1.0/26", "192.168.1.64/26", "192.168.1.128/26", "192.168.1.192/26"},
+		},
+		{
+			name:     "IPv4 host bits are masked",
+			cidr:     "192.168.1.17/24",
+			newBits:  1,
+			expected: []string{"192.168.1.0/25", "192.168.1.128/25"},
+		},
+		{
+			name:     "IPv4 zero new bits",
+			cidr:     "192.168.1.0/24",
+			newBits:  0,
+			expected: []string{"192.168.1.0/24"},
+		},
+		{
+			name:     "IPv4 split to /32",
+			cidr:     "192.168.1.0/31",
+			newBits:  1,
+			expected: []string{"192.168.1.0/32", "192.168.1.1/32"},
+		},
+		{
+			name:     "IPv4 split at top of address space",
+			cidr:     "255.255.255.0/24",
+			newBits:  1,
+			expected: []string{"255.255.255.0/25", "255.255.255.128/25"},
+		},
+		{
+			name:     "IPv6 split in four",
+			cidr:     "2001:db8::/62",
+			newBits:  2,
+			expected: []string{"2001:db8::/64", "2001:db8:0:1::/64", "2001:db8:0:2::/64", "2001:db8:0:3::/64"},
+		},
+		{
+			name:     "IPv6 split to /128",
+			cidr:     "2001:db8::/127",
+			newBits:  1,
+			expected: []string{"2001:db8::/128", "2001:db8::1/128"},
+		},
+		{
+			name:        "IPv4 prefix too long",
+			cidr:        "192.168.1.0/31",
+			newBits:     2,
+			expectError: true,
+		},
+		{
+			name:        "IPv6 prefix too long",
+			cidr:        "2001:db8::/120",
+			newBits:     9,
+			expectError: true,
+		},
+		{
+			name:        "negative new bits",
+			cidr:        "192.168.1.0/24",
+			newBits:     -1,
+			expectError: true,
+		},
+		{
+			name:        "too many new bits",
+			cidr:        "2001:db8::/32",
+			newBits:     17,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, cidr, err := ParseCIDRSloppy(tc.cidr)
+			if err != nil {
+				t.Fatalf("failed to parse cidr %s, unexpected error: '%s'", tc.cidr, err)
+			}
+			subnets, err := SplitCIDR(cidr, tc.newBits)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := make([]string, 0, len(subnets))
+			for _, subnet := range subnets {
+				got = append(got, subnet.String())
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestSplitCIDRSize(t *testing.T) {
+	_, cidr, _ := ParseCIDRSloppy("10.0.0.0/8")
+	subnets, err := SplitCIDR(cidr, 16)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(subnets) != 65536 {
+		t.Fatalf("expected 65536 subnets, got %d", len(subnets))
+	}
+	if first := subnets[0].String(); first != "10.0.0.0/24" {
+		t.Errorf("expected first subnet 10.0.0.0/24, got %s", first)
+	}
+	if last := subnets[len(subnets)-1].String(); last != "10.255.255.0/24" {
+		t.Errorf("expected last subnet 10.255.255.0/24, got %s", last)
+	}
+}
+
+func TestSplitCIDRInvalid(t *testing.T) {
+	if _, err := SplitCIDR(nil, 1); err == nil {
+		t.Errorf("expected error for nil CIDR")
+	}
+	bad := &net.IPNet{IP: net.ParseIP("10.0.0.0"), Mask: net.IPMask{0xff, 0x00, 0xff, 0x00}}
+	if _, err := SplitCIDR(bad, 1); err == nil {
+		t.Errorf("expected error for non-canonical mask")
+	}
+}
+
+func TestSplitCIDRString(t *testing.T) {
+	subnets, err := SplitCIDRString("010.000.000.000/23", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"10.0.0.0/24", "10.0.1.0/24"}
+	if !reflect.DeepEqual(subnets, expected) {
+		t.Errorf("expected %v, got %v", expected, subnets)
+	}
+	if _, err := SplitCIDRString("not-a-cidr", 1); err == nil {
+		t.Errorf("expected error for invalid CIDR string")
+	}
+}
+
+func TestSplitPrefix(t *testing.T) {
+	testCases := []struct {
+		name        string
+		prefix      netip.Prefix
+		newBits     int
+		expected    []string
+		expectError bool
+	}{
+		{
+			name:     "IPv4",
+			prefix:   netip.MustParsePrefix("10.0.0.0/16"),
+			newBits:  1,
+			expected: []string{"10.0.0.0/17", "10.0.128.0/17"},
+		},
+		{
+			name:     "IPv4 host bits are masked",
+			prefix:   netip.MustParsePrefix("192.168.1.17/24"),
+			newBits:  1,
+			expected: []string{"192.168.1.0/25", "192.168.1.128/25"},
+		},
+		{
+			name:     "IPv4-mapped IPv6 is treated as IPv4",
+			prefix:   netip.MustParsePrefix("::ffff:10.0.0.0/120"),
+			newBits:  1,
+			expected: []string{"10.0.0.0/25", "10.0.0.128/25"},
+		},
+		{
+			name:     "IPv6",
+			prefix:   netip.MustParsePrefix("2001:db8::/62"),
+			newBits:  2,
+			expected: []string{"2001:db8::/64", "2001:db8:0:1::/64", "2001:db8:0:2::/64", "2001:db8:0:3::/64"},
+		},
+		{
+			name:        "prefix too long",
+			prefix:      netip.MustParsePrefix("192.168.1.0/31"),
+			newBits:     2,
+			expectError: true,
+		},
+		{
+			name:        "invalid prefix",
+			newBits:     1,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			subnets, err := SplitPrefix(tc.prefix, tc.newBits)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := make([]string, 0, len(subnets))
+			for _, subnet := range subnets {
+				got = append(got, subnet.String())
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestCIDRContainsIP(t *testing.T) {
+	testCases := []struct {
+		cidr     string
+		ip       string
+		expected bool
+	}{
+		{"10.0.0.0/8", "10.1.2.3", true},
+		{"10.0.0.0/8", "11.0.0.0", false},
+		{"10.0.0.0/8", "::ffff:10.1.2.3", true},
+		{"::ffff:10.0.0.0/104", "10.1.2.3", true},
+		{"::ffff:10.0.0.0/104", "11.1.2.3", false},
+		{"0.0.0.0/0", "2001:db8::1", false},
+		{"::/0", "1.2.3.4", false},
+		{"::/0", "2001:db8::1", true},
+		{"2001:db8::/64", "2001:db8::1", true},
+		{"2001:db8::/64", "2001:db8:0:1::1", false},
+	}
+
+	for _, tc := range testCases {
+		_, cidr, err := ParseCIDRSloppy(tc.cidr)
+		if err != nil {
+			t.Fatalf("failed to parse cidr %s, unexpected error: '%s'", tc.cidr, err)
+		}
+		ip := ParseIPSloppy(tc.ip)
+		if got := CIDRContainsIP(cidr, ip); got != tc.expected {
+			t.Errorf("CIDRContainsIP(%s, %s): expected %v, got %v", tc.cidr, tc.ip, tc.expected, got)
+		}
+		if got := PrefixContainsAddr(netip.MustParsePrefix(tc.cidr), netip.MustParseAddr(tc.ip)); got != tc.expected {
+			t.Errorf("PrefixContainsAddr(%s, %s): expected %v, got %v", tc.cidr, tc.ip, tc.expected, got)
+		}
+	}
+
+	if CIDRContainsIP(nil, net.ParseIP("1.2.3.4")) {
+		t.Errorf("expected nil CIDR to contain nothing")
+	}
+	_, cidr, _ := ParseCIDRSloppy("0.0.0.0/0")
+	if CIDRContainsIP(cidr, nil) {
+		t.Errorf("expected nil IP to not be contained")
+	}
+	if PrefixContainsAddr(netip.Prefix{}, netip.MustParseAddr("1.2.3.4")) || PrefixContainsAddr(netip.MustParsePrefix("0.0.0.0/0"), netip.Addr{}) {
+		t.Errorf("expected invalid prefix and address to never match")
+	}
+}
+
+func TestCIDRContainsCIDRAndOverlap(t *testing.T) {
+	testCases := []struct {
+		a        string
+		b        string
+		contains bool
+		overlaps bool
+	}{
+		{"10.0.0.0/8", "10.1.0.0/16", true, true},
+		{"10.1.0.0/16", "10.0.0.0/8", false, true},
+		{"10.0.0.0/8", "10.0.0.0/8", true, true},
+		{"10.0.0.0/8", "11.0.0.0/8", false, false},
+		{"10.0.0.0/16", "10.0.1.0/24", true, true},
+		{"10.0.0.0/24", "10.0.1.0/24", false, false},
+		{"10.0.0.0/8", "::ffff:10.1.0.0/112", true, true},
+		{"::ffff:10.0.0.0/104", "10.1.0.0/16", true, true},
+		{"::ffff:10.1.0.0/112", "10.0.0.0/8", false, true},
+		{"0.0.0.0/0", "::/0", false, false},
+		{"::/0", "10.0.0.0/8", false, false},
+		{"2001:db8::/32", "2001:db8:1::/48", true, true},
+		{"2001:db8:1::/48", "2001:db8::/32", false, true},
+		{"2001:db8:1::/48", "2001:db8:2::/48", false, false},
+	}
+
+	for _, tc := range testCases {
+		_, a, err := ParseCIDRSloppy(tc.a)
+		if err != nil {
+			t.Fatalf("failed to parse cidr %s, unexpected error: '%s'", tc.a, err)
+		}
+		_, b, err := ParseCIDRSloppy(tc.b)
+		if err != nil {
+			t.Fatalf("failed to parse cidr %s, unexpected error: '%s'", tc.b, err)
+		}
+		if got := CIDRContainsCIDR(a, b); got != tc.contains {
+			t.Errorf("CIDRContainsCIDR(%s, %s): expected %v, got %v", tc.a, tc.b, tc.contains, got)
+		}
+		if got := CIDRsOverlap(a, b); got != tc.overlaps {
+			t.Errorf("CIDRsOverlap(%s, %s): expected %v, got %v", tc.a, tc.b, tc.overlaps, got)
+		}
+		if got := CIDRsOverlap(b, a); got != tc.overlaps {
+			t.Errorf("CIDRsOverlap(%s, %s): expected %v, got %v", tc.b, tc.a, tc.overlaps, got)
+		}
+
+		pa, pb := netip.MustParsePrefix(tc.a), netip.MustParsePrefix(tc.b)
+		if got := PrefixContainsPrefix(pa, pb); got != tc.contains {
+			t.Errorf("PrefixContainsPrefix(%s, %s): expected %v, got %v", tc.a, tc.b, tc.contains, got)
+		}
+		if got := PrefixesOverlap(pa, pb); got != tc.overlaps {
+			t.Errorf("PrefixesOverlap(%s, %s): expected %v, got %v", tc.a, tc.b, tc.overlaps, got)
+		}
+	}
+
+	_, cidr, _ := ParseCIDRSloppy("0.0.0.0/0")
+	if CIDRContainsCIDR(cidr, nil) || CIDRContainsCIDR(nil, cidr) || CIDRsOverlap(nil, cidr) {
+		t.Errorf("expected nil CIDR to never contain or overlap")
+	}
+	if PrefixContainsPrefix(netip.MustParsePrefix("0.0.0.0/0"), netip.Prefix{}) || PrefixesOverlap(netip.Prefix{}, netip.MustParsePrefix("0.0.0.0/0")) {
+		t.Errorf("expected invalid prefix to never contain or overlap")
+	}
+}
+
+func TestSummarizeCIDRs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cidrs    []string
+		expected []string
+	}{
+		{
+			name:     "empty",
+			cidrs:    []string{},
+			expected: []string{},
+		},
+		{
+			name:     "single",
+			cidrs:    []string{"10.0.0.0/24"},
+			expected: []string{"10.0.0.0/24"},
+		},
+		{
+			name:     "duplicates",
+			cidrs:    []string{"10.0.0.0/24", "10.0.0.0/24"},
+			expected: []string{"10.0.0.0/24"},
+		},
+		{
+			name:     "contained",
+			cidrs:    []string{"10.0.1.0/24", "10.0.0.0/16", "10.0.2.128/25"},
+			expected: []string{"10.0.0.0/16"},
+		},
+		{
+			name:     "adjacent siblings",
+			cidrs:    []string{"10.0.1.0/24", "10.0.0.0/24"},
+			expected: []string{"10.0.0.0/23"},
+		},
+		{
+			name:     "adjacent non-siblings",
+			cidrs:    []string{"10.0.1.0/24", "10.0.2.0/24"},
+			expected: []string{"10.0.1.0/24", "10.0.2.0/24"},
+		},
+		{
+			name:     "cascading merge",
+			cidrs:    []string{"10.0.3.0/24", "10.0.0.0/24", "10.0.2.0/24", "10.0.1.0/24"},
+			expected: []string{"10.0.0.0/22"},
+		},
+		{
+			name:     "merge with different sizes",
+			cidrs:    []string{"10.0.0.0/25", "10.0.0.128/26", "10.0.0.192/26", "10.0.1.0/24"},
+			expected: []string{"10.0.0.0/23"},
+		},
+		{
+			name:     "host bits are masked",
+			cidrs:    []string{"10.0.0.5/24", "10.0.1.7/24"},
+			expected: []string{"10.0.0.0/23"},
+		},
+		{
+			name:     "whole address space",
+			cidrs:    []string{"0.0.0.0/1", "128.0.0.0/1"},
+			expected: []string{"0.0.0.0/0"},
+		},
+		{
+			name:     "IPv4-mapped IPv6 is IPv4",
+			cidrs:    []string{"::ffff:10.0.0.0/120", "10.0.1.0/24"},
+			expected: []string{"10.0.0.0/23"},
+		},
+		{
+			name:     "dual-stack",
+			cidrs:    []string{"2001:db8:0:1::/64", "10.0.1.0/24", "2001:db8::/64", "10.0.0.0/24", "192.168.0.0/16"},
+			expected: []string{"10.0.0.0/23", "192.168.0.0/16", "2001:db8::/63"},
+		},
+		{
+			name:     "IPv6 not merged across families",
+			cidrs:    []string{"::/1", "0.0.0.0/0", "8000::/2"},
+			expected: []string{"0.0.0.0/0", "::/1", "8000::/2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cidrs, err := ParseCIDRs(tc.cidrs)
+			if err != nil {
+				t.Fatalf("unexpected error parsing CIDRs: %v", err)
+			}
+			summarized := SummarizeCIDRs(cidrs)
+			got := make([]string, 0, len(summarized))
+			for _, cidr := range summarized {
+				got = append(got, cidr.String())
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+
+			prefixes := make([]netip.Prefix, 0, len(tc.cidrs))
+			for _, cidr := range tc.cidrs {
+				prefixes = append(prefixes, netip.MustParsePrefix(cidr))
+			}
+			got = got[:0]
+			for _, prefix := range SummarizePrefixes(prefixes) {
+				got = append(got, prefix.String())
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("SummarizePrefixes: expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+
+	if got := SummarizeCIDRs([]*net.IPNet{nil}); len(got) != 0 {
+		t.Errorf("expected nil CIDRs to be ignored, got %v", got)
+	}
+	if got := SummarizePrefixes([]netip.Prefix{{}}); len(got) != 0 {
+		t.Errorf("expected invalid prefixes to be ignored, got %v", got)
+	}
+}
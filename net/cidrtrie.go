@@ -0,0 +1,243 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"net"
+	"net/netip"
+)
+
+// CIDRTrie is a set of CIDRs stored in a binary radix trie, supporting
+// longest-prefix-match lookups of IPs. IPv4-mapped IPv6 CIDRs and IPs are
+// treated as IPv4. The zero value is an empty trie ready to use. It is not
+// thread safe.
+type CIDRTrie struct {
+	v4   *cidrTrieNode
+	v6   *cidrTrieNode
+	size int
+}
+
+type cidrTrieNode struct {
+	children [2]*cidrTrieNode
+	// cidr is non-nil if this node is a member of the set
+	cidr *net.IPNet
+}
+
+// NewCIDRTrie returns a CIDRTrie containing cidrs.
+func NewCIDRTrie(cidrs ...*net.IPNet) *CIDRTrie {
+	t := &CIDRTrie{}
+	for _, cidr := range cidrs {
+		t.Insert(cidr)
+	}
+	return t
+}
+
+// ipBit returns the i'th most significant bit of ip.
+func ipBit(ip net.IP, i int) int {
+	return int(ip[i/8]>>(7-uint(i%8))) & 1
+}
+
+// trieKey returns the normalized network address and prefix length of cidr,
+// or a nil IP if cidr is nil or invalid.
+func trieKey(cidr *net.IPNet) (net.IP, int) {
+	if cidr == nil {
+		return nil, 0
+	}
+	ip := normalizeIP(cidr.IP)
+	if ip == nil {
+		return nil, 0
+	}
+	if _, bits := cidr.Mask.Size(); bits == 0 {
+		return nil, 0
+	}
+	ones := cidrPrefixLen(cidr)
+	return ip.Mask(net.CIDRMask(ones, 8*len(ip))), ones
+}
+
+// root returns a pointer to the root node for ip's family.
+func (t *CIDRTrie) root(ip net.IP) **cidrTrieNode {
+	if len(ip) == net.IPv4len {
+		return &t.v4
+	}
+	return &t.v6
+}
+
+// Insert adds cidr to the trie. Host bits in cidr are ignored. It returns false
+// if cidr was already present or is invalid.
+func (t *CIDRTrie) Insert(cidr *net.IPNet) bool {
+	ip, ones := trieKey(cidr)
+	if ip == nil {
+		return false
+	}
+	node := t.root(ip)
+	for i := 0; ; i++ {
+		if *node == nil {
+			*node = &cidrTrieNode{}
+		}
+		if i == ones {
+			break
+		}
+		node = &(*node).children[ipBit(ip, i)]
+	}
+	if (*node).cidr != nil {
+		return false
+	}
+	(*node).cidr = &net.IPNet{IP: ip, Mask: net.CIDRMask(ones, 8*len(ip))}
+	t.size++
+	return true
+}
+
+// InsertPrefix is like Insert but takes a netip.Prefix.
+func (t *CIDRTrie) InsertPrefix(prefix netip.Prefix) bool {
+	return t.Insert(ipNetFromPrefix(prefix))
+}
+
+// Delete removes cidr from the trie. It returns false if cidr was not present.
+func (t *CIDRTrie) Delete(cidr *net.IPNet) bool {
+	ip, ones := trieKey(cidr)
+	if ip == nil {
+		return false
+	}
+	if !deleteFromTrie(t.root(ip), ip, 0, ones) {
+		return false
+	}
+	t.size--
+	return true
+}
+
+// DeletePrefix is like Delete but takes a netip.Prefix.
+func (t *CIDRTrie) DeletePrefix(prefix netip.Prefix) bool {
+	return t.Delete(ipNetFromPrefix(prefix))
+}
+
+// deleteFromTrie removes the entry for ip/ones from the subtree at node, which
+// is at the given depth, pruning any nodes left empty.
+func deleteFromTrie(node **cidrTrieNode, ip net.IP, depth, ones int) bool {
+	n := *node
+	if n == nil {
+		return false
+	}
+	if depth == ones {
+		if n.cidr == nil {
+			return false
+		}
+		n.cidr = nil
+	} else if !deleteFromTrie(&n.children[ipBit(ip, depth)], ip, depth+1, ones) {
+		return false
+	}
+	if n.cidr == nil && n.children[0] == nil && n.children[1] == nil {
+		*node = nil
+	}
+	return true
+}
+
+// Has returns true if cidr itself is in the trie.
+func (t *CIDRTrie) Has(cidr *net.IPNet) bool {
+	ip, ones := trieKey(cidr)
+	if ip == nil {
+		return false
+	}
+	node := *t.root(ip)
+	for i := 0; node != nil && i < ones; i++ {
+		node = node.children[ipBit(ip, i)]
+	}
+	return node != nil && node.cidr != nil
+}
+
+// HasPrefix is like Has but takes a netip.Prefix.
+func (t *CIDRTrie) HasPrefix(prefix netip.Prefix) bool {
+	return t.Has(ipNetFromPrefix(prefix))
+}
+
+// LongestPrefixMatch returns the most specific CIDR in the trie that contains
+// ip, or nil if there is none.
+func (t *CIDRTrie) LongestPrefixMatch(ip net.IP) *net.IPNet {
+	ip = normalizeIP(ip)
+	if ip == nil {
+		return nil
+	}
+	var match *net.IPNet
+	node := *t.root(ip)
+	for i := 0; node != nil; i++ {
+		if node.cidr != nil {
+			match = node.cidr
+		}
+		if i == 8*len(ip) {
+			break
+		}
+		node = node.children[ipBit(ip, i)]
+	}
+	if match == nil {
+		return nil
+	}
+	return &net.IPNet{IP: append(net.IP(nil), match.IP...), Mask: append(net.IPMask(nil), match.Mask...)}
+}
+
+// Contains returns true if any CIDR in the trie contains ip.
+func (t *CIDRTrie) Contains(ip net.IP) bool {
+	return t.LongestPrefixMatch(ip) != nil
+}
+
+// LongestPrefixMatchAddr is like LongestPrefixMatch but takes a netip.Addr and
+// returns a netip.Prefix. ok is false if no prefix in the trie contains addr.
+func (t *CIDRTrie) LongestPrefixMatchAddr(addr netip.Addr) (prefix netip.Prefix, ok bool) {
+	match := t.LongestPrefixMatch(ipFromNetipAddr(addr))
+	if match == nil {
+		return netip.Prefix{}, false
+	}
+	return prefixFromIPNet(match), true
+}
+
+// ContainsAddr is like Contains but takes a netip.Addr.
+func (t *CIDRTrie) ContainsAddr(addr netip.Addr) bool {
+	return t.Contains(ipFromNetipAddr(addr))
+}
+
+// Len returns the number of CIDRs in the trie.
+func (t *CIDRTrie) Len() int {
+	return t.size
+}
+
+// List returns the CIDRs in the trie, IPv4 first, ordered by address and then
+// by prefix length.
+func (t *CIDRTrie) List() []*net.IPNet {
+	cidrs := make([]*net.IPNet, 0, t.size)
+	var walk func(n *cidrTrieNode)
+	walk = func(n *cidrTrieNode) {
+		if n == nil {
+			return
+		}
+		if n.cidr != nil {
+			cidrs = append(cidrs, &net.IPNet{IP: append(net.IP(nil), n.cidr.IP...), Mask: append(net.IPMask(nil), n.cidr.Mask...)})
+		}
+		walk(n.children[0])
+		walk(n.children[1])
+	}
+	walk(t.v4)
+	walk(t.v6)
+	return cidrs
+}
+
+// Prefixes is like List but returns netip.Prefix values.
+func (t *CIDRTrie) Prefixes() []netip.Prefix {
+	cidrs := t.List()
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		prefixes = append(prefixes, prefixFromIPNet(cidr))
+	}
+	return prefixes
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+func mustParseCIDRs(t testing.TB, cidrs ...string) []*net.IPNet {
+	t.Helper()
+	parsed, err := ParseCIDRs(cidrs)
+	if err != nil {
+		t.Fatalf("unexpected error parsing CIDRs: %v", err)
+	}
+	return parsed
+}
+
+func cidrStrings(cidrs []*net.IPNet) []string {
+	strs := make([]string, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		strs = append(strs, cidr.String())
+	}
+	return strs
+}
+
+func TestCIDRTrieLongestPrefixMatch(t *testing.T) {
+	trie := NewCIDRTrie(mustParseCIDRs(t,
+		"10.0.0.0/8",
+		"10.1.0.0/16",
+		"10.1.2.0/24",
+		"10.1.2.3/32",
+		"192.168.0.0/16",
+		"2001:db8::/32",
+		"2001:db8:1::/48",
+	)...)
+
+	testCases := []struct {
+		ip       string
+		expected string
+	}{
+		{"10.2.3.4", "10.0.0.0/8"},
+		{"10.1.3.4", "10.1.0.0/16"},
+		{"10.1.2.4", "10.1.2.0/24"},
+		{"10.1.2.3", "10.1.2.3/32"},
+		{"::ffff:10.1.2.3", "10.1.2.3/32"},
+		{"192.168.255.255", "192.168.0.0/16"},
+		{"11.0.0.0", ""},
+		{"2001:db8::1", "2001:db8::/32"},
+		{"2001:db8:1::1", "2001:db8:1::/48"},
+		{"2001:db9::1", ""},
+		{"::a01:203", ""},
+		{"", ""},
+	}
+	for _, tc := range testCases {
+		match := trie.LongestPrefixMatch(ParseIPSloppy(tc.ip))
+		got := ""
+		if match != nil {
+			got = match.String()
+		}
+		if got != tc.expected {
+			t.Errorf("LongestPrefixMatch(%q): expected %q, got %q", tc.ip, tc.expected, got)
+		}
+		if contains := trie.Contains(ParseIPSloppy(tc.ip)); contains != (tc.expected != "") {
+			t.Errorf("Contains(%q): expected %v, got %v", tc.ip, tc.expected != "", contains)
+		}
+	}
+}
+
+func TestCIDRTrieInsertDelete(t *testing.T) {
+	trie := &CIDRTrie{}
+	cidrs := mustParseCIDRs(t, "10.0.0.0/8", "10.0.0.0/16", "0.0.0.0/0", "::/0", "2001:db8::1/128")
+
+	for _, cidr := range cidrs {
+		if !trie.Insert(cidr) {
+			t.Errorf("expected Insert(%s) to succeed", cidr)
+		}
+	}
+	if trie.Insert(cidrs[0]) {
+		t.Errorf("expected duplicate Insert to return false")
+	}
+	if dup := mustParseCIDRs(t, "10.1.2.3/8")[0]; trie.Insert(dup) {
+		t.Errorf("expected Insert with host bits set to be treated as duplicate")
+	}
+	if trie.Len() != len(cidrs) {
+		t.Errorf("expected Len %d, got %d", len(cidrs), trie.Len())
+	}
+	expected := []string{"0.0.0.0/0", "10.0.0.0/8", "10.0.0.0/16", "::/0", "2001:db8::1/128"}
+	if got := cidrStrings(trie.List()); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected List %v, got %v", expected, got)
+	}
+	for _, cidr := range cidrs {
+		if !trie.Has(cidr) {
+			t.Errorf("expected Has(%s)", cidr)
+		}
+	}
+	if trie.Has(mustParseCIDRs(t, "10.0.0.0/12")[0]) {
+		t.Errorf("expected Has(10.0.0.0/12) to be false")
+	}
+
+	if !trie.Delete(cidrs[0]) {
+		t.Errorf("expected Delete(%s) to succeed", cidrs[0])
+	}
+	if trie.Delete(cidrs[0]) {
+		t.Errorf("expected second Delete(%s) to fail", cidrs[0])
+	}
+	if trie.Delete(mustParseCIDRs(t, "10.0.0.0/24")[0]) {
+		t.Errorf("expected Delete of absent CIDR to fail")
+	}
+	if match := trie.LongestPrefixMatch(ParseIPSloppy("10.1.0.0")); match == nil || match.String() != "0.0.0.0/0" {
+		t.Errorf("expected 10.1.0.0 to match 0.0.0.0/0 after delete, got %v", match)
+	}
+	if match := trie.LongestPrefixMatch(ParseIPSloppy("10.0.1.0")); match == nil || match.String() != "10.0.0.0/16" {
+		t.Errorf("expected 10.0.1.0 to match 10.0.0.0/16 after delete, got %v", match)
+	}
+
+	for _, cidr := range cidrs[1:] {
+		if !trie.Delete(cidr) {
+			t.Errorf("expected Delete(%s) to succeed", cidr)
+		}
+	}
+	if trie.Len() != 0 || trie.v4 != nil || trie.v6 != nil {
+		t.Errorf("expected trie to be empty and pruned, got %d entries", trie.Len())
+	}
+	if trie.Insert(nil) || trie.Delete(nil) || trie.Has(nil) {
+		t.Errorf("expected nil CIDR operations to return false")
+	}
+}
+
+func TestCIDRTriePrefixes(t *testing.T) {
+	trie := &CIDRTrie{}
+	prefixes := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("::ffff:10.1.0.0/112"),
+		netip.MustParsePrefix("2001:db8::/32"),
+	}
+	for _, prefix := range prefixes {
+		if !trie.InsertPrefix(prefix) {
+			t.Errorf("expected InsertPrefix(%s) to succeed", prefix)
+		}
+	}
+	if trie.InsertPrefix(netip.MustParsePrefix("10.1.2.3/16")) {
+		t.Errorf("expected InsertPrefix with host bits set to be treated as duplicate")
+	}
+	if trie.InsertPrefix(netip.Prefix{}) || trie.HasPrefix(netip.Prefix{}) || trie.DeletePrefix(netip.Prefix{}) {
+		t.Errorf("expected invalid prefix operations to return false")
+	}
+	if !trie.HasPrefix(netip.MustParsePrefix("10.1.0.0/16")) {
+		t.Errorf("expected IPv4-mapped prefix to be stored as IPv4")
+	}
+	expected := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("10.1.0.0/16"),
+		netip.MustParsePrefix("2001:db8::/32"),
+	}
+	if got := trie.Prefixes(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected Prefixes %v, got %v", expected, got)
+	}
+
+	testCases := []struct {
+		addr     netip.Addr
+		expected netip.Prefix
+	}{
+		{netip.MustParseAddr("10.2.0.1"), netip.MustParsePrefix("10.0.0.0/8")},
+		{netip.MustParseAddr("::ffff:10.1.0.1"), netip.MustParsePrefix("10.1.0.0/16")},
+		{netip.MustParseAddr("2001:db8::1"), netip.MustParsePrefix("2001:db8::/32")},
+		{netip.MustParseAddr("11.0.0.1"), netip.Prefix{}},
+		{netip.Addr{}, netip.Prefix{}},
+	}
+	for _, tc := range testCases {
+		match, ok := trie.LongestPrefixMatchAddr(tc.addr)
+		if match != tc.expected || ok != tc.expected.IsValid() {
+			t.Errorf("LongestPrefixMatchAddr(%s): expected %s, got %s (%v)", tc.addr, tc.expected, match, ok)
+		}
+		if contains := trie.ContainsAddr(tc.addr); contains != tc.expected.IsValid() {
+			t.Errorf("ContainsAddr(%s): expected %v, got %v", tc.addr, tc.expected.IsValid(), contains)
+		}
+	}
+
+	if !trie.DeletePrefix(netip.MustParsePrefix("::ffff:10.0.0.0/104")) || trie.Len() != 2 {
+		t.Errorf("expected DeletePrefix to remove the IPv4-mapped form of 10.0.0.0/8")
+	}
+}
+
+func BenchmarkCIDRTrieLongestPrefixMatch(b *testing.B) {
+	trie := &CIDRTrie{}
+	for i := 0; i < 1000; i++ {
+		trie.Insert(mustParseCIDRs(b, fmt.Sprintf("10.%d.%d.0/24", i/256, i%256))[0])
+	}
+	ip := ParseIPSloppy("10.3.200.17")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		trie.LongestPrefixMatch(ip)
+	}
+}
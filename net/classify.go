@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"net"
+	"net/netip"
+)
+
+// IsLoopback returns true if ip is a loopback address ("127.0.0.0/8" or
+// "::1"). It returns false if ip is nil or invalid.
+func IsLoopback(ip net.IP) bool {
+	return IPFamilyOf(ip) != IPFamilyUnknown && ip.IsLoopback()
+}
+
+// IsLoopbackString is like IsLoopback but takes a string, which is parsed with
+// ParseIPSloppy. It returns false if ip cannot be parsed.
+func IsLoopbackString(ip string) bool {
+	return IsLoopback(ParseIPSloppy(ip))
+}
+
+// IsLoopbackAddr is like IsLoopback but takes a netip.Addr. IPv4-mapped IPv6
+// addresses are considered to be IPv4 and any zone is ignored.
+func IsLoopbackAddr(addr netip.Addr) bool {
+	return IsLoopback(ipFromNetipAddr(addr))
+}
+
+// IsLinkLocal returns true if ip is a link-local unicast address
+// ("169.254.0.0/16" or "fe80::/10"). It returns false if ip is nil or invalid.
+func IsLinkLocal(ip net.IP) bool {
+	return IPFamilyOf(ip) != IPFamilyUnknown && ip.IsLinkLocalUnicast()
+}
+
+// IsLinkLocalString is like IsLinkLocal but takes a string, which is parsed
+// with ParseIPSloppy. It returns false if ip cannot be parsed.
+func IsLinkLocalString(ip string) bool {
+	return IsLinkLocal(ParseIPSloppy(ip))
+}
+
+// IsLinkLocalAddr is like IsLinkLocal but takes a netip.Addr. IPv4-mapped IPv6
+// addresses are considered to be IPv4 and any zone is ignored.
+func IsLinkLocalAddr(addr netip.Addr) bool {
+	return IsLinkLocal(ipFromNetipAddr(addr))
+}
+
+// IsPrivate returns true if ip is a private address as defined by RFC 1918
+// (IPv4) or RFC 4193 (IPv6). It returns false if ip is nil or invalid.
+func IsPrivate(ip net.IP) bool {
+	return IPFamilyOf(ip) != IPFamilyUnknown && ip.IsPrivate()
+}
+
+// IsPrivateString is like IsPrivate but takes a string, which is parsed with
+// ParseIPSloppy. It returns false if ip cannot be parsed.
+func IsPrivateString(ip string) bool {
+	return IsPrivate(ParseIPSloppy(ip))
+}
+
+// IsPrivateAddr is like IsPrivate but takes a netip.Addr. IPv4-mapped IPv6
+// addresses are considered to be IPv4 and any zone is ignored.
+func IsPrivateAddr(addr netip.Addr) bool {
+	return IsPrivate(ipFromNetipAddr(addr))
+}
+
+// IsGlobalUnicast returns true if ip is a global unicast address, as defined
+// by net.IP.IsGlobalUnicast; note that this includes private addresses. It
+// returns false if ip is nil or invalid.
+func IsGlobalUnicast(ip net.IP) bool {
+	return IPFamilyOf(ip) != IPFamilyUnknown && ip.IsGlobalUnicast()
+}
+
+// IsGlobalUnicastString is like IsGlobalUnicast but takes a string, which is
+// parsed with ParseIPSloppy. It returns false if ip cannot be parsed.
+func IsGlobalUnicastString(ip string) bool {
+	return IsGlobalUnicast(ParseIPSloppy(ip))
+}
+
+// IsGlobalUnicastAddr is like IsGlobalUnicast but takes a netip.Addr.
+// IPv4-mapped IPv6 addresses are considered to be IPv4 and any zone is
+// ignored.
+func IsGlobalUnicastAddr(addr netip.Addr) bool {
+	return IsGlobalUnicast(ipFromNetipAddr(addr))
+}
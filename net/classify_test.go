@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+)
+
+func TestIPClassification(t *testing.T) {
+	testCases := []struct {
+		ip        string
+		loopback  bool
+		linkLocal bool
+		private   bool
+		global    bool
+	}{
+		{ip: "127.0.0.1", loopback: true},
+		{ip: "127.000.000.001", loopback: true},
+		{ip: "::1", loopback: true},
+		{ip: "::ffff:127.0.0.1", loopback: true},
+		{ip: "169.254.169.254", linkLocal: true},
+		{ip: "169.254.001.001", linkLocal: true},
+		{ip: "fe80::1", linkLocal: true},
+		{ip: "10.0.0.1", private: true, global: true},
+		{ip: "010.000.000.001", private: true, global: true},
+		{ip: "172.16.0.1", private: true, global: true},
+		{ip: "192.168.1.1", private: true, global: true},
+		{ip: "fd00::1", private: true, global: true},
+		{ip: "8.8.8.8", global: true},
+		{ip: "2001:4860:4860::8888", global: true},
+		{ip: "0.0.0.0"},
+		{ip: "::"},
+		{ip: "224.0.0.1"},
+		{ip: "ff02::1"},
+		{ip: "255.255.255.255"},
+		{ip: "fe80::1%eth0"},
+		{ip: " 10.0.0.1"},
+		{ip: "not an ip"},
+		{ip: ""},
+	}
+
+	for _, tc := range testCases {
+		if got := IsLoopbackString(tc.ip); got != tc.loopback {
+			t.Errorf("IsLoopbackString(%q): expected %v, got %v", tc.ip, tc.loopback, got)
+		}
+		if got := IsLinkLocalString(tc.ip); got != tc.linkLocal {
+			t.Errorf("IsLinkLocalString(%q): expected %v, got %v", tc.ip, tc.linkLocal, got)
+		}
+		if got := IsPrivateString(tc.ip); got != tc.private {
+			t.Errorf("IsPrivateString(%q): expected %v, got %v", tc.ip, tc.private, got)
+		}
+		if got := IsGlobalUnicastString(tc.ip); got != tc.global {
+			t.Errorf("IsGlobalUnicastString(%q): expected %v, got %v", tc.ip, tc.global, got)
+		}
+
+		ip := ParseIPSloppy(tc.ip)
+		if IsLoopback(ip) != tc.loopback || IsLinkLocal(ip) != tc.linkLocal || IsPrivate(ip) != tc.private || IsGlobalUnicast(ip) != tc.global {
+			t.Errorf("%q: net.IP variants disagree with string variants", tc.ip)
+		}
+		addr := addrFromIP(ip)
+		if IsLoopbackAddr(addr) != tc.loopback || IsLinkLocalAddr(addr) != tc.linkLocal || IsPrivateAddr(addr) != tc.private || IsGlobalUnicastAddr(addr) != tc.global {
+			t.Errorf("%q: netip.Addr variants disagree with string variants", tc.ip)
+		}
+	}
+
+	if !IsLoopbackAddr(netip.MustParseAddr("::ffff:127.0.0.1")) || !IsPrivateAddr(netip.MustParseAddr("::ffff:10.0.0.1")) {
+		t.Errorf("expected IPv4-mapped IPv6 addresses to be classified as IPv4")
+	}
+	if !IsLinkLocalAddr(netip.MustParseAddr("fe80::1%eth0")) {
+		t.Errorf("expected zoned link-local address to be link-local")
+	}
+	if IsLoopbackAddr(netip.Addr{}) || IsLinkLocalAddr(netip.Addr{}) || IsPrivateAddr(netip.Addr{}) || IsGlobalUnicastAddr(netip.Addr{}) {
+		t.Errorf("expected invalid netip.Addr to not be classified")
+	}
+
+	invalid := net.IP{10, 0, 0}
+	if IsLoopback(invalid) || IsLinkLocal(invalid) || IsPrivate(invalid) || IsGlobalUnicast(invalid) {
+		t.Errorf("expected invalid IP to not be classified")
+	}
+}
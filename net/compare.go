@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"bytes"
+	"net"
+	"sort"
+)
+
+// CompareIPs returns an integer comparing two IPs. The result will be 0 if a
+// and b are the same IP, -1 if a sorts before b, and +1 otherwise. Invalid IPs
+// sort before IPv4 IPs, which sort before IPv6 IPs; IPs of the same family
+// sort by address. IPv4-mapped IPv6 addresses are considered to be IPv4, so
+// "1.2.3.4" and "::ffff:1.2.3.4" compare equal.
+func CompareIPs(a, b net.IP) int {
+	a, b = normalizeIP(a), normalizeIP(b)
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return bytes.Compare(a, b)
+}
+
+// CompareCIDRs returns an integer comparing two CIDRs. The result will be 0 if
+// a and b are the same CIDR, -1 if a sorts before b, and +1 otherwise. CIDRs
+// are ordered by their network address as with CompareIPs, and then by prefix
+// length, with shorter prefixes first. Host bits are ignored. nil CIDRs sort
+// first.
+func CompareCIDRs(a, b *net.IPNet) int {
+	aIP, aOnes := trieKey(a)
+	bIP, bOnes := trieKey(b)
+	if c := CompareIPs(aIP, bIP); c != 0 {
+		return c
+	}
+	switch {
+	case aOnes < bOnes:
+		return -1
+	case aOnes > bOnes:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// SortIPs sorts ips in place in the order defined by CompareIPs. The sort is
+// stable, so equal IPs keep their relative order.
+func SortIPs(ips []net.IP) {
+	sort.SliceStable(ips, func(i, j int) bool {
+		return CompareIPs(ips[i], ips[j]) < 0
+	})
+}
+
+// SortCIDRs sorts cidrs in place in the order defined by CompareCIDRs. The sort
+// is stable, so equal CIDRs keep their relative order.
+func SortCIDRs(cidrs []*net.IPNet) {
+	sort.SliceStable(cidrs, func(i, j int) bool {
+		return CompareCIDRs(cidrs[i], cidrs[j]) < 0
+	})
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestCompareIPs(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.2.3.4", "1.2.3.4", 0},
+		{"1.2.3.4", "::ffff:1.2.3.4", 0},
+		{"1.2.3.4", "1.2.3.5", -1},
+		{"1.2.3.5", "1.2.3.4", 1},
+		{"9.0.0.0", "10.0.0.0", -1},
+		{"255.255.255.255", "::", -1},
+		{"::", "255.255.255.255", 1},
+		{"2001:db8::1", "2001:db8::2", -1},
+		{"2001:db8::1", "2001:db8::1", 0},
+		{"", "0.0.0.0", -1},
+		{"", "", 0},
+	}
+	for _, tc := range testCases {
+		if got := CompareIPs(ParseIPSloppy(tc.a), ParseIPSloppy(tc.b)); got != tc.expected {
+			t.Errorf("CompareIPs(%q, %q): expected %d, got %d", tc.a, tc.b, tc.expected, got)
+		}
+	}
+}
+
+func TestCompareCIDRs(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"10.0.0.0/8", "10.0.0.0/8", 0},
+		{"10.0.0.0/8", "10.1.0.0/8", 0},
+		{"10.0.0.0/8", "::ffff:10.0.0.0/104", 0},
+		{"10.0.0.0/8", "10.0.0.0/16", -1},
+		{"10.0.0.0/16", "10.0.0.0/8", 1},
+		{"10.0.0.0/16", "9.0.0.0/8", 1},
+		{"192.168.0.0/16", "::/0", -1},
+		{"2001:db8::/32", "2001:db8::/48", -1},
+		{"2001:db8:1::/48", "2001:db8::/32", 1},
+	}
+	for _, tc := range testCases {
+		_, a, err := ParseCIDRSloppy(tc.a)
+		if err != nil {
+			t.Fatalf("failed to parse cidr %s, unexpected error: '%s'", tc.a, err)
+		}
+		_, b, err := ParseCIDRSloppy(tc.b)
+		if err != nil {
+			t.Fatalf("failed to parse cidr %s, unexpected error: '%s'", tc.b, err)
+		}
+		if got := CompareCIDRs(a, b); got != tc.expected {
+			t.Errorf("CompareCIDRs(%q, %q): expected %d, got %d", tc.a, tc.b, tc.expected, got)
+		}
+	}
+	_, cidr, _ := ParseCIDRSloppy("0.0.0.0/0")
+	if got := CompareCIDRs(nil, cidr); got != -1 {
+		t.Errorf("expected nil CIDR to sort first, got %d", got)
+	}
+}
+
+func TestSortIPs(t *testing.T) {
+	input := []string{"2001:db8::2", "10.0.0.2", "::1", "10.0.0.10", "2001:db8::1", "10.0.0.1", "::ffff:10.0.0.1"}
+	expected := []string{"10.0.0.1", "10.0.0.1", "10.0.0.2", "10.0.0.10", "::1", "2001:db8::1", "2001:db8::2"}
+
+	ips := make([]net.IP, 0, len(input))
+	for _, s := range input {
+		ips = append(ips, ParseIPSloppy(s))
+	}
+	ips[5] = ips[5].To4()
+	SortIPs(ips)
+	got := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		got = append(got, ip.String())
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	// stability: the 4-byte and 16-byte forms of 10.0.0.1 keep their order
+	if len(ips[0]) != net.IPv4len || len(ips[1]) != net.IPv6len {
+		t.Errorf("expected sort to be stable")
+	}
+}
+
+func TestSortCIDRs(t *testing.T) {
+	cidrs := mustParseCIDRs(t, "2001:db8::/64", "10.0.0.0/16", "2001:db8::/32", "10.0.0.0/8", "192.168.0.0/16", "9.0.0.0/8")
+	SortCIDRs(cidrs)
+	expected := []string{"9.0.0.0/8", "10.0.0.0/8", "10.0.0.0/16", "192.168.0.0/16", "2001:db8::/32", "2001:db8::/64"}
+	if got := cidrStrings(cidrs); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"fmt"
+	"net"
+)
+
+// InterfaceAddrFilter decides whether an address assigned to a local network
+// interface should be included in the results of ListInterfaceAddrs.
+type InterfaceAddrFilter func(iface *net.Interface, ip net.IP) bool
+
+// interfaceLister abstracts the stdlib interface enumeration functions so they
+// can be mocked for unit-testing.
+type interfaceLister interface {
+	Interfaces() ([]net.Interface, error)
+	InterfaceByName(name string) (*net.Interface, error)
+	Addrs(iface *net.Interface) ([]net.Addr, error)
+}
+
+type netInterfaceLister struct{}
+
+func (netInterfaceLister) Interfaces() ([]net.Interface, error) {
+	return net.Interfaces()
+}
+
+func (netInterfaceLister) InterfaceByName(name string) (*net.Interface, error) {
+	return net.InterfaceByName(name)
+}
+
+func (netInterfaceLister) Addrs(iface *net.Interface) ([]net.Addr, error) {
+	return iface.Addrs()
+}
+
+// ipFromAddr returns the IP of an address returned by net.Interface.Addrs(), or
+// nil if it is not an IP address.
+func ipFromAddr(addr net.Addr) net.IP {
+	switch a := addr.(type) {
+	case *net.IPNet:
+		return a.IP
+	case *net.IPAddr:
+		return a.IP
+	default:
+		return nil
+	}
+}
+
+// ListInterfaceAddrs returns the IPs assigned to local network interfaces for
+// which filter returns true, in interface order. If filter is nil, all IPs
+// are returned. IPv4 IPs are returned in 4-byte form.
+func ListInterfaceAddrs(filter InterfaceAddrFilter) ([]net.IP, error) {
+	return listInterfaceAddrs(netInterfaceLister{}, filter)
+}
+
+func listInterfaceAddrs(lister interfaceLister, filter InterfaceAddrFilter) ([]net.IP, error) {
+	ifaces, err := lister.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	var ips []net.IP
+	for i := range ifaces {
+		iface := &ifaces[i]
+		addrs, err := lister.Addrs(iface)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get addresses of interface %q: %w", iface.Name, err)
+		}
+		for _, addr := range addrs {
+			ip := normalizeIP(ipFromAddr(addr))
+			if ip == nil {
+				continue
+			}
+			if filter == nil || filter(iface, ip) {
+				ips = append(ips, ip)
+			}
+		}
+	}
+	return ips, nil
+}
+
+// GetIPsByInterfaceName returns the IPs assigned to the named local network
+// interface. It returns an error if no interface has that name.
+func GetIPsByInterfaceName(name string) ([]net.IP, error) {
+	return getIPsByInterfaceName(netInterfaceLister{}, name)
+}
+
+func getIPsByInterfaceName(lister interfaceLister, name string) ([]net.IP, error) {
+	iface, err := lister.InterfaceByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get interface %q: %w", name, err)
+	}
+	addrs, err := lister.Addrs(iface)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get addresses of interface %q: %w", iface.Name, err)
+	}
+	var ips []net.IP
+	for _, addr := range addrs {
+		if ip := normalizeIP(ipFromAddr(addr)); ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+	return ips, nil
+}
+
+// ChooseBindAddress returns a global unicast IP assigned to a local network
+// interface that is up and is not a loopback interface, suitable as a default
+// address for a daemon to bind to or advertise. If preferredFamily is not
+// IPFamilyUnknown, an IP of that family is returned if one exists; otherwise
+// an IP of the other family is returned. It returns an error if no suitable
+// IP exists.
+func ChooseBindAddress(preferredFamily IPFamily) (net.IP, error) {
+	return chooseBindAddress(netInterfaceLister{}, preferredFamily)
+}
+
+func chooseBindAddress(lister interfaceLister, preferredFamily IPFamily) (net.IP, error) {
+	ips, err := listInterfaceAddrs(lister, func(iface *net.Interface, ip net.IP) bool {
+		return iface.Flags&net.FlagUp != 0 && iface.Flags&net.FlagLoopback == 0 && ip.IsGlobalUnicast()
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("no global unicast IP found on any active non-loopback interface")
+	}
+	if preferredFamily != IPFamilyUnknown {
+		for _, ip := range ips {
+			if IPFamilyOf(ip) == preferredFamily {
+				return ip, nil
+			}
+		}
+	}
+	return ips[0], nil
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"testing"
+)
+
+type fakeInterface struct {
+	iface    net.Interface
+	addrs    []string
+	addrsErr error
+}
+
+type fakeInterfaceLister struct {
+	ifaces   []fakeInterface
+	addrsErr error
+}
+
+func (f *fakeInterfaceLister) Interfaces() ([]net.Interface, error) {
+	ifaces := make([]net.Interface, 0, len(f.ifaces))
+	for _, fi := range f.ifaces {
+		ifaces = append(ifaces, fi.iface)
+	}
+	return ifaces, nil
+}
+
+func (f *fakeInterfaceLister) InterfaceByName(name string) (*net.Interface, error) {
+	for _, fi := range f.ifaces {
+		if fi.iface.Name == name {
+			iface := fi.iface
+			return &iface, nil
+		}
+	}
+	return nil, fmt.Errorf("no such network interface")
+}
+
+func (f *fakeInterfaceLister) Addrs(iface *net.Interface) ([]net.Addr, error) {
+	if f.addrsErr != nil {
+		return nil, f.addrsErr
+	}
+	for _, fi := range f.ifaces {
+		if fi.iface.Name != iface.Name {
+			continue
+		}
+		if fi.addrsErr != nil {
+			return nil, fi.addrsErr
+		}
+		var addrs []net.Addr
+		for _, a := range fi.addrs {
+			ip, cidr, err := ParseCIDRSloppy(a)
+			if err != nil {
+				addrs = append(addrs, &net.IPAddr{IP: ParseIPSloppy(a)})
+				continue
+			}
+			addrs = append(addrs, &net.IPNet{IP: ip, Mask: cidr.Mask})
+		}
+		return addrs, nil
+	}
+	return nil, nil
+}
+
+func newFakeInterfaceLister() *fakeInterfaceLister {
+	return &fakeInterfaceLister{
+		ifaces: []fakeInterface{
+			{
+				iface: net.Interface{Name: "lo", Flags: net.FlagUp | net.FlagLoopback},
+				addrs: []string{"127.0.0.1/8", "::1/128"},
+			},
+			{
+				iface: net.Interface{Name: "eth0", Flags: net.FlagUp},
+				addrs: []string{"fe80::1/64", "2001:db8::10/64", "192.168.0.10/24"},
+			},
+			{
+				iface: net.Interface{Name: "eth1"},
+				addrs: []string{"10.0.0.10/24"},
+			},
+			{
+				iface: net.Interface{Name: "eth2", Flags: net.FlagUp},
+			},
+		},
+	}
+}
+
+func ipStrings(ips []net.IP) []string {
+	strs := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		strs = append(strs, ip.String())
+	}
+	return strs
+}
+
+func TestListInterfaceAddrs(t *testing.T) {
+	lister := newFakeInterfaceLister()
+
+	ips, err := listInterfaceAddrs(lister, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"127.0.0.1", "::1", "fe80::1", "2001:db8::10", "192.168.0.10", "10.0.0.10"}
+	if got := ipStrings(ips); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if len(ips[0]) != net.IPv4len {
+		t.Errorf("expected IPv4 addresses in 4-byte form")
+	}
+
+	ips, err = listInterfaceAddrs(lister, func(iface *net.Interface, ip net.IP) bool {
+		return IsIPv4(ip) && iface.Flags&net.FlagLoopback == 0
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = []string{"192.168.0.10", "10.0.0.10"}
+	if got := ipStrings(ips); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	lister.addrsErr = fmt.Errorf("boom")
+	if _, err := listInterfaceAddrs(lister, nil); err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+func TestGetIPsByInterfaceName(t *testing.T) {
+	lister := newFakeInterfaceLister()
+
+	ips, err := getIPsByInterfaceName(lister, "eth0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"fe80::1", "2001:db8::10", "192.168.0.10"}
+	if got := ipStrings(ips); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	ips, err = getIPsByInterfaceName(lister, "eth2")
+	if err != nil {
+		t.Errorf("unexpected error for interface without addresses: %v", err)
+	}
+	if len(ips) != 0 {
+		t.Errorf("expected no IPs, got %v", ips)
+	}
+
+	if _, err := getIPsByInterfaceName(lister, "eth3"); err == nil {
+		t.Errorf("expected error for unknown interface")
+	}
+
+	// Errors from other interfaces must not affect the lookup.
+	lister.ifaces[2].addrsErr = fmt.Errorf("boom")
+	ips, err = getIPsByInterfaceName(lister, "eth0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := ipStrings(ips); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if _, err := getIPsByInterfaceName(lister, "eth1"); err == nil {
+		t.Errorf("expected error for interface whose addresses can't be listed")
+	}
+}
+
+func TestChooseBindAddress(t *testing.T) {
+	testCases := []struct {
+		name        string
+		family      IPFamily
+		ifaces      []fakeInterface
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "no preference",
+			family:   IPFamilyUnknown,
+			ifaces:   newFakeInterfaceLister().ifaces,
+			expected: "2001:db8::10",
+		},
+		{
+			name:     "prefer IPv4",
+			family:   IPv4,
+			ifaces:   newFakeInterfaceLister().ifaces,
+			expected: "192.168.0.10",
+		},
+		{
+			name:     "prefer IPv6",
+			family:   IPv6,
+			ifaces:   newFakeInterfaceLister().ifaces,
+			expected: "2001:db8::10",
+		},
+		{
+			name:   "fall back to other family",
+			family: IPv6,
+			ifaces: []fakeInterface{
+				{iface: net.Interface{Name: "eth0", Flags: net.FlagUp}, addrs: []string{"fe80::1/64", "192.168.0.10/24"}},
+			},
+			expected: "192.168.0.10",
+		},
+		{
+			name:   "only loopback and down interfaces",
+			family: IPv4,
+			ifaces: []fakeInterface{
+				{iface: net.Interface{Name: "lo", Flags: net.FlagUp | net.FlagLoopback}, addrs: []string{"127.0.0.1/8"}},
+				{iface: net.Interface{Name: "eth0"}, addrs: []string{"192.168.0.10/24"}},
+			},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ip, err := chooseBindAddress(&fakeInterfaceLister{ifaces: tc.ifaces}, tc.family)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error, got %v", ip)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ip.String() != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, ip)
+			}
+		})
+	}
+}
+
+func TestListInterfaceAddrsReal(t *testing.T) {
+	if _, err := ListInterfaceAddrs(nil); err != nil {
+		t.Errorf("unexpected error listing real interfaces: %v", err)
+	}
+}
@@ -19,6 +19,7 @@ package net
 import (
 	"fmt"
 	"net"
+	"net/netip"
 )
 
 // IPFamily refers to a specific family if not empty, i.e. "4" or "6".
@@ -179,3 +180,123 @@ func IsIPv4CIDR(cidr *net.IPNet) bool {
 func IsIPv4CIDRString(cidr string) bool {
 	return IPFamilyOfCIDRString(cidr) == IPv4
 }
+
+// orderByFamily returns a copy of items with the items of the primary family
+// first, followed by those of the other family and then those with an unknown
+// family, preserving the relative order within each group.
+func orderByFamily[T any](primary IPFamily, items []T, familyOf func(T) IPFamily) []T {
+	ordered := make([]T, 0, len(items))
+	var others, unknown []T
+	for _, item := range items {
+		switch familyOf(item) {
+		case primary:
+			ordered = append(ordered, item)
+		case IPFamilyUnknown:
+			unknown = append(unknown, item)
+		default:
+			others = append(others, item)
+		}
+	}
+	ordered = append(ordered, others...)
+	return append(ordered, unknown...)
+}
+
+// OrderByFamilyPreference returns a copy of ips reordered so that the IPs of
+// the primary family come first, preserving the relative order of IPs within
+// each family. Strings that cannot be parsed as IPs are placed last. This can
+// be used to emit a dual-stack pair of IPs in a specific order.
+func OrderByFamilyPreference(primary IPFamily, ips []string) []string {
+	return orderByFamily(primary, ips, IPFamilyOfString)
+}
+
+// OrderIPsByFamilyPreference is like OrderByFamilyPreference but for net.IPs.
+func OrderIPsByFamilyPreference(primary IPFamily, ips []net.IP) []net.IP {
+	return orderByFamily(primary, ips, IPFamilyOf)
+}
+
+// OrderCIDRsByFamilyPreference is like OrderByFamilyPreference but for CIDRs.
+func OrderCIDRsByFamilyPreference(primary IPFamily, cidrs []*net.IPNet) []*net.IPNet {
+	return orderByFamily(primary, cidrs, IPFamilyOfCIDR)
+}
+
+// OrderAddrsByFamilyPreference is like OrderByFamilyPreference but for
+// netip.Addrs. IPv4-mapped IPv6 addresses are considered to be IPv4.
+func OrderAddrsByFamilyPreference(primary IPFamily, addrs []netip.Addr) []netip.Addr {
+	return orderByFamily(primary, addrs, func(addr netip.Addr) IPFamily {
+		return IPFamilyOf(ipFromNetipAddr(addr))
+	})
+}
+
+// OrderPrefixesByFamilyPreference is like OrderByFamilyPreference but for
+// netip.Prefixes. IPv4-mapped IPv6 prefixes are considered to be IPv4.
+func OrderPrefixesByFamilyPreference(primary IPFamily, prefixes []netip.Prefix) []netip.Prefix {
+	return orderByFamily(primary, prefixes, func(prefix netip.Prefix) IPFamily {
+		return IPFamilyOfCIDR(ipNetFromPrefix(prefix))
+	})
+}
+
+// filterByFamily returns the items of the given family, in order.
+func filterByFamily[T any](family IPFamily, items []T, familyOf func(T) IPFamily) []T {
+	var filtered []T
+	for _, item := range items {
+		if familyOf(item) == family {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
+
+// FilterIPStringsByFamily returns the IPs in ips that are of the given family,
+// in their original order. Strings that cannot be parsed as IPs are dropped.
+func FilterIPStringsByFamily(family IPFamily, ips []string) []string {
+	return filterByFamily(family, ips, IPFamilyOfString)
+}
+
+// FilterIPsByFamily returns the IPs in ips that are of the given family, in
+// their original order.
+func FilterIPsByFamily(family IPFamily, ips []net.IP) []net.IP {
+	return filterByFamily(family, ips, IPFamilyOf)
+}
+
+// FilterCIDRsByFamily returns the CIDRs in cidrs that are of the given family,
+// in their original order.
+func FilterCIDRsByFamily(family IPFamily, cidrs []*net.IPNet) []*net.IPNet {
+	return filterByFamily(family, cidrs, IPFamilyOfCIDR)
+}
+
+// selectForFamilies returns the first item of each family in families, in the
+// order of families, along with the families for which there was no item.
+func selectForFamilies[T any](families []IPFamily, items []T, familyOf func(T) IPFamily) (selected []T, missing []IPFamily) {
+	for _, family := range families {
+		found := false
+		for _, item := range items {
+			if familyOf(item) == family {
+				selected = append(selected, item)
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, family)
+		}
+	}
+	return selected, missing
+}
+
+// SelectIPStringsForFamilies returns the first IP in ips of each family in
+// families, in the order given by families, as needed to implement dual-stack
+// IP family policies. missing lists the families in families for which ips
+// contains no IP; if it is empty, selected has one IP per requested family.
+func SelectIPStringsForFamilies(families []IPFamily, ips []string) (selected []string, missing []IPFamily) {
+	return selectForFamilies(families, ips, IPFamilyOfString)
+}
+
+// SelectIPsForFamilies is like SelectIPStringsForFamilies but for net.IPs.
+func SelectIPsForFamilies(families []IPFamily, ips []net.IP) (selected []net.IP, missing []IPFamily) {
+	return selectForFamilies(families, ips, IPFamilyOf)
+}
+
+// SelectCIDRsForFamilies is like SelectIPStringsForFamilies but for CIDRs.
+func SelectCIDRsForFamilies(families []IPFamily, cidrs []*net.IPNet) (selected []*net.IPNet, missing []IPFamily) {
+	return selectForFamilies(families, cidrs, IPFamilyOfCIDR)
+}
@@ -19,6 +19,8 @@ package net
 import (
 	"fmt"
 	"net"
+	"net/netip"
+	"reflect"
 	"testing"
 )
 
@@ -657,3 +659,209 @@ func TestIPFamilyOfCIDR(t *testing.T) {
 		})
 	}
 }
+
+func TestOrderByFamilyPreference(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		primary  IPFamily
+		ips      []string
+		expected []string
+	}{
+		{
+			desc:     "IPv4 primary",
+			primary:  IPv4,
+			ips:      []string{"2001:db8::1", "10.0.0.1", "2001:db8::2", "10.0.0.2"},
+			expected: []string{"10.0.0.1", "10.0.0.2", "2001:db8::1", "2001:db8::2"},
+		},
+		{
+			desc:     "IPv6 primary",
+			primary:  IPv6,
+			ips:      []string{"10.0.0.1", "2001:db8::1", "10.0.0.2", "2001:db8::2"},
+			expected: []string{"2001:db8::1", "2001:db8::2", "10.0.0.1", "10.0.0.2"},
+		},
+		{
+			desc:     "already ordered",
+			primary:  IPv4,
+			ips:      []string{"10.0.0.1", "2001:db8::1"},
+			expected: []string{"10.0.0.1", "2001:db8::1"},
+		},
+		{
+			desc:     "single family",
+			primary:  IPv6,
+			ips:      []string{"10.0.0.2", "10.0.0.1"},
+			expected: []string{"10.0.0.2", "10.0.0.1"},
+		},
+		{
+			desc:     "invalid IPs last",
+			primary:  IPv6,
+			ips:      []string{"bad", "10.0.0.1", "", "2001:db8::1"},
+			expected: []string{"2001:db8::1", "10.0.0.1", "bad", ""},
+		},
+		{
+			desc:     "empty",
+			primary:  IPv4,
+			ips:      []string{},
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			input := make([]string, len(tc.ips))
+			copy(input, tc.ips)
+			ordered := OrderByFamilyPreference(tc.primary, input)
+			if !reflect.DeepEqual(ordered, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, ordered)
+			}
+			if !reflect.DeepEqual(input, tc.ips) {
+				t.Errorf("input was modified: %v", input)
+			}
+
+			var ips []net.IP
+			var expectedIPs []string
+			for _, s := range tc.ips {
+				if ip := ParseIPSloppy(s); ip != nil {
+					ips = append(ips, ip)
+				}
+			}
+			for _, s := range tc.expected {
+				if ip := ParseIPSloppy(s); ip != nil {
+					expectedIPs = append(expectedIPs, ip.String())
+				}
+			}
+			var gotIPs []string
+			for _, ip := range OrderIPsByFamilyPreference(tc.primary, ips) {
+				gotIPs = append(gotIPs, ip.String())
+			}
+			if !reflect.DeepEqual(gotIPs, expectedIPs) {
+				t.Errorf("expected IPs %v, got %v", expectedIPs, gotIPs)
+			}
+
+			var addrs []netip.Addr
+			for _, ip := range ips {
+				addrs = append(addrs, addrFromIP(ip))
+			}
+			var gotAddrs []string
+			for _, addr := range OrderAddrsByFamilyPreference(tc.primary, addrs) {
+				gotAddrs = append(gotAddrs, addr.String())
+			}
+			if !reflect.DeepEqual(gotAddrs, expectedIPs) {
+				t.Errorf("expected addrs %v, got %v", expectedIPs, gotAddrs)
+			}
+		})
+	}
+}
+
+func TestOrderCIDRsByFamilyPreference(t *testing.T) {
+	_, v4, _ := ParseCIDRSloppy("10.0.0.0/8")
+	_, v6, _ := ParseCIDRSloppy("fd00::/64")
+	ordered := OrderCIDRsByFamilyPreference(IPv6, []*net.IPNet{v4, nil, v6})
+	if len(ordered) != 3 || ordered[0] != v6 || ordered[1] != v4 || ordered[2] != nil {
+		t.Errorf("unexpected order %v", ordered)
+	}
+
+	prefixes := []netip.Prefix{
+		netip.MustParsePrefix("fd00::/64"),
+		{},
+		netip.MustParsePrefix("::ffff:10.0.0.0/104"),
+		netip.MustParsePrefix("10.0.0.0/8"),
+	}
+	expected := []netip.Prefix{prefixes[2], prefixes[3], prefixes[0], prefixes[1]}
+	if got := OrderPrefixesByFamilyPreference(IPv4, prefixes); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected prefixes %v, got %v", expected, got)
+	}
+}
+
+func TestFilterByFamily(t *testing.T) {
+	ips := []string{"2001:db8::1", "10.0.0.1", "bad", "::ffff:10.0.0.2", "2001:db8::2"}
+
+	if got, expected := FilterIPStringsByFamily(IPv4, ips), []string{"10.0.0.1", "::ffff:10.0.0.2"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if got, expected := FilterIPStringsByFamily(IPv6, ips), []string{"2001:db8::1", "2001:db8::2"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if got := FilterIPStringsByFamily(IPv6, []string{"10.0.0.1"}); len(got) != 0 {
+		t.Errorf("expected no IPs, got %v", got)
+	}
+
+	parsed := []net.IP{ParseIPSloppy("10.0.0.1"), ParseIPSloppy("2001:db8::1"), nil}
+	if got := FilterIPsByFamily(IPv6, parsed); len(got) != 1 || !got[0].Equal(parsed[1]) {
+		t.Errorf("unexpected result %v", got)
+	}
+
+	_, v4, _ := ParseCIDRSloppy("10.0.0.0/8")
+	_, v6, _ := ParseCIDRSloppy("fd00::/64")
+	if got := FilterCIDRsByFamily(IPv4, []*net.IPNet{v6, v4, nil}); len(got) != 1 || got[0] != v4 {
+		t.Errorf("unexpected result %v", got)
+	}
+}
+
+func TestSelectForFamilies(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		families         []IPFamily
+		ips              []string
+		expectedSelected []string
+		expectedMissing  []IPFamily
+	}{
+		{
+			desc:             "dual-stack, IPv4 primary",
+			families:         []IPFamily{IPv4, IPv6},
+			ips:              []string{"2001:db8::1", "10.0.0.1", "10.0.0.2", "2001:db8::2"},
+			expectedSelected: []string{"10.0.0.1", "2001:db8::1"},
+		},
+		{
+			desc:             "dual-stack, IPv6 primary",
+			families:         []IPFamily{IPv6, IPv4},
+			ips:              []string{"10.0.0.1", "2001:db8::1"},
+			expectedSelected: []string{"2001:db8::1", "10.0.0.1"},
+		},
+		{
+			desc:             "single-stack",
+			families:         []IPFamily{IPv6},
+			ips:              []string{"10.0.0.1", "2001:db8::1"},
+			expectedSelected: []string{"2001:db8::1"},
+		},
+		{
+			desc:             "missing family",
+			families:         []IPFamily{IPv4, IPv6},
+			ips:              []string{"10.0.0.1", "10.0.0.2"},
+			expectedSelected: []string{"10.0.0.1"},
+			expectedMissing:  []IPFamily{IPv6},
+		},
+		{
+			desc:            "no IPs",
+			families:        []IPFamily{IPv4, IPv6},
+			ips:             []string{"bad"},
+			expectedMissing: []IPFamily{IPv4, IPv6},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			selected, missing := SelectIPStringsForFamilies(tc.families, tc.ips)
+			if !reflect.DeepEqual(selected, tc.expectedSelected) {
+				t.Errorf("expected selected %v, got %v", tc.expectedSelected, selected)
+			}
+			if !reflect.DeepEqual(missing, tc.expectedMissing) {
+				t.Errorf("expected missing %v, got %v", tc.expectedMissing, missing)
+			}
+
+			var ips []net.IP
+			for _, s := range tc.ips {
+				ips = append(ips, ParseIPSloppy(s))
+			}
+			selectedIPs, missingIPs := SelectIPsForFamilies(tc.families, ips)
+			if len(selectedIPs) != len(tc.expectedSelected) || !reflect.DeepEqual(missingIPs, tc.expectedMissing) {
+				t.Errorf("SelectIPsForFamilies gave %v, %v", selectedIPs, missingIPs)
+			}
+		})
+	}
+
+	_, v4, _ := ParseCIDRSloppy("10.0.0.0/8")
+	selected, missing := SelectCIDRsForFamilies([]IPFamily{IPv6, IPv4}, []*net.IPNet{v4})
+	if len(selected) != 1 || selected[0] != v4 || !reflect.DeepEqual(missing, []IPFamily{IPv6}) {
+		t.Errorf("unexpected result %v, %v", selected, missing)
+	}
+}
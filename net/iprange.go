@@ -0,0 +1,219 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// IPRange is an inclusive range of IP addresses of a single IP family. The
+// zero value is not a valid range; use NewIPRange, NewIPRangeFromAddrs,
+// ParseIPRange, IPRangeFromCIDR or IPRangeFromPrefix to construct one.
+type IPRange struct {
+	first net.IP
+	last  net.IP
+}
+
+// normalizeIP returns ip in its 4-byte form if it is IPv4 and its 16-byte form
+// if it is IPv6, or nil if it is invalid.
+func normalizeIP(ip net.IP) net.IP {
+	switch IPFamilyOf(ip) {
+	case IPv4:
+		return ip.To4()
+	case IPv6:
+		return ip.To16()
+	default:
+		return nil
+	}
+}
+
+// NewIPRange returns the IPRange from first to last, inclusive. first and last
+// must be valid IPs of the same family and first must not be after last.
+func NewIPRange(first, last net.IP) (*IPRange, error) {
+	f, l := normalizeIP(first), normalizeIP(last)
+	if f == nil {
+		return nil, fmt.Errorf("invalid first IP %q", first)
+	}
+	if l == nil {
+		return nil, fmt.Errorf("invalid last IP %q", last)
+	}
+	if len(f) != len(l) {
+		return nil, fmt.Errorf("IP family mismatch between %s and %s", first, last)
+	}
+	if bytes.Compare(f, l) > 0 {
+		return nil, fmt.Errorf("first IP %s is after last IP %s", first, last)
+	}
+	return &IPRange{first: f, last: l}, nil
+}
+
+// NewIPRangeFromAddrs is like NewIPRange but takes netip.Addr values.
+// IPv4-mapped IPv6 addresses are considered to be IPv4.
+func NewIPRangeFromAddrs(first, last netip.Addr) (*IPRange, error) {
+	return NewIPRange(ipFromNetipAddr(first), ipFromNetipAddr(last))
+}
+
+// ParseIPRange parses a range of the form "first-last", e.g.
+// "10.0.0.1-10.0.0.100". Like ParseIPSloppy, it accepts IPv4 addresses with
+// leading zeros. A single IP is also accepted and results in a range of size 1.
+func ParseIPRange(s string) (*IPRange, error) {
+	firstStr, lastStr := s, s
+	if i := strings.Index(s, "-"); i >= 0 {
+		firstStr, lastStr = s[:i], s[i+1:]
+	}
+	first := ParseIPSloppy(strings.TrimSpace(firstStr))
+	if first == nil {
+		return nil, fmt.Errorf("invalid IP range %q: bad first IP", s)
+	}
+	last := ParseIPSloppy(strings.TrimSpace(lastStr))
+	if last == nil {
+		return nil, fmt.Errorf("invalid IP range %q: bad last IP", s)
+	}
+	r, err := NewIPRange(first, last)
+	if err != nil {
+		return nil, fmt.Errorf("invalid IP range %q: %v", s, err)
+	}
+	return r, nil
+}
+
+// IPRangeFromCIDR returns the IPRange covering all of the addresses in cidr,
+// including the network and broadcast addresses. It returns nil if cidr is nil
+// or invalid.
+func IPRangeFromCIDR(cidr *net.IPNet) *IPRange {
+	if cidr == nil {
+		return nil
+	}
+	ip := normalizeIP(cidr.IP)
+	if ip == nil {
+		return nil
+	}
+	ones := cidrPrefixLen(cidr)
+	if _, bits := cidr.Mask.Size(); bits == 0 {
+		return nil
+	}
+	mask := net.CIDRMask(ones, 8*len(ip))
+	first := ip.Mask(mask)
+	last := make(net.IP, len(first))
+	for i := range first {
+		last[i] = first[i] | ^mask[i]
+	}
+	return &IPRange{first: first, last: last}
+}
+
+// IPRangeFromPrefix is like IPRangeFromCIDR but takes a netip.Prefix. It
+// returns nil if prefix is invalid.
+func IPRangeFromPrefix(prefix netip.Prefix) *IPRange {
+	return IPRangeFromCIDR(ipNetFromPrefix(prefix))
+}
+
+// First returns the first IP in the range.
+func (r *IPRange) First() net.IP {
+	return append(net.IP(nil), r.first...)
+}
+
+// Last returns the last IP in the range.
+func (r *IPRange) Last() net.IP {
+	return append(net.IP(nil), r.last...)
+}
+
+// FirstAddr returns the first IP in the range as a netip.Addr.
+func (r *IPRange) FirstAddr() netip.Addr {
+	return addrFromIP(r.first)
+}
+
+// LastAddr returns the last IP in the range as a netip.Addr.
+func (r *IPRange) LastAddr() netip.Addr {
+	return addrFromIP(r.last)
+}
+
+// Family returns the IP family of the range.
+func (r *IPRange) Family() IPFamily {
+	return IPFamilyOf(r.first)
+}
+
+// Contains returns true if ip is within the range. IPv4-mapped IPv6 addresses
+// are considered to be IPv4.
+func (r *IPRange) Contains(ip net.IP) bool {
+	ip = normalizeIP(ip)
+	if ip == nil || len(ip) != len(r.first) {
+		return false
+	}
+	return bytes.Compare(r.first, ip) <= 0 && bytes.Compare(ip, r.last) <= 0
+}
+
+// ContainsAddr is like Contains but takes a netip.Addr.
+func (r *IPRange) ContainsAddr(addr netip.Addr) bool {
+	return r.Contains(ipFromNetipAddr(addr))
+}
+
+// Size returns the number of addresses in the range.
+func (r *IPRange) Size() *big.Int {
+	size := big.NewInt(0).SetBytes(r.last)
+	size.Sub(size, big.NewInt(0).SetBytes(r.first))
+	return size.Add(size, big.NewInt(1))
+}
+
+// CIDRs returns the smallest list of CIDRs that covers exactly the addresses
+// in the range, in ascending order.
+func (r *IPRange) CIDRs() []*net.IPNet {
+	bits := 8 * len(r.first)
+	start := big.NewInt(0).SetBytes(r.first)
+	end := big.NewInt(0).SetBytes(r.last)
+	one := big.NewInt(1)
+
+	var cidrs []*net.IPNet
+	for start.Cmp(end) <= 0 {
+		// Find the largest block that starts at start and does not extend
+		// past end.
+		hostBits := 0
+		for hostBits < bits && start.Bit(hostBits) == 0 {
+			blockEnd := big.NewInt(0).Lsh(one, uint(hostBits+1))
+			blockEnd.Add(blockEnd, start)
+			blockEnd.Sub(blockEnd, one)
+			if blockEnd.Cmp(end) > 0 {
+				break
+			}
+			hostBits++
+		}
+
+		ip := make(net.IP, len(r.first))
+		start.FillBytes(ip)
+		cidrs = append(cidrs, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits-hostBits, bits)})
+
+		start.Add(start, big.NewInt(0).Lsh(one, uint(hostBits)))
+	}
+	return cidrs
+}
+
+// Prefixes is like CIDRs but returns netip.Prefix values.
+func (r *IPRange) Prefixes() []netip.Prefix {
+	cidrs := r.CIDRs()
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		prefixes = append(prefixes, prefixFromIPNet(cidr))
+	}
+	return prefixes
+}
+
+// String returns the range in the form "first-last".
+func (r *IPRange) String() string {
+	return r.first.String() + "-" + r.last.String()
+}
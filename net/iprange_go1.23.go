@@ -0,0 +1,51 @@
+//go:build go1.23
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"bytes"
+	"iter"
+	"net"
+	"net/netip"
+)
+
+// All returns an iterator over every IP in the range, in ascending order.
+// Each yielded IP is a new slice that the caller may retain. Note that IPv6
+// ranges may be far too large to iterate fully.
+func (r *IPRange) All() iter.Seq[net.IP] {
+	return func(yield func(net.IP) bool) {
+		for ip := r.First(); ip != nil; ip = NextIP(ip) {
+			if !yield(ip) || bytes.Equal(ip, r.last) {
+				return
+			}
+		}
+	}
+}
+
+// Addrs is like All but iterates over netip.Addr values.
+func (r *IPRange) Addrs() iter.Seq[netip.Addr] {
+	return func(yield func(netip.Addr) bool) {
+		last := r.LastAddr()
+		for addr := r.FirstAddr(); addr.IsValid(); addr = addr.Next() {
+			if !yield(addr) || addr == last {
+				return
+			}
+		}
+	}
+}
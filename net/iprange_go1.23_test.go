@@ -0,0 +1,68 @@
+//go:build go1.23
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIPRangeAll(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected []string
+	}{
+		{"10.0.0.254-10.0.1.1", []string{"10.0.0.254", "10.0.0.255", "10.0.1.0", "10.0.1.1"}},
+		{"255.255.255.255-255.255.255.255", []string{"255.255.255.255"}},
+		{"2001:db8::fffe-2001:db8::1:0", []string{"2001:db8::fffe", "2001:db8::ffff", "2001:db8::1:0"}},
+	}
+	for _, tc := range testCases {
+		r, err := ParseIPRange(tc.input)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tc.input, err)
+		}
+		var got []string
+		for ip := range r.All() {
+			got = append(got, ip.String())
+		}
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%q: expected %v, got %v", tc.input, tc.expected, got)
+		}
+
+		got = nil
+		for addr := range r.Addrs() {
+			got = append(got, addr.String())
+		}
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%q: expected Addrs %v, got %v", tc.input, tc.expected, got)
+		}
+	}
+
+	r, _ := ParseIPRange("::-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff")
+	count := 0
+	for range r.All() {
+		count++
+		if count == 10 {
+			break
+		}
+	}
+	if count != 10 {
+		t.Errorf("expected early break after 10 IPs, got %d", count)
+	}
+}
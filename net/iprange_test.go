@@ -0,0 +1,214 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+func TestParseIPRange(t *testing.T) {
+	testCases := []struct {
+		input       string
+		expected    string
+		family      IPFamily
+		size        string
+		expectError bool
+	}{
+		{input: "10.0.0.1-10.0.0.100", expected: "10.0.0.1-10.0.0.100", family: IPv4, size: "100"},
+		{input: "010.000.000.001 - 010.000.000.002", expected: "10.0.0.1-10.0.0.2", family: IPv4, size: "2"},
+		{input: "10.0.0.1", expected: "10.0.0.1-10.0.0.1", family: IPv4, size: "1"},
+		{input: "::ffff:10.0.0.1-10.0.0.3", expected: "10.0.0.1-10.0.0.3", family: IPv4, size: "3"},
+		{input: "0.0.0.0-255.255.255.255", expected: "0.0.0.0-255.255.255.255", family: IPv4, size: "4294967296"},
+		{input: "2001:db8::1-2001:db8::ff", expected: "2001:db8::1-2001:db8::ff", family: IPv6, size: "255"},
+		{input: "::-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", expected: "::-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", family: IPv6, size: "340282366920938463463374607431768211456"},
+		{input: "10.0.0.2-10.0.0.1", expectError: true},
+		{input: "10.0.0.1-2001:db8::1", expectError: true},
+		{input: "10.0.0.1-", expectError: true},
+		{input: "-10.0.0.1", expectError: true},
+		{input: "not-an-ip", expectError: true},
+		{input: "", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		r, err := ParseIPRange(tc.input)
+		if tc.expectError {
+			if err == nil {
+				t.Errorf("%q: expected error, got %s", tc.input, r)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.input, err)
+			continue
+		}
+		if r.String() != tc.expected {
+			t.Errorf("%q: expected %s, got %s", tc.input, tc.expected, r)
+		}
+		if r.Family() != tc.family {
+			t.Errorf("%q: expected family %q, got %q", tc.input, tc.family, r.Family())
+		}
+		if size := r.Size().String(); size != tc.size {
+			t.Errorf("%q: expected size %s, got %s", tc.input, tc.size, size)
+		}
+	}
+}
+
+func TestIPRangeContains(t *testing.T) {
+	r, err := ParseIPRange("10.0.0.10-10.0.1.5")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testCases := []struct {
+		ip       string
+		expected bool
+	}{
+		{"10.0.0.10", true},
+		{"10.0.0.255", true},
+		{"10.0.1.5", true},
+		{"::ffff:10.0.0.20", true},
+		{"10.0.0.9", false},
+		{"10.0.1.6", false},
+		{"::a00:14", false},
+		{"", false},
+	}
+	for _, tc := range testCases {
+		if got := r.Contains(ParseIPSloppy(tc.ip)); got != tc.expected {
+			t.Errorf("Contains(%q): expected %v, got %v", tc.ip, tc.expected, got)
+		}
+	}
+}
+
+func TestIPRangeCIDRs(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected []string
+	}{
+		{"10.0.0.0-10.0.0.255", []string{"10.0.0.0/24"}},
+		{"10.0.0.5-10.0.0.5", []string{"10.0.0.5/32"}},
+		{"10.0.0.1-10.0.0.6", []string{"10.0.0.1/32", "10.0.0.2/31", "10.0.0.4/31", "10.0.0.6/32"}},
+		{"10.0.0.255-10.0.2.0", []string{"10.0.0.255/32", "10.0.1.0/24", "10.0.2.0/32"}},
+		{"0.0.0.0-255.255.255.255", []string{"0.0.0.0/0"}},
+		{"255.255.255.254-255.255.255.255", []string{"255.255.255.254/31"}},
+		{"2001:db8::-2001:db8::1:0", []string{"2001:db8::/112", "2001:db8::1:0/128"}},
+		{"::-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", []string{"::/0"}},
+	}
+	for _, tc := range testCases {
+		r, err := ParseIPRange(tc.input)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tc.input, err)
+		}
+		var got []string
+		for _, cidr := range r.CIDRs() {
+			got = append(got, cidr.String())
+		}
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%q: expected %v, got %v", tc.input, tc.expected, got)
+		}
+	}
+}
+
+func TestIPRangeFromCIDR(t *testing.T) {
+	testCases := []struct {
+		cidr     string
+		expected string
+	}{
+		{"10.0.0.0/24", "10.0.0.0-10.0.0.255"},
+		{"10.0.0.17/30", "10.0.0.16-10.0.0.19"},
+		{"10.0.0.1/32", "10.0.0.1-10.0.0.1"},
+		{"::ffff:10.0.0.0/120", "10.0.0.0-10.0.0.255"},
+		{"2001:db8::/120", "2001:db8::-2001:db8::ff"},
+	}
+	for _, tc := range testCases {
+		_, cidr, err := ParseCIDRSloppy(tc.cidr)
+		if err != nil {
+			t.Fatalf("failed to parse cidr %s, unexpected error: '%s'", tc.cidr, err)
+		}
+		r := IPRangeFromCIDR(cidr)
+		if r.String() != tc.expected {
+			t.Errorf("%s: expected %s, got %s", tc.cidr, tc.expected, r)
+		}
+		cidrs := r.CIDRs()
+		if len(cidrs) != 1 || cidrs[0].String() != cidr.String() {
+			t.Errorf("%s: expected round-trip to a single CIDR, got %v", tc.cidr, cidrs)
+		}
+	}
+	if IPRangeFromCIDR(nil) != nil {
+		t.Errorf("expected nil range for nil CIDR")
+	}
+}
+
+func TestIPRangeFirstLastCopy(t *testing.T) {
+	r, _ := ParseIPRange("10.0.0.1-10.0.0.2")
+	first := r.First()
+	first[3] = 99
+	if r.String() != "10.0.0.1-10.0.0.2" {
+		t.Errorf("modifying First() result changed the range: %s", r)
+	}
+}
+
+func TestIPRangeAddrs(t *testing.T) {
+	r, err := NewIPRangeFromAddrs(netip.MustParseAddr("::ffff:10.0.0.1"), netip.MustParseAddr("10.0.0.6"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.String() != "10.0.0.1-10.0.0.6" {
+		t.Errorf("expected 10.0.0.1-10.0.0.6, got %s", r)
+	}
+	if first, last := r.FirstAddr(), r.LastAddr(); first != netip.MustParseAddr("10.0.0.1") || last != netip.MustParseAddr("10.0.0.6") {
+		t.Errorf("expected first 10.0.0.1 and last 10.0.0.6, got %s and %s", first, last)
+	}
+	for _, tc := range []struct {
+		addr     netip.Addr
+		expected bool
+	}{
+		{netip.MustParseAddr("10.0.0.1"), true},
+		{netip.MustParseAddr("::ffff:10.0.0.6"), true},
+		{netip.MustParseAddr("10.0.0.7"), false},
+		{netip.MustParseAddr("::a00:1"), false},
+		{netip.Addr{}, false},
+	} {
+		if got := r.ContainsAddr(tc.addr); got != tc.expected {
+			t.Errorf("ContainsAddr(%s): expected %v, got %v", tc.addr, tc.expected, got)
+		}
+	}
+	expected := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.1/32"),
+		netip.MustParsePrefix("10.0.0.2/31"),
+		netip.MustParsePrefix("10.0.0.4/31"),
+		netip.MustParsePrefix("10.0.0.6/32"),
+	}
+	if got := r.Prefixes(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected prefixes %v, got %v", expected, got)
+	}
+
+	if _, err := NewIPRangeFromAddrs(netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("2001:db8::1")); err == nil {
+		t.Errorf("expected error for mixed families")
+	}
+	if _, err := NewIPRangeFromAddrs(netip.Addr{}, netip.MustParseAddr("10.0.0.1")); err == nil {
+		t.Errorf("expected error for invalid first address")
+	}
+
+	r = IPRangeFromPrefix(netip.MustParsePrefix("2001:db8::17/120"))
+	if r == nil || r.String() != "2001:db8::-2001:db8::ff" {
+		t.Errorf("expected 2001:db8::-2001:db8::ff, got %v", r)
+	}
+	if IPRangeFromPrefix(netip.Prefix{}) != nil {
+		t.Errorf("expected nil range for invalid prefix")
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"encoding"
+	"fmt"
+	"net"
+)
+
+// IP wraps a net.IP so that it can be used directly in configuration structs.
+// It implements encoding.TextMarshaler and encoding.TextUnmarshaler (and hence
+// JSON and YAML marshaling as a string): unmarshaling uses ParseIPSloppy, so
+// legacy IPv4 addresses with leading zeros are accepted, and marshaling
+// always produces the canonical form. IPv4-mapped IPv6 addresses are
+// normalized to plain IPv4. The empty string corresponds to a nil IP.
+type IP struct {
+	net.IP
+}
+
+var _ encoding.TextMarshaler = IP{}
+var _ encoding.TextUnmarshaler = &IP{}
+
+// MarshalText implements encoding.TextMarshaler.
+func (ip IP) MarshalText() ([]byte, error) {
+	if len(ip.IP) == 0 {
+		return []byte{}, nil
+	}
+	if IPFamilyOf(ip.IP) == IPFamilyUnknown {
+		return nil, fmt.Errorf("invalid IP %q", ip.IP)
+	}
+	return []byte(ip.IP.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (ip *IP) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		ip.IP = nil
+		return nil
+	}
+	parsed := ParseIPSloppy(string(text))
+	if parsed == nil {
+		return fmt.Errorf("invalid IP %q", text)
+	}
+	ip.IP = normalizeIP(parsed)
+	return nil
+}
+
+// CIDR wraps a *net.IPNet so that it can be used directly in configuration
+// structs. It implements encoding.TextMarshaler and encoding.TextUnmarshaler:
+// unmarshaling uses ParseCIDRSloppy, so legacy IPv4 addresses with leading
+// zeros are accepted and any host bits are cleared, and marshaling always
+// produces the canonical form. IPv4-mapped IPv6 CIDRs are normalized to plain
+// IPv4. The empty string corresponds to a nil IPNet.
+type CIDR struct {
+	*net.IPNet
+}
+
+var _ encoding.TextMarshaler = CIDR{}
+var _ encoding.TextUnmarshaler = &CIDR{}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c CIDR) MarshalText() ([]byte, error) {
+	if c.IPNet == nil {
+		return []byte{}, nil
+	}
+	if IPFamilyOfCIDR(c.IPNet) == IPFamilyUnknown {
+		return nil, fmt.Errorf("invalid CIDR %q", c.IPNet)
+	}
+	return []byte(c.IPNet.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *CIDR) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		c.IPNet = nil
+		return nil
+	}
+	_, parsed, err := ParseCIDRSloppy(string(text))
+	if err != nil {
+		return err
+	}
+	ip, ones := trieKey(parsed)
+	if ip == nil {
+		return fmt.Errorf("invalid CIDR %q", text)
+	}
+	c.IPNet = &net.IPNet{IP: ip, Mask: net.CIDRMask(ones, 8*len(ip))}
+	return nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+)
+
+func TestIPText(t *testing.T) {
+	testCases := []struct {
+		input       string
+		expected    string
+		expectError bool
+	}{
+		{input: "1.2.3.4", expected: "1.2.3.4"},
+		{input: "001.002.003.004", expected: "1.2.3.4"},
+		{input: "::ffff:1.2.3.4", expected: "1.2.3.4"},
+		{input: "2001:DB8:0::1", expected: "2001:db8::1"},
+		{input: "", expected: ""},
+		{input: "1.2.3", expectError: true},
+		{input: "1.2.3.4/32", expectError: true},
+	}
+	for _, tc := range testCases {
+		var ip IP
+		err := ip.UnmarshalText([]byte(tc.input))
+		if tc.expectError {
+			if err == nil {
+				t.Errorf("%q: expected error, got %v", tc.input, ip)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.input, err)
+			continue
+		}
+		out, err := ip.MarshalText()
+		if err != nil {
+			t.Errorf("%q: unexpected error marshaling: %v", tc.input, err)
+			continue
+		}
+		if string(out) != tc.expected {
+			t.Errorf("%q: expected %q, got %q", tc.input, tc.expected, out)
+		}
+	}
+
+	if _, err := (IP{net.IP{1, 2, 3}}).MarshalText(); err == nil {
+		t.Errorf("expected error marshaling invalid IP")
+	}
+}
+
+func TestCIDRText(t *testing.T) {
+	testCases := []struct {
+		input       string
+		expected    string
+		expectError bool
+	}{
+		{input: "10.0.0.0/8", expected: "10.0.0.0/8"},
+		{input: "010.001.000.000/16", expected: "10.1.0.0/16"},
+		{input: "10.1.2.3/8", expected: "10.0.0.0/8"},
+		{input: "::ffff:10.0.0.0/104", expected: "10.0.0.0/8"},
+		{input: "2001:DB8::/32", expected: "2001:db8::/32"},
+		{input: "", expected: ""},
+		{input: "10.0.0.0", expectError: true},
+		{input: "10.0.0.0/33", expectError: true},
+	}
+	for _, tc := range testCases {
+		var c CIDR
+		err := c.UnmarshalText([]byte(tc.input))
+		if tc.expectError {
+			if err == nil {
+				t.Errorf("%q: expected error, got %v", tc.input, c)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.input, err)
+			continue
+		}
+		out, err := c.MarshalText()
+		if err != nil {
+			t.Errorf("%q: unexpected error marshaling: %v", tc.input, err)
+			continue
+		}
+		if string(out) != tc.expected {
+			t.Errorf("%q: expected %q, got %q", tc.input, tc.expected, out)
+		}
+	}
+}
+
+func TestIPAndCIDRJSON(t *testing.T) {
+	type config struct {
+		BindAddress IP     `json:"bindAddress"`
+		PodCIDRs    []CIDR `json:"podCIDRs"`
+		Unset       IP     `json:"unset"`
+	}
+
+	in := `{"bindAddress":"010.000.000.001","podCIDRs":["10.244.0.0/16","fd00:10:244::/56"],"unset":""}`
+	var cfg config
+	if err := json.Unmarshal([]byte(in), &cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.BindAddress.Equal(net.IPv4(10, 0, 0, 1)) {
+		t.Errorf("expected bindAddress 10.0.0.1, got %v", cfg.BindAddress)
+	}
+	if len(cfg.PodCIDRs) != 2 || !IsIPv6CIDR(cfg.PodCIDRs[1].IPNet) {
+		t.Errorf("unexpected podCIDRs %v", cfg.PodCIDRs)
+	}
+
+	out, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"bindAddress":"10.0.0.1","podCIDRs":["10.244.0.0/16","fd00:10:244::/56"],"unset":""}`
+	if string(out) != expected {
+		t.Errorf("expected %s, got %s", expected, out)
+	}
+
+	if err := json.Unmarshal([]byte(`{"bindAddress":"bad"}`), &cfg); err == nil {
+		t.Errorf("expected error for invalid IP")
+	}
+}
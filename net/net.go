@@ -22,7 +22,9 @@ import (
 	"math"
 	"math/big"
 	"net"
+	"net/netip"
 	"strconv"
+	"strings"
 )
 
 // ParseCIDRs parses a list of cidrs and return error if any is invalid.
@@ -39,6 +41,48 @@ func ParseCIDRs(cidrsString []string) ([]*net.IPNet, error) {
 	return cidrs, nil
 }
 
+// ParseIPsByFamily parses a list of IPs and returns them grouped by IP family,
+// preserving their relative order within each family. If any entry is invalid,
+// it returns an error naming every invalid entry by index.
+func ParseIPsByFamily(ipStrings []string) (map[IPFamily][]net.IP, error) {
+	ips := make(map[IPFamily][]net.IP)
+	var errs []string
+	for i, ipString := range ipStrings {
+		ip := ParseIPSloppy(ipString)
+		if ip == nil {
+			errs = append(errs, fmt.Sprintf("invalid IP[%d]: %q", i, ipString))
+			continue
+		}
+		family := IPFamilyOf(ip)
+		ips[family] = append(ips[family], ip)
+	}
+	if len(errs) > 0 {
+		return nil, errors.New(strings.Join(errs, "; "))
+	}
+	return ips, nil
+}
+
+// ParseCIDRsByFamily parses a list of CIDRs and returns them grouped by IP
+// family, preserving their relative order within each family. If any entry is
+// invalid, it returns an error naming every invalid entry by index.
+func ParseCIDRsByFamily(cidrStrings []string) (map[IPFamily][]*net.IPNet, error) {
+	cidrs := make(map[IPFamily][]*net.IPNet)
+	var errs []string
+	for i, cidrString := range cidrStrings {
+		_, cidr, err := ParseCIDRSloppy(cidrString)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("invalid CIDR[%d]: %q (%v)", i, cidrString, err))
+			continue
+		}
+		family := IPFamilyOfCIDR(cidr)
+		cidrs[family] = append(cidrs[family], cidr)
+	}
+	if len(errs) > 0 {
+		return nil, errors.New(strings.Join(errs, "; "))
+	}
+	return cidrs, nil
+}
+
 // ParsePort parses a string representing an IP port.  If the string is not a
 // valid port number, this returns an error.
 func ParsePort(port string, allowZero bool) (int, error) {
@@ -52,6 +96,41 @@ func ParsePort(port string, allowZero bool) (int, error) {
 	return int(portInt), nil
 }
 
+// ParseHostPort parses an IP address with an optional port, such as
+// "1.2.3.4:80", "[2001:db8::1]:80", "1.2.3.4", "2001:db8::1" or
+// "[2001:db8::1]". The IP is parsed with ParseIPSloppy, so legacy IPv4
+// addresses with leading zeros are accepted, and IPv4 results are in 4-byte
+// form. If no port is given, port is 0, but a trailing ":" with an empty port
+// is rejected. Only IPv6 addresses may be enclosed in brackets. Hostnames are
+// not accepted.
+func ParseHostPort(hostport string) (ip net.IP, family IPFamily, port int, err error) {
+	host, portStr := hostport, ""
+	if parsed := ParseIPSloppy(host); parsed == nil {
+		if strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]") {
+			host = host[1 : len(host)-1]
+		} else if host, portStr, err = net.SplitHostPort(hostport); err != nil {
+			return nil, IPFamilyUnknown, 0, err
+		} else if portStr == "" {
+			return nil, IPFamilyUnknown, 0, fmt.Errorf("missing port in %q", hostport)
+		}
+		if strings.HasPrefix(hostport, "[") && !strings.Contains(host, ":") {
+			return nil, IPFamilyUnknown, 0, fmt.Errorf("invalid brackets around non-IPv6 address %q in %q", host, hostport)
+		}
+	}
+
+	ip = normalizeIP(ParseIPSloppy(host))
+	if ip == nil {
+		return nil, IPFamilyUnknown, 0, fmt.Errorf("invalid IP %q in %q", host, hostport)
+	}
+	if portStr != "" {
+		port, err = ParsePort(portStr, true)
+		if err != nil {
+			return nil, IPFamilyUnknown, 0, fmt.Errorf("invalid port %q in %q: %v", portStr, hostport, err)
+		}
+	}
+	return ip, IPFamilyOf(ip), port, nil
+}
+
 // BigForIP creates a big.Int based on the provided net.IP
 func BigForIP(ip net.IP) *big.Int {
 	// NOTE: Convert to 16-byte representation so we can
@@ -67,6 +146,80 @@ func AddIPOffset(base *big.Int, offset int) net.IP {
 	return net.IP(r[len(r)-16:])
 }
 
+// NextIP returns the IP immediately following ip, in the same family. It
+// returns nil if ip is invalid or is the last address of its family (e.g.
+// "255.255.255.255"); unlike AddIPOffset it never overflows from IPv4 into
+// IPv6. IPv4 results are always in 4-byte form.
+func NextIP(ip net.IP) net.IP {
+	return AddToIP(ip, 1)
+}
+
+// PrevIP returns the IP immediately preceding ip, in the same family. It
+// returns nil if ip is invalid or is the first address of its family (e.g.
+// "0.0.0.0" or "::"). IPv4 results are always in 4-byte form.
+func PrevIP(ip net.IP) net.IP {
+	ip = normalizeIP(ip)
+	if ip == nil {
+		return nil
+	}
+	prev := make(net.IP, len(ip))
+	copy(prev, ip)
+	for i := len(prev) - 1; i >= 0; i-- {
+		prev[i]--
+		if prev[i] != 0xff {
+			return prev
+		}
+	}
+	// wrapped around from the first address
+	return nil
+}
+
+// AddToIP returns ip + offset, in the same family. It returns nil if ip is
+// invalid or if the result would not fit in ip's family, rather than wrapping
+// around or overflowing from IPv4 into IPv6. Unlike AddIPOffset, it does not
+// need a big.Int for the common small-offset case. IPv4 results are always in
+// 4-byte form.
+func AddToIP(ip net.IP, offset uint64) net.IP {
+	ip = normalizeIP(ip)
+	if ip == nil {
+		return nil
+	}
+	sum := make(net.IP, len(ip))
+	carry := uint64(0)
+	for i := len(ip) - 1; i >= 0; i-- {
+		v := uint64(ip[i]) + offset&0xff + carry
+		sum[i] = byte(v)
+		carry = v >> 8
+		offset >>= 8
+	}
+	if offset != 0 || carry != 0 {
+		return nil
+	}
+	return sum
+}
+
+// NextAddr is like NextIP but takes and returns a netip.Addr. It returns the
+// zero Addr if addr is invalid or is the last address of its family.
+// IPv4-mapped IPv6 addresses are treated as IPv4, so the result is unmapped.
+func NextAddr(addr netip.Addr) netip.Addr {
+	return addrFromIP(NextIP(ipFromNetipAddr(addr)))
+}
+
+// PrevAddr is like PrevIP but takes and returns a netip.Addr. It returns the
+// zero Addr if addr is invalid or is the first address of its family.
+// IPv4-mapped IPv6 addresses are treated as IPv4, so the result is unmapped.
+func PrevAddr(addr netip.Addr) netip.Addr {
+	return addrFromIP(PrevIP(ipFromNetipAddr(addr)))
+}
+
+// AddrAdd is like AddToIP but takes and returns a netip.Addr. It returns the
+// zero Addr if addr is invalid or if the result would not fit in addr's
+// family. IPv4-mapped IPv6 addresses are treated as IPv4, so the result is
+// unmapped.
+func AddrAdd(addr netip.Addr, offset uint64) netip.Addr {
+	return addrFromIP(AddToIP(ipFromNetipAddr(addr), offset))
+}
+
 // RangeSize returns the size of a range in valid addresses.
 // returns the size of the subnet (or math.MaxInt64 if the range size would overflow int64)
 func RangeSize(subnet *net.IPNet) int64 {
@@ -81,6 +234,64 @@ func RangeSize(subnet *net.IPNet) int64 {
 	return int64(1) << uint(bits-ones)
 }
 
+// subnetHostBits returns the number of host bits in subnet, or -1 if subnet is
+// nil or invalid.
+func subnetHostBits(subnet *net.IPNet) int {
+	if subnet == nil {
+		return -1
+	}
+	if _, bits := subnet.Mask.Size(); bits == 0 {
+		return -1
+	}
+	switch IPFamilyOfCIDR(subnet) {
+	case IPv4:
+		return 8*net.IPv4len - cidrPrefixLen(subnet)
+	case IPv6:
+		return 8*net.IPv6len - cidrPrefixLen(subnet)
+	default:
+		return -1
+	}
+}
+
+// RangeSizeBig returns the exact number of addresses in subnet, including the
+// network and broadcast addresses. Unlike RangeSize it never caps the result,
+// so it correctly reports the size of large IPv6 subnets. It returns 0 if
+// subnet is nil or invalid.
+func RangeSizeBig(subnet *net.IPNet) *big.Int {
+	hostBits := subnetHostBits(subnet)
+	if hostBits < 0 {
+		return big.NewInt(0)
+	}
+	return big.NewInt(0).Lsh(big.NewInt(1), uint(hostBits))
+}
+
+// RangeSizeUint64 returns the number of addresses in subnet, including the
+// network and broadcast addresses, or math.MaxUint64 if the size would
+// overflow a uint64. It returns 0 if subnet is nil or invalid.
+func RangeSizeUint64(subnet *net.IPNet) uint64 {
+	hostBits := subnetHostBits(subnet)
+	switch {
+	case hostBits < 0:
+		return 0
+	case hostBits >= 64:
+		return math.MaxUint64
+	default:
+		return uint64(1) << uint(hostBits)
+	}
+}
+
+// PrefixRangeSizeBig is like RangeSizeBig but takes a netip.Prefix. It
+// returns 0 if prefix is invalid.
+func PrefixRangeSizeBig(prefix netip.Prefix) *big.Int {
+	return RangeSizeBig(ipNetFromPrefix(prefix))
+}
+
+// PrefixRangeSizeUint64 is like RangeSizeUint64 but takes a netip.Prefix. It
+// returns 0 if prefix is invalid.
+func PrefixRangeSizeUint64(prefix netip.Prefix) uint64 {
+	return RangeSizeUint64(ipNetFromPrefix(prefix))
+}
+
 // GetIndexedIP returns a net.IP that is subnet.IP + index in the contiguous IP space.
 func GetIndexedIP(subnet *net.IPNet, index int) (net.IP, error) {
 	ip := AddIPOffset(BigForIP(subnet.IP), index)
@@ -89,3 +300,72 @@ func GetIndexedIP(subnet *net.IPNet, index int) (net.IP, error) {
 	}
 	return ip, nil
 }
+
+// GetIndexedIPReverse returns a net.IP that is index addresses before the last
+// address of subnet (i.e. its broadcast address, for IPv4) in the contiguous
+// IP space, so index 0 returns the last address of subnet. This is useful for
+// allocators that reserve addresses at the end of a range.
+func GetIndexedIPReverse(subnet *net.IPNet, index int) (net.IP, error) {
+	r := IPRangeFromCIDR(subnet)
+	if r == nil {
+		return nil, fmt.Errorf("invalid subnet %q", subnet)
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("invalid negative index %d", index)
+	}
+	ip := AddIPOffset(BigForIP(r.last), -index)
+	if !subnet.Contains(ip) {
+		return nil, fmt.Errorf("can't generate IP with reverse index %d from subnet. subnet too small. subnet: %q", index, subnet)
+	}
+	return ip, nil
+}
+
+// GetIndexedUsableIPReverse is like GetIndexedIPReverse, but for IPv4 subnets
+// larger than /31 it skips the network and broadcast addresses, so index 0
+// returns the address just before the broadcast address, and it returns an
+// error rather than returning the network address. IPv6 subnets, and IPv4 /31
+// and /32 subnets, have no reserved addresses.
+func GetIndexedUsableIPReverse(subnet *net.IPNet, index int) (net.IP, error) {
+	if !IsIPv4CIDR(subnet) || subnetHostBits(subnet) < 2 {
+		return GetIndexedIPReverse(subnet, index)
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("invalid negative index %d", index)
+	}
+	ip, err := GetIndexedIPReverse(subnet, index+1)
+	if err != nil {
+		return nil, err
+	}
+	if ip.Equal(subnet.IP.Mask(subnet.Mask)) {
+		return nil, fmt.Errorf("can't generate IP with reverse index %d from subnet. subnet too small. subnet: %q", index, subnet)
+	}
+	return ip, nil
+}
+
+// GetIndexedAddrReverse is like GetIndexedIPReverse but takes a netip.Prefix
+// and returns a netip.Addr.
+func GetIndexedAddrReverse(prefix netip.Prefix, index int) (netip.Addr, error) {
+	subnet := ipNetFromPrefix(prefix)
+	if subnet == nil {
+		return netip.Addr{}, fmt.Errorf("invalid prefix %q", prefix)
+	}
+	ip, err := GetIndexedIPReverse(subnet, index)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return addrFromIP(ip), nil
+}
+
+// GetIndexedUsableAddrReverse is like GetIndexedUsableIPReverse but takes a
+// netip.Prefix and returns a netip.Addr.
+func GetIndexedUsableAddrReverse(prefix netip.Prefix, index int) (netip.Addr, error) {
+	subnet := ipNetFromPrefix(prefix)
+	if subnet == nil {
+		return netip.Addr{}, fmt.Errorf("invalid prefix %q", prefix)
+	}
+	ip, err := GetIndexedUsableIPReverse(subnet, index)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return addrFromIP(ip), nil
+}
@@ -17,6 +17,11 @@ limitations under the License.
 package net
 
 import (
+	"math"
+	"net"
+	"net/netip"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -248,3 +253,330 @@ func TestGetIndexedIP(t *testing.T) {
 
 	}
 }
+
+func TestNextPrevIP(t *testing.T) {
+	testCases := []struct {
+		ip   string
+		next string
+		prev string
+	}{
+		{"10.0.0.1", "10.0.0.2", "10.0.0.0"},
+		{"10.0.0.255", "10.0.1.0", "10.0.0.254"},
+		{"10.0.1.0", "10.0.1.1", "10.0.0.255"},
+		{"::ffff:10.0.0.1", "10.0.0.2", "10.0.0.0"},
+		{"0.0.0.0", "0.0.0.1", ""},
+		{"255.255.255.255", "", "255.255.255.254"},
+		{"2001:db8::ffff", "2001:db8::1:0", "2001:db8::fffe"},
+		{"::", "::1", ""},
+		{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", "", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe"},
+		{"", "", ""},
+	}
+	for _, tc := range testCases {
+		ip := ParseIPSloppy(tc.ip)
+		next, prev := NextIP(ip), PrevIP(ip)
+		if (next == nil && tc.next != "") || (next != nil && next.String() != tc.next) {
+			t.Errorf("NextIP(%q): expected %q, got %v", tc.ip, tc.next, next)
+		}
+		if (prev == nil && tc.prev != "") || (prev != nil && prev.String() != tc.prev) {
+			t.Errorf("PrevIP(%q): expected %q, got %v", tc.ip, tc.prev, prev)
+		}
+		if IsIPv4(ip) && (next != nil && len(next) != net.IPv4len || prev != nil && len(prev) != net.IPv4len) {
+			t.Errorf("expected 4-byte results for IPv4 input %q", tc.ip)
+		}
+
+		addr, _ := netip.ParseAddr(tc.ip)
+		if got := NextAddr(addr); got.IsValid() != (tc.next != "") || got.IsValid() && got.String() != tc.next {
+			t.Errorf("NextAddr(%q): expected %q, got %v", tc.ip, tc.next, got)
+		}
+		if got := PrevAddr(addr); got.IsValid() != (tc.prev != "") || got.IsValid() && got.String() != tc.prev {
+			t.Errorf("PrevAddr(%q): expected %q, got %v", tc.ip, tc.prev, got)
+		}
+	}
+
+	ip := ParseIPSloppy("10.0.0.1")
+	_ = NextIP(ip)
+	_ = PrevIP(ip)
+	if ip.String() != "10.0.0.1" {
+		t.Errorf("input IP was modified: %s", ip)
+	}
+}
+
+func TestAddToIP(t *testing.T) {
+	testCases := []struct {
+		ip       string
+		offset   uint64
+		expected string
+	}{
+		{"10.0.0.1", 0, "10.0.0.1"},
+		{"10.0.0.1", 255, "10.0.1.0"},
+		{"10.0.0.0", 65536, "10.1.0.0"},
+		{"0.0.0.0", math.MaxUint32, "255.255.255.255"},
+		{"0.0.0.1", math.MaxUint32, ""},
+		{"0.0.0.0", math.MaxUint32 + 1, ""},
+		{"255.255.255.0", 256, ""},
+		{"2001:db8::", 1 << 32, "2001:db8::1:0:0"},
+		{"::", math.MaxUint64, "::ffff:ffff:ffff:ffff"},
+		{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fff0", 15, "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"},
+		{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fff0", 16, ""},
+		{"", 1, ""},
+	}
+	for _, tc := range testCases {
+		got := AddToIP(ParseIPSloppy(tc.ip), tc.offset)
+		if (got == nil && tc.expected != "") || (got != nil && got.String() != tc.expected) {
+			t.Errorf("AddToIP(%q, %d): expected %q, got %v", tc.ip, tc.offset, tc.expected, got)
+		}
+
+		addr, _ := netip.ParseAddr(tc.ip)
+		if got := AddrAdd(addr, tc.offset); got.IsValid() != (tc.expected != "") || got.IsValid() && got.String() != tc.expected {
+			t.Errorf("AddrAdd(%q, %d): expected %q, got %v", tc.ip, tc.offset, tc.expected, got)
+		}
+	}
+}
+
+func TestParseIPsByFamily(t *testing.T) {
+	ips, err := ParseIPsByFamily([]string{"2001:db8::2", "10.0.0.2", "010.000.000.001", "::ffff:10.0.0.3", "2001:db8::1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := map[IPFamily][]string{}
+	for family, familyIPs := range ips {
+		for _, ip := range familyIPs {
+			got[family] = append(got[family], ip.String())
+		}
+	}
+	expected := map[IPFamily][]string{
+		IPv4: {"10.0.0.2", "10.0.0.1", "10.0.0.3"},
+		IPv6: {"2001:db8::2", "2001:db8::1"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	ips, err = ParseIPsByFamily(nil)
+	if err != nil || len(ips) != 0 {
+		t.Errorf("expected empty result for no input, got %v, %v", ips, err)
+	}
+
+	_, err = ParseIPsByFamily([]string{"10.0.0.1", "bad", "2001:db8::1", "10.0.0.0/8"})
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if !strings.Contains(err.Error(), "IP[1]") || !strings.Contains(err.Error(), "IP[3]") {
+		t.Errorf("expected error to name both invalid entries, got %q", err)
+	}
+}
+
+func TestParseCIDRsByFamily(t *testing.T) {
+	cidrs, err := ParseCIDRsByFamily([]string{"fd00::/64", "10.0.0.0/8", "192.168.0.0/16"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := map[IPFamily][]string{}
+	for family, familyCIDRs := range cidrs {
+		got[family] = cidrStrings(familyCIDRs)
+	}
+	expected := map[IPFamily][]string{
+		IPv4: {"10.0.0.0/8", "192.168.0.0/16"},
+		IPv6: {"fd00::/64"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	_, err = ParseCIDRsByFamily([]string{"10.0.0.1", "10.0.0.0/8", "fd00::/129"})
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if !strings.Contains(err.Error(), "CIDR[0]") || !strings.Contains(err.Error(), "CIDR[2]") {
+		t.Errorf("expected error to name both invalid entries, got %q", err)
+	}
+}
+
+func TestParseHostPort(t *testing.T) {
+	testCases := []struct {
+		input       string
+		ip          string
+		family      IPFamily
+		port        int
+		expectError bool
+	}{
+		{input: "1.2.3.4:80", ip: "1.2.3.4", family: IPv4, port: 80},
+		{input: "001.002.003.004:80", ip: "1.2.3.4", family: IPv4, port: 80},
+		{input: "1.2.3.4", ip: "1.2.3.4", family: IPv4},
+		{input: "1.2.3.4:0", ip: "1.2.3.4", family: IPv4},
+		{input: "[2001:db8::1]:443", ip: "2001:db8::1", family: IPv6, port: 443},
+		{input: "[2001:db8::1]", ip: "2001:db8::1", family: IPv6},
+		{input: "2001:db8::1", ip: "2001:db8::1", family: IPv6},
+		{input: "::1", ip: "::1", family: IPv6},
+		{input: "[::ffff:1.2.3.4]:80", ip: "1.2.3.4", family: IPv4, port: 80},
+		{input: "localhost:80", expectError: true},
+		{input: "1.2.3.4:http", expectError: true},
+		{input: "1.2.3.4:65536", expectError: true},
+		{input: "2001:db8::1:80:", expectError: true},
+		{input: "[1.2.3.4:80", expectError: true},
+		{input: "1.2.3.4:", expectError: true},
+		{input: "[2001:db8::1]:", expectError: true},
+		{input: "[1.2.3.4]:80", expectError: true},
+		{input: "[1.2.3.4]", expectError: true},
+		{input: "[fe80::1%eth0]:80", expectError: true},
+		{input: "", expectError: true},
+	}
+	for _, tc := range testCases {
+		ip, family, port, err := ParseHostPort(tc.input)
+		if tc.expectError {
+			if err == nil {
+				t.Errorf("%q: expected error, got %v, %v, %v", tc.input, ip, family, port)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.input, err)
+			continue
+		}
+		if ip.String() != tc.ip || family != tc.family || port != tc.port {
+			t.Errorf("%q: expected %s, %q, %d; got %s, %q, %d", tc.input, tc.ip, tc.family, tc.port, ip, family, port)
+		}
+		if family == IPv4 && len(ip) != net.IPv4len {
+			t.Errorf("%q: expected IPv4 result in 4-byte form", tc.input)
+		}
+	}
+}
+
+func TestRangeSizeBig(t *testing.T) {
+	testCases := []struct {
+		cidr     string
+		expected string
+		uint64   uint64
+	}{
+		{"192.168.1.0/24", "256", 256},
+		{"192.168.1.1/32", "1", 1},
+		{"192.168.1.0/31", "2", 2},
+		{"0.0.0.0/0", "4294967296", 4294967296},
+		{"::ffff:192.168.1.0/120", "256", 256},
+		{"2001:db8::/120", "256", 256},
+		{"2001:db8::1/128", "1", 1},
+		{"2001:db8::/65", "9223372036854775808", 1 << 63},
+		{"2001:db8::/64", "18446744073709551616", math.MaxUint64},
+		{"2001:db8::/32", "79228162514264337593543950336", math.MaxUint64},
+		{"::/0", "340282366920938463463374607431768211456", math.MaxUint64},
+	}
+	for _, tc := range testCases {
+		_, cidr, err := ParseCIDRSloppy(tc.cidr)
+		if err != nil {
+			t.Fatalf("failed to parse cidr %s, unexpected error: '%s'", tc.cidr, err)
+		}
+		if size := RangeSizeBig(cidr).String(); size != tc.expected {
+			t.Errorf("RangeSizeBig(%s): expected %s, got %s", tc.cidr, tc.expected, size)
+		}
+		if size := RangeSizeUint64(cidr); size != tc.uint64 {
+			t.Errorf("RangeSizeUint64(%s): expected %d, got %d", tc.cidr, tc.uint64, size)
+		}
+
+		prefix := netip.MustParsePrefix(tc.cidr)
+		if size := PrefixRangeSizeBig(prefix).String(); size != tc.expected {
+			t.Errorf("PrefixRangeSizeBig(%s): expected %s, got %s", tc.cidr, tc.expected, size)
+		}
+		if size := PrefixRangeSizeUint64(prefix); size != tc.uint64 {
+			t.Errorf("PrefixRangeSizeUint64(%s): expected %d, got %d", tc.cidr, tc.uint64, size)
+		}
+	}
+
+	if size := RangeSizeBig(nil); size.Sign() != 0 {
+		t.Errorf("expected size 0 for nil subnet, got %s", size)
+	}
+	if size := RangeSizeUint64(nil); size != 0 {
+		t.Errorf("expected size 0 for nil subnet, got %d", size)
+	}
+	if size := PrefixRangeSizeBig(netip.Prefix{}); size.Sign() != 0 {
+		t.Errorf("expected size 0 for invalid prefix, got %s", size)
+	}
+	if size := PrefixRangeSizeUint64(netip.Prefix{}); size != 0 {
+		t.Errorf("expected size 0 for invalid prefix, got %d", size)
+	}
+}
+
+func TestGetIndexedIPReverse(t *testing.T) {
+	testCases := []struct {
+		cidr           string
+		index          int
+		expectedIP     string
+		expectedUsable string
+	}{
+		{cidr: "192.168.1.0/24", index: 0, expectedIP: "192.168.1.255", expectedUsable: "192.168.1.254"},
+		{cidr: "192.168.1.0/24", index: 1, expectedIP: "192.168.1.254", expectedUsable: "192.168.1.253"},
+		{cidr: "192.168.1.0/24", index: 254, expectedIP: "192.168.1.1", expectedUsable: ""},
+		{cidr: "192.168.1.0/24", index: 253, expectedIP: "192.168.1.2", expectedUsable: "192.168.1.1"},
+		{cidr: "192.168.1.0/24", index: 255, expectedIP: "192.168.1.0", expectedUsable: ""},
+		{cidr: "192.168.1.0/24", index: 256, expectedIP: "", expectedUsable: ""},
+		{cidr: "192.168.1.0/24", index: -1, expectedIP: "", expectedUsable: ""},
+		{cidr: "192.168.1.0/30", index: 1, expectedIP: "192.168.1.2", expectedUsable: "192.168.1.1"},
+		{cidr: "192.168.1.0/31", index: 1, expectedIP: "192.168.1.0", expectedUsable: "192.168.1.0"},
+		{cidr: "192.168.1.1/32", index: 0, expectedIP: "192.168.1.1", expectedUsable: "192.168.1.1"},
+		{cidr: "0.0.0.0/0", index: 0, expectedIP: "255.255.255.255", expectedUsable: "255.255.255.254"},
+		{cidr: "fd:11:b2:be::/120", index: 0, expectedIP: "fd:11:b2:be::ff", expectedUsable: "fd:11:b2:be::ff"},
+		{cidr: "fd:11:b2:be::/120", index: 255, expectedIP: "fd:11:b2:be::", expectedUsable: "fd:11:b2:be::"},
+		{cidr: "fd:11:b2:be::/120", index: 256, expectedIP: "", expectedUsable: ""},
+	}
+
+	for _, tc := range testCases {
+		_, subnet, err := ParseCIDRSloppy(tc.cidr)
+		if err != nil {
+			t.Fatalf("failed to parse cidr %s, unexpected error: '%s'", tc.cidr, err)
+		}
+
+		ip, err := GetIndexedIPReverse(subnet, tc.index)
+		if tc.expectedIP == "" {
+			if err == nil {
+				t.Errorf("GetIndexedIPReverse(%s, %d): expected error, got %s", tc.cidr, tc.index, ip)
+			}
+		} else if err != nil {
+			t.Errorf("GetIndexedIPReverse(%s, %d): unexpected error: %v", tc.cidr, tc.index, err)
+		} else if ip.String() != tc.expectedIP {
+			t.Errorf("GetIndexedIPReverse(%s, %d): expected %s, got %s", tc.cidr, tc.index, tc.expectedIP, ip)
+		}
+
+		ip, err = GetIndexedUsableIPReverse(subnet, tc.index)
+		if tc.expectedUsable == "" {
+			if err == nil {
+				t.Errorf("GetIndexedUsableIPReverse(%s, %d): expected error, got %s", tc.cidr, tc.index, ip)
+			}
+		} else if err != nil {
+			t.Errorf("GetIndexedUsableIPReverse(%s, %d): unexpected error: %v", tc.cidr, tc.index, err)
+		} else if ip.String() != tc.expectedUsable {
+			t.Errorf("GetIndexedUsableIPReverse(%s, %d): expected %s, got %s", tc.cidr, tc.index, tc.expectedUsable, ip)
+		}
+
+		prefix := netip.MustParsePrefix(tc.cidr)
+		addr, err := GetIndexedAddrReverse(prefix, tc.index)
+		if tc.expectedIP == "" {
+			if err == nil {
+				t.Errorf("GetIndexedAddrReverse(%s, %d): expected error, got %s", tc.cidr, tc.index, addr)
+			}
+		} else if err != nil {
+			t.Errorf("GetIndexedAddrReverse(%s, %d): unexpected error: %v", tc.cidr, tc.index, err)
+		} else if addr.String() != tc.expectedIP {
+			t.Errorf("GetIndexedAddrReverse(%s, %d): expected %s, got %s", tc.cidr, tc.index, tc.expectedIP, addr)
+		}
+
+		addr, err = GetIndexedUsableAddrReverse(prefix, tc.index)
+		if tc.expectedUsable == "" {
+			if err == nil {
+				t.Errorf("GetIndexedUsableAddrReverse(%s, %d): expected error, got %s", tc.cidr, tc.index, addr)
+			}
+		} else if err != nil {
+			t.Errorf("GetIndexedUsableAddrReverse(%s, %d): unexpected error: %v", tc.cidr, tc.index, err)
+		} else if addr.String() != tc.expectedUsable {
+			t.Errorf("GetIndexedUsableAddrReverse(%s, %d): expected %s, got %s", tc.cidr, tc.index, tc.expectedUsable, addr)
+		}
+	}
+
+	if _, err := GetIndexedIPReverse(nil, 0); err == nil {
+		t.Errorf("expected error for nil subnet")
+	}
+	if _, err := GetIndexedAddrReverse(netip.Prefix{}, 0); err == nil {
+		t.Errorf("expected error for invalid prefix")
+	}
+	if _, err := GetIndexedUsableAddrReverse(netip.Prefix{}, 0); err == nil {
+		t.Errorf("expected error for invalid prefix")
+	}
+}
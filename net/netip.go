@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"net"
+	"net/netip"
+)
+
+// The netip.Addr and netip.Prefix variants of the functions in this package
+// convert their arguments to the net.IP forms and back, so that both forms
+// share a single implementation and the same IPv4-mapped IPv6 semantics.
+
+// ipFromNetipAddr returns addr as a net.IP, in 4-byte form if addr is IPv4 or an
+// IPv4-mapped IPv6 address, or nil if addr is invalid. Any zone is dropped.
+func ipFromNetipAddr(addr netip.Addr) net.IP {
+	if !addr.IsValid() {
+		return nil
+	}
+	return net.IP(addr.Unmap().AsSlice())
+}
+
+// addrFromIP returns ip as a netip.Addr, unmapping IPv4-mapped IPv6 addresses,
+// or the zero Addr if ip is nil or invalid.
+func addrFromIP(ip net.IP) netip.Addr {
+	addr, _ := netip.AddrFromSlice(normalizeIP(ip))
+	return addr
+}
+
+// ipNetFromPrefix returns prefix as a *net.IPNet with its host bits cleared,
+// or nil if prefix is invalid. IPv4-mapped IPv6 prefixes of length 96 or more
+// are converted to the equivalent IPv4 CIDR.
+func ipNetFromPrefix(prefix netip.Prefix) *net.IPNet {
+	if !prefix.IsValid() {
+		return nil
+	}
+	addr, ones := prefix.Addr(), prefix.Bits()
+	if addr.Is4In6() && ones >= 8*(net.IPv6len-net.IPv4len) {
+		addr, ones = addr.Unmap(), ones-8*(net.IPv6len-net.IPv4len)
+	}
+	addr = netip.PrefixFrom(addr, ones).Masked().Addr()
+	return &net.IPNet{IP: net.IP(addr.AsSlice()), Mask: net.CIDRMask(ones, addr.BitLen())}
+}
+
+// prefixFromIPNet returns cidr as a netip.Prefix with its host bits cleared,
+// or the zero Prefix if cidr is nil or invalid. IPv4-mapped IPv6 CIDRs are
+// converted to IPv4.
+func prefixFromIPNet(cidr *net.IPNet) netip.Prefix {
+	if cidr == nil {
+		return netip.Prefix{}
+	}
+	if _, bits := cidr.Mask.Size(); bits == 0 {
+		return netip.Prefix{}
+	}
+	addr := addrFromIP(cidr.IP)
+	if !addr.IsValid() {
+		return netip.Prefix{}
+	}
+	return netip.PrefixFrom(addr, cidrPrefixLen(cidr)).Masked()
+}
@@ -17,6 +17,9 @@ limitations under the License.
 package net
 
 import (
+	"net"
+	"strings"
+
 	forkednet "k8s.io/utils/internal/third_party/forked/golang/net"
 )
 
@@ -31,3 +34,21 @@ var ParseIPSloppy = forkednet.ParseIP
 // the behavior in 1.17.  We're choosing to keep it for compat with potential
 // stored values.
 var ParseCIDRSloppy = forkednet.ParseCIDR
+
+// ParseIPWithZone parses an IP that may have an IPv6 zone suffix, such as
+// "fe80::1%eth0", returning the IP and the zone (or "" if there is none). The
+// IP part is parsed with ParseIPSloppy. It returns a nil IP if the string is
+// not a valid IP, if the zone is empty, or if a zone is attached to an IPv4
+// address. Note that ParseIPSloppy and the other parsing functions in this
+// package reject zoned addresses.
+func ParseIPWithZone(ipStr string) (net.IP, string) {
+	i := strings.LastIndex(ipStr, "%")
+	if i < 0 {
+		return ParseIPSloppy(ipStr), ""
+	}
+	ip, zone := ParseIPSloppy(ipStr[:i]), ipStr[i+1:]
+	if ip == nil || zone == "" || ip.To4() != nil {
+		return nil, ""
+	}
+	return ip, zone
+}
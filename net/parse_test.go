@@ -0,0 +1,52 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"testing"
+)
+
+func TestParseIPWithZone(t *testing.T) {
+	testCases := []struct {
+		input string
+		ip    string
+		zone  string
+	}{
+		{input: "fe80::1%eth0", ip: "fe80::1", zone: "eth0"},
+		{input: "fe80::1%2", ip: "fe80::1", zone: "2"},
+		{input: "ff02::1%br-ex", ip: "ff02::1", zone: "br-ex"},
+		{input: "fe80::1", ip: "fe80::1"},
+		{input: "2001:db8::1", ip: "2001:db8::1"},
+		{input: "1.2.3.4", ip: "1.2.3.4"},
+		{input: "001.002.003.004", ip: "1.2.3.4"},
+		{input: "fe80::1%", ip: "<nil>"},
+		{input: "1.2.3.4%eth0", ip: "<nil>"},
+		{input: "::ffff:1.2.3.4%eth0", ip: "<nil>"},
+		{input: "%eth0", ip: "<nil>"},
+		{input: "bad%eth0", ip: "<nil>"},
+		{input: "", ip: "<nil>"},
+	}
+	for _, tc := range testCases {
+		ip, zone := ParseIPWithZone(tc.input)
+		if ip.String() != tc.ip || zone != tc.zone {
+			t.Errorf("%q: expected %s, %q; got %s, %q", tc.input, tc.ip, tc.zone, ip, zone)
+		}
+		if zone != "" && ParseIPSloppy(tc.input) != nil {
+			t.Errorf("%q: expected ParseIPSloppy to reject zoned IP", tc.input)
+		}
+	}
+}
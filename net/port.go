@@ -17,6 +17,7 @@ limitations under the License.
 package net
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"strconv"
@@ -80,6 +81,55 @@ func (lp *LocalPort) String() string {
 	return fmt.Sprintf("%q (%s/%s%s)", lp.Description, ipPort, strings.ToLower(string(lp.Protocol)), lp.IPFamily)
 }
 
+// PortRange is an inclusive range of port numbers.
+type PortRange struct {
+	// First is the first port in the range.
+	First int
+	// Last is the last port in the range.
+	Last int
+}
+
+// ParsePortRange parses a port range of the form "first-last", e.g.
+// "30000-32767", or a single port, which results in a range of size 1. Ports
+// must be between 1 and 65535 and first must not be greater than last.
+func ParsePortRange(value string) (*PortRange, error) {
+	firstStr, lastStr := value, value
+	if i := strings.Index(value, "-"); i >= 0 {
+		firstStr, lastStr = value[:i], value[i+1:]
+	}
+	first, err := ParsePort(strings.TrimSpace(firstStr), false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port range %q: %v", value, err)
+	}
+	last, err := ParsePort(strings.TrimSpace(lastStr), false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port range %q: %v", value, err)
+	}
+	if first > last {
+		return nil, fmt.Errorf("invalid port range %q: first port is greater than last port", value)
+	}
+	return &PortRange{First: first, Last: last}, nil
+}
+
+// Contains returns true if port is within the range.
+func (pr *PortRange) Contains(port int) bool {
+	return pr.First <= port && port <= pr.Last
+}
+
+// Size returns the number of ports in the range.
+func (pr *PortRange) Size() int {
+	if pr.Last < pr.First {
+		return 0
+	}
+	return pr.Last - pr.First + 1
+}
+
+// String returns the range in the form "first-last", which can be parsed
+// by ParsePortRange.
+func (pr *PortRange) String() string {
+	return fmt.Sprintf("%d-%d", pr.First, pr.Last)
+}
+
 // Closeable closes an opened LocalPort.
 type Closeable interface {
 	Close() error
@@ -90,34 +140,71 @@ type PortOpener interface {
 	OpenLocalPort(lp *LocalPort) (Closeable, error)
 }
 
-type listenPortOpener struct{}
+// PortOpenerWithContext is a PortOpener that can also open a LocalPort
+// with a context, so that callers can cancel slow binds.
+type PortOpenerWithContext interface {
+	PortOpener
+	OpenLocalPortContext(ctx context.Context, lp *LocalPort) (Closeable, error)
+}
+
+// ListenPortOpenerOptions configures the sockets opened by a PortOpener
+// returned from NewListenPortOpener.
+type ListenPortOpenerOptions struct {
+	// ReuseAddr sets SO_REUSEADDR on the socket before it is bound.
+	ReuseAddr bool
+	// ReusePort sets SO_REUSEPORT on the socket before it is bound, allowing
+	// another process (e.g. a restarted instance of the caller) to bind the
+	// same port while this socket is still open. It is not supported on
+	// Windows.
+	ReusePort bool
+}
+
+type listenPortOpener struct {
+	opts ListenPortOpenerOptions
+}
 
 // ListenPortOpener opens ports by calling bind() and listen().
 var ListenPortOpener listenPortOpener
 
+var _ PortOpenerWithContext = &ListenPortOpener
+
+// NewListenPortOpener returns a PortOpener that opens ports by calling bind()
+// and listen(), after setting the socket options requested in opts.
+func NewListenPortOpener(opts ListenPortOpenerOptions) PortOpenerWithContext {
+	return &listenPortOpener{opts: opts}
+}
+
 // OpenLocalPort holds the given local port open.
 func (l *listenPortOpener) OpenLocalPort(lp *LocalPort) (Closeable, error) {
-	return openLocalPort(lp)
+	return openLocalPort(context.Background(), lp, l.opts)
+}
+
+// OpenLocalPortContext holds the given local port open. ctx bounds the time
+// spent opening the port; it has no effect once the port is open.
+func (l *listenPortOpener) OpenLocalPortContext(ctx context.Context, lp *LocalPort) (Closeable, error) {
+	return openLocalPort(ctx, lp, l.opts)
 }
 
-func openLocalPort(lp *LocalPort) (Closeable, error) {
+func openLocalPort(ctx context.Context, lp *LocalPort, opts ListenPortOpenerOptions) (Closeable, error) {
+	// net.ListenConfig only consults ctx while resolving the address, so
+	// check it up front as well.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	var socket Closeable
 	hostPort := net.JoinHostPort(lp.IP, strconv.Itoa(lp.Port))
+	lc := net.ListenConfig{Control: socketControl(opts)}
 	switch lp.Protocol {
 	case TCP:
 		network := "tcp" + string(lp.IPFamily)
-		listener, err := net.Listen(network, hostPort)
+		listener, err := lc.Listen(ctx, network, hostPort)
 		if err != nil {
 			return nil, err
 		}
 		socket = listener
 	case UDP:
 		network := "udp" + string(lp.IPFamily)
-		addr, err := net.ResolveUDPAddr(network, hostPort)
-		if err != nil {
-			return nil, err
-		}
-		conn, err := net.ListenUDP(network, addr)
+		conn, err := lc.ListenPacket(ctx, network, hostPort)
 		if err != nil {
 			return nil, err
 		}
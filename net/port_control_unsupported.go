@@ -0,0 +1,36 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd && !windows
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"fmt"
+	"runtime"
+	"syscall"
+)
+
+// socketControl returns a net.ListenConfig Control function that sets the
+// socket options requested in opts, or nil if no options are requested.
+func socketControl(opts ListenPortOpenerOptions) func(network, address string, c syscall.RawConn) error {
+	if !opts.ReuseAddr && !opts.ReusePort {
+		return nil
+	}
+	return func(network, address string, c syscall.RawConn) error {
+		return fmt.Errorf("socket options are not supported on %s", runtime.GOOS)
+	}
+}
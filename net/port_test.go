@@ -17,6 +17,9 @@ limitations under the License.
 package net
 
 import (
+	"context"
+	"net"
+	"runtime"
 	"testing"
 )
 
@@ -85,3 +88,138 @@ func TestLocalPortString(t *testing.T) {
 		}
 	}
 }
+
+func TestParsePortRange(t *testing.T) {
+	testCases := []struct {
+		input       string
+		expected    string
+		size        int
+		expectError bool
+	}{
+		{input: "30000-32767", expected: "30000-32767", size: 2768},
+		{input: " 80 - 90 ", expected: "80-90", size: 11},
+		{input: "1-65535", expected: "1-65535", size: 65535},
+		{input: "8080", expected: "8080-8080", size: 1},
+		{input: "8080-8080", expected: "8080-8080", size: 1},
+		{input: "90-80", expectError: true},
+		{input: "0-80", expectError: true},
+		{input: "80-65536", expectError: true},
+		{input: "80-", expectError: true},
+		{input: "-80", expectError: true},
+		{input: "80-90-100", expectError: true},
+		{input: "a-b", expectError: true},
+		{input: "", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		pr, err := ParsePortRange(tc.input)
+		if tc.expectError {
+			if err == nil {
+				t.Errorf("%q: expected error, got %v", tc.input, pr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.input, err)
+			continue
+		}
+		if pr.String() != tc.expected {
+			t.Errorf("%q: expected %s, got %s", tc.input, tc.expected, pr)
+		}
+		if pr.Size() != tc.size {
+			t.Errorf("%q: expected size %d, got %d", tc.input, tc.size, pr.Size())
+		}
+	}
+}
+
+func TestPortRangeContains(t *testing.T) {
+	pr := &PortRange{First: 30000, Last: 32767}
+	testCases := []struct {
+		port     int
+		expected bool
+	}{
+		{29999, false},
+		{30000, true},
+		{31000, true},
+		{32767, true},
+		{32768, false},
+	}
+	for _, tc := range testCases {
+		if got := pr.Contains(tc.port); got != tc.expected {
+			t.Errorf("Contains(%d): expected %v, got %v", tc.port, tc.expected, got)
+		}
+	}
+	if size := (&PortRange{First: 10, Last: 5}).Size(); size != 0 {
+		t.Errorf("expected size 0 for an inverted range, got %d", size)
+	}
+}
+
+func TestListenPortOpenerReusePort(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skipf("SO_REUSEPORT test only runs on linux")
+	}
+	for _, protocol := range []Protocol{TCP, UDP} {
+		t.Run(string(protocol), func(t *testing.T) {
+			opener := NewListenPortOpener(ListenPortOpenerOptions{ReuseAddr: true, ReusePort: true})
+
+			lp, err := NewLocalPort("first", "127.0.0.1", IPFamilyUnknown, 0, protocol)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			first, err := opener.OpenLocalPort(lp)
+			if err != nil {
+				t.Fatalf("unexpected error opening first socket: %v", err)
+			}
+			defer first.Close()
+
+			var port int
+			switch s := first.(type) {
+			case net.Listener:
+				port = s.Addr().(*net.TCPAddr).Port
+			case net.PacketConn:
+				port = s.LocalAddr().(*net.UDPAddr).Port
+			default:
+				t.Fatalf("unexpected socket type %T", first)
+			}
+
+			lp, err = NewLocalPort("second", "127.0.0.1", IPFamilyUnknown, port, protocol)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			second, err := opener.OpenLocalPort(lp)
+			if err != nil {
+				t.Fatalf("expected to be able to reopen port %d with SO_REUSEPORT: %v", port, err)
+			}
+			second.Close()
+
+			if third, err := ListenPortOpener.OpenLocalPort(lp); err == nil {
+				third.Close()
+				t.Errorf("expected reopening port %d without SO_REUSEPORT to fail", port)
+			}
+		})
+	}
+}
+
+func TestOpenLocalPortContext(t *testing.T) {
+	for _, protocol := range []Protocol{TCP, UDP} {
+		t.Run(string(protocol), func(t *testing.T) {
+			lp, err := NewLocalPort("test", "127.0.0.1", IPFamilyUnknown, 0, protocol)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			port, err := ListenPortOpener.OpenLocalPortContext(context.Background(), lp)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			port.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			if port, err := ListenPortOpener.OpenLocalPortContext(ctx, lp); err == nil {
+				port.Close()
+				t.Errorf("expected error opening port with a cancelled context")
+			}
+		})
+	}
+}
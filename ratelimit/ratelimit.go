@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ratelimit provides a token bucket rate limiter which measures time
+// with a clock.Clock, so that code using it can be tested with a fake clock.
+package ratelimit // import "k8s.io/utils/ratelimit"
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// Inf is an infinite rate, which allows all events.
+var Inf = math.Inf(1)
+
+// Limiter is a token bucket rate limiter: the bucket holds up to burst tokens
+// and is refilled at rate tokens per second, and each event takes a token. It
+// starts with a full bucket. It is safe for concurrent use.
+type Limiter struct {
+	clock clock.Clock
+	rate  float64
+	burst int
+
+	lock sync.Mutex
+	// tokens is the number of tokens in the bucket at last. It is negative
+	// when tokens have been reserved ahead of time.
+	tokens float64
+	last   time.Time
+	// lastEvent is the latest time to act of any reservation.
+	lastEvent time.Time
+}
+
+// NewLimiter returns a Limiter which allows events at up to rate per second,
+// with bursts of up to burst events, measuring time with c.
+func NewLimiter(c clock.Clock, rate float64, burst int) *Limiter {
+	return &Limiter{
+		clock:  c,
+		rate:   rate,
+		burst:  burst,
+		tokens: float64(burst),
+		last:   c.Now(),
+	}
+}
+
+// Reservation holds tokens reserved from a Limiter for an event which may
+// happen after a delay.
+type Reservation struct {
+	limiter   *Limiter
+	ok        bool
+	tokens    int
+	timeToAct time.Time
+}
+
+// OK returns true if the tokens could be reserved. If not, the event must not
+// happen, and the Reservation's other methods have no effect.
+func (r *Reservation) OK() bool {
+	return r.ok
+}
+
+// Delay returns how long to wait before the event can happen.
+func (r *Reservation) Delay() time.Duration {
+	if !r.ok {
+		return 0
+	}
+	if d := r.timeToAct.Sub(r.limiter.clock.Now()); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// Cancel returns the reserved tokens to the limiter, for an event which won't
+// happen after all, as far as possible: nothing is returned once the
+// reservation's time to act has passed, and tokens which reservations made
+// after this one already count on are kept.
+func (r *Reservation) Cancel() {
+	if !r.ok {
+		return
+	}
+	r.ok = false
+	l := r.limiter
+	if l.rate == Inf {
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	now := l.clock.Now()
+	if now.After(r.timeToAct) {
+		return
+	}
+	restore := float64(r.tokens) - l.lastEvent.Sub(r.timeToAct).Seconds()*l.rate
+	if restore <= 0 {
+		return
+	}
+	l.advance(now)
+	l.tokens = math.Min(l.tokens+restore, float64(l.burst))
+	if l.rate > 0 && r.timeToAct.Equal(l.lastEvent) {
+		// this was the latest reservation, so the one before it is now
+		if prev := r.timeToAct.Add(-time.Duration(float64(r.tokens) / l.rate * float64(time.Second))); !prev.Before(now) {
+			l.lastEvent = prev
+		}
+	}
+}
+
+// Allow is AllowN(1).
+func (l *Limiter) Allow() bool {
+	return l.AllowN(1)
+}
+
+// AllowN returns true and takes n tokens if n events may happen now.
+func (l *Limiter) AllowN(n int) bool {
+	return l.reserveN(n, 0).ok
+}
+
+// Reserve is ReserveN(1).
+func (l *Limiter) Reserve() *Reservation {
+	return l.ReserveN(1)
+}
+
+// ReserveN reserves n tokens for n events which may happen after the
+// Reservation's Delay. The Reservation is not OK if n exceeds the burst size.
+func (l *Limiter) ReserveN(n int) *Reservation {
+	return l.reserveN(n, time.Duration(math.MaxInt64))
+}
+
+// Wait is WaitN(ctx, 1).
+func (l *Limiter) Wait(ctx context.Context) error {
+	return l.WaitN(ctx, 1)
+}
+
+// WaitN blocks until n events may happen, using a timer from the limiter's
+// clock. It fails if n exceeds the burst size, if ctx is done first, or if
+// ctx's deadline, assumed to be measured by the limiter's clock as with
+// clock.WithDeadline, would pass before then.
+func (l *Limiter) WaitN(ctx context.Context, n int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	maxWait := time.Duration(math.MaxInt64)
+	if deadline, ok := ctx.Deadline(); ok {
+		maxWait = deadline.Sub(l.clock.Now())
+	}
+	r := l.reserveN(n, maxWait)
+	if !r.ok {
+		if n > l.burst {
+			return fmt.Errorf("can't wait for %d events: more than the burst of %d", n, l.burst)
+		}
+		return fmt.Errorf("can't wait for %d events: the wait would exceed the context deadline", n)
+	}
+	delay := r.Delay()
+	if delay == 0 {
+		return nil
+	}
+	t := l.clock.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C():
+		return nil
+	case <-ctx.Done():
+		r.Cancel()
+		return ctx.Err()
+	}
+}
+
+// advance refills the bucket for the time passed since last. l must be
+// locked.
+func (l *Limiter) advance(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = math.Min(l.tokens+elapsed.Seconds()*l.rate, float64(l.burst))
+		l.last = now
+	}
+}
+
+// reserveN reserves n tokens if they are available within maxWait.
+func (l *Limiter) reserveN(n int, maxWait time.Duration) *Reservation {
+	now := l.clock.Now()
+	r := &Reservation{limiter: l, tokens: n, timeToAct: now}
+	if l.rate == Inf {
+		r.ok = true
+		return r
+	}
+	if n > l.burst {
+		return r
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.advance(now)
+	tokens := l.tokens - float64(n)
+	var wait time.Duration
+	if tokens < 0 {
+		if l.rate <= 0 {
+			return r
+		}
+		wait = time.Duration(math.MaxInt64)
+		if w := math.Ceil(-tokens / l.rate * float64(time.Second)); w < float64(math.MaxInt64) {
+			wait = time.Duration(w)
+		}
+	}
+	if wait > maxWait {
+		return r
+	}
+	l.tokens = tokens
+	r.ok = true
+	r.timeToAct = now.Add(wait)
+	l.lastEvent = r.timeToAct
+	return r
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/utils/clock"
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+func TestAllow(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	l := NewLimiter(fakeClock, 2, 3)
+
+	// the bucket starts full
+	for i := 0; i < 3; i++ {
+		if !l.Allow() {
+			t.Errorf("expected event %d of the burst to be allowed", i)
+		}
+	}
+	if l.Allow() {
+		t.Errorf("expected event to be denied once the burst is used up")
+	}
+
+	// tokens are refilled at 2 per second
+	fakeClock.Step(500 * time.Millisecond)
+	if !l.Allow() {
+		t.Errorf("expected event to be allowed after a token was refilled")
+	}
+	if l.Allow() {
+		t.Errorf("expected event to be denied")
+	}
+
+	// the bucket doesn't fill beyond the burst
+	fakeClock.Step(time.Hour)
+	if !l.AllowN(3) {
+		t.Errorf("expected a full burst to be allowed")
+	}
+	if l.AllowN(1) {
+		t.Errorf("expected event to be denied")
+	}
+	if l.AllowN(4) {
+		t.Errorf("expected more than the burst to be denied")
+	}
+}
+
+func TestAllowLimits(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	inf := NewLimiter(fakeClock, Inf, 0)
+	for i := 0; i < 100; i++ {
+		if !inf.AllowN(10) {
+			t.Fatalf("expected an infinite rate to allow all events")
+		}
+	}
+
+	zero := NewLimiter(fakeClock, 0, 1)
+	if !zero.Allow() {
+		t.Errorf("expected the initial burst to be allowed")
+	}
+	fakeClock.Step(time.Hour)
+	if zero.Allow() || zero.Reserve().OK() {
+		t.Errorf("expected a zero rate never to refill")
+	}
+}
+
+func TestReserve(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	l := NewLimiter(fakeClock, 10, 1)
+
+	if r := l.Reserve(); !r.OK() || r.Delay() != 0 {
+		t.Errorf("expected immediate reservation, got %v, %v", r.OK(), r.Delay())
+	}
+	r := l.Reserve()
+	if !r.OK() || r.Delay() != 100*time.Millisecond {
+		t.Errorf("expected reservation in 100ms, got %v, %v", r.OK(), r.Delay())
+	}
+	if r2 := l.Reserve(); !r2.OK() || r2.Delay() != 200*time.Millisecond {
+		t.Errorf("expected reservation in 200ms, got %v, %v", r2.OK(), r2.Delay())
+	}
+	fakeClock.Step(50 * time.Millisecond)
+	if r.Delay() != 50*time.Millisecond {
+		t.Errorf("expected remaining delay of 50ms, got %v", r.Delay())
+	}
+
+	// cancelling a reservation doesn't return the tokens which later
+	// reservations already count on
+	r.Cancel()
+	r.Cancel()
+	r3 := l.Reserve()
+	if r3.Delay() != 250*time.Millisecond {
+		t.Errorf("expected reservation in 250ms after cancelling, got %v", r3.Delay())
+	}
+
+	// cancelling the latest reservation returns its tokens
+	r3.Cancel()
+	r4 := l.Reserve()
+	if r4.Delay() != 250*time.Millisecond {
+		t.Errorf("expected reservation in 250ms after cancelling the latest reservation, got %v", r4.Delay())
+	}
+
+	// cancelling after the time to act has passed returns nothing
+	fakeClock.Step(300 * time.Millisecond)
+	r4.Cancel()
+	if l.Allow() {
+		t.Errorf("expected cancelling a reservation after its time to act not to return its tokens")
+	}
+
+	if l.ReserveN(2).OK() {
+		t.Errorf("expected reservation of more than the burst to fail")
+	}
+}
+
+func TestWait(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	l := NewLimiter(fakeClock, 1, 1)
+	ctx := context.Background()
+
+	if err := l.Wait(ctx); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- l.Wait(ctx)
+	}()
+	if err := fakeClock.BlockUntilWaiters(ctx, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fakeClock.Step(time.Second)
+	if err := <-done; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := l.WaitN(ctx, 2); err == nil {
+		t.Errorf("expected error waiting for more than the burst")
+	}
+
+	// the wait would exceed the deadline
+	deadlineCtx, deadlineCancel := clock.WithTimeout(ctx, fakeClock, 500*time.Millisecond)
+	if err := l.Wait(deadlineCtx); err == nil {
+		t.Errorf("expected error waiting past the context deadline")
+	}
+	deadlineCancel()
+	for fakeClock.HasWaiters() {
+		time.Sleep(time.Millisecond)
+	}
+
+	// cancelling the context interrupts the wait and returns the token
+	cancelCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		done <- l.Wait(cancelCtx)
+	}()
+	if err := fakeClock.BlockUntilWaiters(ctx, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+	fakeClock.Step(time.Second)
+	if !l.Allow() {
+		t.Errorf("expected the token of the cancelled wait to be returned")
+	}
+}
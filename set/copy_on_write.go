@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package set
+
+import (
+	"sync/atomic"
+)
+
+// CopyOnWrite is a set whose Clone takes constant time: clones share the same underlying Set until
+// one of them is modified, at which point the modified one makes its own copy. This avoids the
+// cost of copying large sets which are cloned often but rarely modified.
+// Clones may be used from different goroutines, but each CopyOnWrite on its own is not thread
+// safe.
+type CopyOnWrite[E ordered] struct {
+	set Set[E]
+	// refs is the number of CopyOnWrite values sharing set.
+	refs *int32
+}
+
+// NewCopyOnWrite creates a new CopyOnWrite set holding the contents of s. It takes ownership of s,
+// which must not be used by the caller afterwards.
+func NewCopyOnWrite[E ordered](s Set[E]) *CopyOnWrite[E] {
+	if s == nil {
+		s = Set[E]{}
+	}
+	refs := int32(1)
+	return &CopyOnWrite[E]{set: s, refs: &refs}
+}
+
+// Clone returns a new set with the same contents, sharing storage with c until either is modified.
+func (c *CopyOnWrite[E]) Clone() *CopyOnWrite[E] {
+	atomic.AddInt32(c.refs, 1)
+	return &CopyOnWrite[E]{set: c.set, refs: c.refs}
+}
+
+// own ensures that c is the only user of its underlying Set, copying it if it is shared.
+func (c *CopyOnWrite[E]) own() {
+	if atomic.LoadInt32(c.refs) == 1 {
+		return
+	}
+	copied := c.set.Clone()
+	// only release the shared set once we are done reading it, so that the other users don't
+	// start modifying it in place while it is being copied
+	atomic.AddInt32(c.refs, -1)
+	refs := int32(1)
+	c.set = copied
+	c.refs = &refs
+}
+
+// Insert adds items to the set.
+func (c *CopyOnWrite[E]) Insert(items ...E) *CopyOnWrite[E] {
+	if len(items) > 0 {
+		c.own()
+		c.set.Insert(items...)
+	}
+	return c
+}
+
+// Delete removes all items from the set.
+func (c *CopyOnWrite[E]) Delete(items ...E) *CopyOnWrite[E] {
+	if c.set.HasAny(items...) {
+		c.own()
+		c.set.Delete(items...)
+	}
+	return c
+}
+
+// Has returns true if and only if item is contained in the set.
+func (c *CopyOnWrite[E]) Has(item E) bool {
+	return c.set.Has(item)
+}
+
+// HasAll returns true if and only if all items are contained in the set.
+func (c *CopyOnWrite[E]) HasAll(items ...E) bool {
+	return c.set.HasAll(items...)
+}
+
+// HasAny returns true if any items are contained in the set.
+func (c *CopyOnWrite[E]) HasAny(items ...E) bool {
+	return c.set.HasAny(items...)
+}
+
+// Len returns the number of elements in the set.
+func (c *CopyOnWrite[E]) Len() int {
+	return c.set.Len()
+}
+
+// SortedList returns the contents as a sorted slice.
+func (c *CopyOnWrite[E]) SortedList() []E {
+	return c.set.SortedList()
+}
+
+// UnsortedList returns the slice with contents in random order.
+func (c *CopyOnWrite[E]) UnsortedList() []E {
+	return c.set.UnsortedList()
+}
+
+// Set returns a copy of the contents as a Set.
+func (c *CopyOnWrite[E]) Set() Set[E] {
+	return c.set.Clone()
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package set
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// sharesStorage returns true if c1 and c2 use the same underlying map.
+func sharesStorage[E ordered](c1, c2 *CopyOnWrite[E]) bool {
+	return reflect.ValueOf(c1.set).Pointer() == reflect.ValueOf(c2.set).Pointer()
+}
+
+func TestCopyOnWrite(t *testing.T) {
+	c := NewCopyOnWrite(New("a", "b"))
+	clone := c.Clone()
+	if !sharesStorage(c, clone) {
+		t.Errorf("Expected clone to share storage before modification")
+	}
+
+	// Reads and no-op writes don't copy.
+	if !clone.Has("a") || !clone.HasAll("a", "b") || !clone.HasAny("c", "b") || clone.Len() != 2 {
+		t.Errorf("Unexpected contents: %v", clone.SortedList())
+	}
+	clone.Insert().Delete("z")
+	if !sharesStorage(c, clone) {
+		t.Errorf("Expected no-op modifications not to copy")
+	}
+
+	clone.Insert("c")
+	if sharesStorage(c, clone) {
+		t.Errorf("Expected modified clone to have its own storage")
+	}
+	if e, a := []string{"a", "b", "c"}, clone.SortedList(); !reflect.DeepEqual(e, a) {
+		t.Errorf("Expected clone to contain %v, got %v", e, a)
+	}
+	if e, a := []string{"a", "b"}, c.SortedList(); !reflect.DeepEqual(e, a) {
+		t.Errorf("Expected original to contain %v, got %v", e, a)
+	}
+
+	// The original is no longer shared, so it is modified in place.
+	before := reflect.ValueOf(c.set).Pointer()
+	c.Delete("a")
+	if reflect.ValueOf(c.set).Pointer() != before {
+		t.Errorf("Expected unshared set to be modified in place")
+	}
+	if e, a := []string{"b"}, c.SortedList(); !reflect.DeepEqual(e, a) {
+		t.Errorf("Expected original to contain %v, got %v", e, a)
+	}
+	if e, a := New("a", "b", "c"), clone.Set(); !e.Equal(a) {
+		t.Errorf("Expected %v, got %v", e, a)
+	}
+}
+
+func TestCopyOnWriteNil(t *testing.T) {
+	c := NewCopyOnWrite[int](nil)
+	c.Clone().Insert(1)
+	c.Insert(2)
+	if e, a := []int{2}, c.UnsortedList(); !reflect.DeepEqual(e, a) {
+		t.Errorf("Expected %v, got %v", e, a)
+	}
+}
+
+func TestCopyOnWriteConcurrentClones(t *testing.T) {
+	c := NewCopyOnWrite(New(0))
+	var wg sync.WaitGroup
+	for i := 1; i <= 8; i++ {
+		clone := c.Clone()
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				clone.Has(0)
+			}
+			clone.Insert(i)
+			if clone.Len() != 2 || !clone.HasAll(0, i) {
+				t.Errorf("Unexpected contents: %v", clone.SortedList())
+			}
+		}(i)
+	}
+	c.Insert(-1)
+	wg.Wait()
+	if e, a := []int{-1, 0}, c.SortedList(); !reflect.DeepEqual(e, a) {
+		t.Errorf("Expected %v, got %v", e, a)
+	}
+}
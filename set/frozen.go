@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package set
+
+import (
+	"sort"
+)
+
+// Frozen is an immutable set, built once and then only read. It is backed by a sorted slice,
+// which uses less memory than a Set, and lookups are done by binary search. Since it can't be
+// modified, a Frozen can be shared freely, including between goroutines, without copying.
+// The zero value is an empty set.
+type Frozen[E ordered] struct {
+	items []E
+}
+
+// NewFrozen creates a new Frozen set containing items.
+func NewFrozen[E ordered](items ...E) Frozen[E] {
+	sorted := make(sortableSlice[E], len(items))
+	copy(sorted, items)
+	sort.Sort(sorted)
+	// remove duplicates, which are adjacent once sorted
+	unique := sorted[:0]
+	for i, item := range sorted {
+		if i == 0 || item != sorted[i-1] {
+			unique = append(unique, item)
+		}
+	}
+	return Frozen[E]{items: unique[:len(unique):len(unique)]}
+}
+
+// Has returns true if and only if item is contained in the set.
+func (f Frozen[E]) Has(item E) bool {
+	i := sort.Search(len(f.items), func(i int) bool { return f.items[i] >= item })
+	return i < len(f.items) && f.items[i] == item
+}
+
+// HasAll returns true if and only if all items are contained in the set.
+func (f Frozen[E]) HasAll(items ...E) bool {
+	for _, item := range items {
+		if !f.Has(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasAny returns true if any items are contained in the set.
+func (f Frozen[E]) HasAny(items ...E) bool {
+	for _, item := range items {
+		if f.Has(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of elements in the set.
+func (f Frozen[E]) Len() int {
+	return len(f.items)
+}
+
+// Equal returns true if and only if f and f2 contain the same elements.
+func (f Frozen[E]) Equal(f2 Frozen[E]) bool {
+	if len(f.items) != len(f2.items) {
+		return false
+	}
+	for i := range f.items {
+		if f.items[i] != f2.items[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// SortedList returns the contents as a sorted slice. The slice is a copy which the caller may
+// modify.
+func (f Frozen[E]) SortedList() []E {
+	res := make([]E, len(f.items))
+	copy(res, f.items)
+	return res
+}
+
+// Set returns the contents as a new, mutable Set.
+func (f Frozen[E]) Set() Set[E] {
+	result := make(Set[E], len(f.items))
+	result.Insert(f.items...)
+	return result
+}
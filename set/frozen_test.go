@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package set
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFrozen(t *testing.T) {
+	items := []string{"c", "a", "b", "a"}
+	f := NewFrozen(items...)
+	// The frozen set doesn't depend on the slice it was built from.
+	items[0] = "z"
+
+	if f.Len() != 3 {
+		t.Errorf("Expected len=3: %d", f.Len())
+	}
+	for _, item := range []string{"a", "b", "c"} {
+		if !f.Has(item) {
+			t.Errorf("Missing %q in %v", item, f.SortedList())
+		}
+	}
+	for _, item := range []string{"", "0", "aa", "d", "z"} {
+		if f.Has(item) {
+			t.Errorf("Unexpected %q in %v", item, f.SortedList())
+		}
+	}
+	if !f.HasAll("a", "c") || f.HasAll("a", "d") {
+		t.Errorf("Unexpected HasAll result for contents: %v", f.SortedList())
+	}
+	if !f.HasAny("d", "b") || f.HasAny("d", "e") {
+		t.Errorf("Unexpected HasAny result for contents: %v", f.SortedList())
+	}
+	if e, a := []string{"a", "b", "c"}, f.SortedList(); !reflect.DeepEqual(e, a) {
+		t.Errorf("Expected %v, got %v", e, a)
+	}
+	if e, a := New("a", "b", "c"), f.Set(); !e.Equal(a) {
+		t.Errorf("Expected %v, got %v", e, a)
+	}
+
+	// Modifying the returned values doesn't affect the frozen set.
+	list := f.SortedList()
+	list[0] = "z"
+	s := f.Set()
+	s.Insert("d")
+	if f.Has("z") || f.Has("d") || !f.Has("a") {
+		t.Errorf("Frozen set was modified: %v", f.SortedList())
+	}
+}
+
+func TestFrozenEmpty(t *testing.T) {
+	var zero Frozen[int]
+	for _, f := range []Frozen[int]{zero, NewFrozen[int]()} {
+		if f.Len() != 0 || f.Has(0) || f.HasAny(0) || !f.HasAll() {
+			t.Errorf("Expected an empty set, got %v", f.SortedList())
+		}
+		if len(f.SortedList()) != 0 || f.Set().Len() != 0 {
+			t.Errorf("Expected an empty set, got %v", f.SortedList())
+		}
+	}
+	if !zero.Equal(NewFrozen[int]()) {
+		t.Errorf("Expected the zero value to equal an empty set")
+	}
+}
+
+func TestFrozenEqual(t *testing.T) {
+	tests := []struct {
+		name     string
+		f1       Frozen[int]
+		f2       Frozen[int]
+		expected bool
+	}{
+		{name: "same elements", f1: NewFrozen(1, 2, 3), f2: NewFrozen(3, 2, 1, 1), expected: true},
+		{name: "different lengths", f1: NewFrozen(1, 2), f2: NewFrozen(1, 2, 3), expected: false},
+		{name: "different elements", f1: NewFrozen(1, 2), f2: NewFrozen(1, 3), expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if a := tt.f1.Equal(tt.f2); a != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, a)
+			}
+		})
+	}
+}
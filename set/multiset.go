@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package set
+
+// Multiset is a set in which each element may occur more than once, implemented via a map from
+// element to its number of occurrences. Elements with no occurrences are not stored.
+type Multiset[E ordered] map[E]int
+
+// NewMultiset creates a new multiset, counting each occurrence of each item.
+func NewMultiset[E ordered](items ...E) Multiset[E] {
+	m := Multiset[E]{}
+	m.Insert(items...)
+	return m
+}
+
+// Insert adds one occurrence of each item to the multiset.
+func (m Multiset[E]) Insert(items ...E) Multiset[E] {
+	for _, item := range items {
+		m[item]++
+	}
+	return m
+}
+
+// InsertN adds n occurrences of item to the multiset. If n is negative, it removes occurrences
+// instead, as RemoveN.
+func (m Multiset[E]) InsertN(item E, n int) Multiset[E] {
+	if n < 0 {
+		return m.RemoveN(item, -n)
+	}
+	if n > 0 {
+		m[item] += n
+	}
+	return m
+}
+
+// Remove removes one occurrence of each item from the multiset, if there is one.
+func (m Multiset[E]) Remove(items ...E) Multiset[E] {
+	for _, item := range items {
+		m.RemoveN(item, 1)
+	}
+	return m
+}
+
+// RemoveN removes up to n occurrences of item from the multiset.
+func (m Multiset[E]) RemoveN(item E, n int) Multiset[E] {
+	if n <= 0 {
+		return m
+	}
+	if m[item] <= n {
+		delete(m, item)
+	} else {
+		m[item] -= n
+	}
+	return m
+}
+
+// Count returns the number of occurrences of item in the multiset.
+func (m Multiset[E]) Count(item E) int {
+	return m[item]
+}
+
+// Has returns true if and only if item occurs at least once in the multiset.
+func (m Multiset[E]) Has(item E) bool {
+	return m[item] > 0
+}
+
+// Len returns the number of distinct elements in the multiset.
+func (m Multiset[E]) Len() int {
+	return len(m)
+}
+
+// Total returns the total number of occurrences of all elements in the multiset.
+func (m Multiset[E]) Total() int {
+	total := 0
+	for _, count := range m {
+		total += count
+	}
+	return total
+}
+
+// Union returns a new multiset in which each element occurs as many times as it does in whichever
+// of m and m2 has more occurrences of it.
+// For example:
+// m1 = {a, a, b}
+// m2 = {a, b, b, c}
+// m1.Union(m2) = {a, a, b, b, c}
+func (m Multiset[E]) Union(m2 Multiset[E]) Multiset[E] {
+	result := m.Clone()
+	for key, count := range m2 {
+		if count > result[key] {
+			result[key] = count
+		}
+	}
+	return result
+}
+
+// Intersection returns a new multiset in which each element occurs as many times as it does in
+// whichever of m and m2 has fewer occurrences of it.
+// For example:
+// m1 = {a, a, b}
+// m2 = {a, b, b, c}
+// m1.Intersection(m2) = {a, b}
+func (m Multiset[E]) Intersection(m2 Multiset[E]) Multiset[E] {
+	result := Multiset[E]{}
+	for key, count := range m {
+		if count2 := m2[key]; count2 < count {
+			count = count2
+		}
+		if count > 0 {
+			result[key] = count
+		}
+	}
+	return result
+}
+
+// Sum returns a new multiset in which the occurrences of each element in m and m2 are added up.
+// For example:
+// m1 = {a, a, b}
+// m2 = {a, b, b, c}
+// m1.Sum(m2) = {a, a, a, b, b, b, c}
+func (m Multiset[E]) Sum(m2 Multiset[E]) Multiset[E] {
+	result := m.Clone()
+	for key, count := range m2 {
+		result[key] += count
+	}
+	return result
+}
+
+// Equal returns true if and only if every element occurs the same number of times in m and m2.
+func (m Multiset[E]) Equal(m2 Multiset[E]) bool {
+	if len(m) != len(m2) {
+		return false
+	}
+	for key, count := range m {
+		if m2[key] != count {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a new multiset which is a copy of the current multiset.
+func (m Multiset[E]) Clone() Multiset[E] {
+	result := make(Multiset[E], len(m))
+	for key, count := range m {
+		result[key] = count
+	}
+	return result
+}
+
+// Set returns the distinct elements of the multiset as a Set.
+func (m Multiset[E]) Set() Set[E] {
+	result := make(Set[E], len(m))
+	for key := range m {
+		result.Insert(key)
+	}
+	return result
+}
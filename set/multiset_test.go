@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package set
+
+import (
+	"testing"
+)
+
+func TestMultisetCounts(t *testing.T) {
+	m := NewMultiset("a", "b", "a")
+	if m.Count("a") != 2 || m.Count("b") != 1 || m.Count("c") != 0 {
+		t.Errorf("Unexpected counts: %v", m)
+	}
+	if m.Len() != 2 || m.Total() != 3 {
+		t.Errorf("Expected len=2 and total=3: %d, %d", m.Len(), m.Total())
+	}
+	if !m.Has("a") || m.Has("c") {
+		t.Errorf("Unexpected contents: %v", m)
+	}
+
+	m.InsertN("c", 3).InsertN("c", 0)
+	if m.Count("c") != 3 {
+		t.Errorf("Expected count=3: %d", m.Count("c"))
+	}
+	m.InsertN("c", -2)
+	if m.Count("c") != 1 {
+		t.Errorf("Expected count=1: %d", m.Count("c"))
+	}
+
+	m.Remove("a", "c", "d")
+	if !m.Equal(NewMultiset("a", "b")) {
+		t.Errorf("Unexpected contents: %v", m)
+	}
+	if _, ok := m["c"]; ok {
+		t.Errorf("Expected elements with no occurrences not to be stored: %v", m)
+	}
+	m.RemoveN("a", 5).RemoveN("b", 0).RemoveN("b", -1)
+	if !m.Equal(NewMultiset("b")) {
+		t.Errorf("Unexpected contents: %v", m)
+	}
+	if !m.Set().Equal(New("b")) {
+		t.Errorf("Unexpected set: %v", m.Set())
+	}
+}
+
+func TestMultisetOperations(t *testing.T) {
+	m1 := NewMultiset("a", "a", "b")
+	m2 := NewMultiset("a", "b", "b", "c")
+	tests := []struct {
+		name     string
+		result   Multiset[string]
+		expected Multiset[string]
+	}{
+		{name: "union", result: m1.Union(m2), expected: NewMultiset("a", "a", "b", "b", "c")},
+		{name: "union reversed", result: m2.Union(m1), expected: NewMultiset("a", "a", "b", "b", "c")},
+		{name: "intersection", result: m1.Intersection(m2), expected: NewMultiset("a", "b")},
+		{name: "intersection reversed", result: m2.Intersection(m1), expected: NewMultiset("a", "b")},
+		{name: "sum", result: m1.Sum(m2), expected: NewMultiset("a", "a", "a", "b", "b", "b", "c")},
+		{name: "union with empty", result: m1.Union(NewMultiset[string]()), expected: m1},
+		{name: "intersection with empty", result: m1.Intersection(NewMultiset[string]()), expected: NewMultiset[string]()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.result.Equal(tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, tt.result)
+			}
+		})
+	}
+	if !m1.Equal(NewMultiset("a", "a", "b")) || !m2.Equal(NewMultiset("a", "b", "b", "c")) {
+		t.Errorf("Expected operands to be unchanged: %v, %v", m1, m2)
+	}
+}
+
+func TestMultisetEqualAndClone(t *testing.T) {
+	m := NewMultiset(1, 1, 2)
+	c := m.Clone()
+	if !m.Equal(c) {
+		t.Errorf("Expected clone %v to equal %v", c, m)
+	}
+	c.Insert(2)
+	if m.Equal(c) || m.Count(2) != 1 {
+		t.Errorf("Expected clone to be independent: %v, %v", m, c)
+	}
+	if m.Equal(NewMultiset(1, 2, 2)) || m.Equal(NewMultiset(1, 1, 3)) {
+		t.Errorf("Unexpected equality for %v", m)
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package set
+
+// OrderedSet is a set that remembers the order in which its elements were inserted, for example
+// to deduplicate a list of arguments while keeping their original order. Has, Insert and Delete
+// take constant time.
+// The zero value is an empty set ready to use. Not thread safe.
+type OrderedSet[E ordered] struct {
+	index map[E]*orderedSetNode[E]
+	// head and tail are the first and last inserted elements.
+	head, tail *orderedSetNode[E]
+}
+
+type orderedSetNode[E ordered] struct {
+	item       E
+	prev, next *orderedSetNode[E]
+}
+
+// NewOrderedSet creates a new OrderedSet holding items in the given order. Duplicates are kept at
+// the position of their first occurrence.
+func NewOrderedSet[E ordered](items ...E) *OrderedSet[E] {
+	s := &OrderedSet[E]{}
+	s.Insert(items...)
+	return s
+}
+
+// Insert adds items to the end of the set. Items which are already in the set keep their
+// original position.
+func (s *OrderedSet[E]) Insert(items ...E) *OrderedSet[E] {
+	for _, item := range items {
+		if s.Has(item) {
+			continue
+		}
+		if s.index == nil {
+			s.index = map[E]*orderedSetNode[E]{}
+		}
+		node := &orderedSetNode[E]{item: item, prev: s.tail}
+		if s.tail == nil {
+			s.head = node
+		} else {
+			s.tail.next = node
+		}
+		s.tail = node
+		s.index[item] = node
+	}
+	return s
+}
+
+// Delete removes all items from the set.
+func (s *OrderedSet[E]) Delete(items ...E) *OrderedSet[E] {
+	for _, item := range items {
+		node, ok := s.index[item]
+		if !ok {
+			continue
+		}
+		if node.prev == nil {
+			s.head = node.next
+		} else {
+			node.prev.next = node.next
+		}
+		if node.next == nil {
+			s.tail = node.prev
+		} else {
+			node.next.prev = node.prev
+		}
+		delete(s.index, item)
+	}
+	return s
+}
+
+// Has returns true if and only if item is contained in the set.
+func (s *OrderedSet[E]) Has(item E) bool {
+	_, contained := s.index[item]
+	return contained
+}
+
+// HasAll returns true if and only if all items are contained in the set.
+func (s *OrderedSet[E]) HasAll(items ...E) bool {
+	for _, item := range items {
+		if !s.Has(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasAny returns true if any items are contained in the set.
+func (s *OrderedSet[E]) HasAny(items ...E) bool {
+	for _, item := range items {
+		if s.Has(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of elements in the set.
+func (s *OrderedSet[E]) Len() int {
+	return len(s.index)
+}
+
+// Clear empties the set.
+func (s *OrderedSet[E]) Clear() *OrderedSet[E] {
+	s.index = nil
+	s.head = nil
+	s.tail = nil
+	return s
+}
+
+// Clone returns a new set which is a copy of the current set, with the same order.
+func (s *OrderedSet[E]) Clone() *OrderedSet[E] {
+	return NewOrderedSet(s.UnsortedList()...)
+}
+
+// Set returns the contents as an unordered Set.
+func (s *OrderedSet[E]) Set() Set[E] {
+	result := make(Set[E], s.Len())
+	for node := s.head; node != nil; node = node.next {
+		result.Insert(node.item)
+	}
+	return result
+}
+
+// SortedList returns the contents as a sorted slice.
+func (s *OrderedSet[E]) SortedList() []E {
+	return s.Set().SortedList()
+}
+
+// UnsortedList returns the contents as a slice in insertion order.
+func (s *OrderedSet[E]) UnsortedList() []E {
+	res := make([]E, 0, s.Len())
+	for node := s.head; node != nil; node = node.next {
+		res = append(res, node.item)
+	}
+	return res
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package set
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOrderedSetInsertionOrder(t *testing.T) {
+	s := NewOrderedSet("--verbose", "--output=json", "--verbose", "-f")
+	if e, a := []string{"--verbose", "--output=json", "-f"}, s.UnsortedList(); !reflect.DeepEqual(e, a) {
+		t.Errorf("Expected %v, got %v", e, a)
+	}
+	if e, a := []string{"--output=json", "--verbose", "-f"}, s.SortedList(); !reflect.DeepEqual(e, a) {
+		t.Errorf("Expected %v, got %v", e, a)
+	}
+	if s.Len() != 3 {
+		t.Errorf("Expected len=3: %d", s.Len())
+	}
+}
+
+func TestOrderedSetDelete(t *testing.T) {
+	tests := []struct {
+		name     string
+		delete   []int
+		expected []int
+		// expectedReinserted is the contents after inserting the deleted items again
+		expectedReinserted []int
+	}{
+		{name: "none", delete: nil, expected: []int{1, 2, 3, 4}, expectedReinserted: []int{1, 2, 3, 4}},
+		{name: "missing", delete: []int{5}, expected: []int{1, 2, 3, 4}, expectedReinserted: []int{1, 2, 3, 4, 5}},
+		{name: "first", delete: []int{1}, expected: []int{2, 3, 4}, expectedReinserted: []int{2, 3, 4, 1}},
+		{name: "middle", delete: []int{2, 3}, expected: []int{1, 4}, expectedReinserted: []int{1, 4, 2, 3}},
+		{name: "last", delete: []int{4}, expected: []int{1, 2, 3}, expectedReinserted: []int{1, 2, 3, 4}},
+		{name: "all", delete: []int{4, 1, 3, 2}, expected: []int{}, expectedReinserted: []int{4, 1, 3, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewOrderedSet(1, 2, 3, 4)
+			s.Delete(tt.delete...)
+			if a := s.UnsortedList(); !reflect.DeepEqual(tt.expected, a) {
+				t.Errorf("Expected %v, got %v", tt.expected, a)
+			}
+			if s.Len() != len(tt.expected) {
+				t.Errorf("Expected len=%d: %d", len(tt.expected), s.Len())
+			}
+			for _, item := range tt.delete {
+				if s.Has(item) {
+					t.Errorf("Unexpected item %d", item)
+				}
+			}
+			s.Insert(tt.delete...)
+			if a := s.UnsortedList(); !reflect.DeepEqual(tt.expectedReinserted, a) {
+				t.Errorf("Expected %v after reinserting, got %v", tt.expectedReinserted, a)
+			}
+		})
+	}
+}
+
+func TestOrderedSetOperations(t *testing.T) {
+	var s OrderedSet[string]
+	if s.Has("a") || s.Len() != 0 || len(s.UnsortedList()) != 0 {
+		t.Errorf("Expected the zero value to be empty")
+	}
+	s.Insert("b", "a", "c")
+	if !s.HasAll("a", "b") || s.HasAll("a", "d") {
+		t.Errorf("Unexpected HasAll result for contents: %v", s.UnsortedList())
+	}
+	if !s.HasAny("d", "c") || s.HasAny("d", "e") {
+		t.Errorf("Unexpected HasAny result for contents: %v", s.UnsortedList())
+	}
+	if e, a := New("a", "b", "c"), s.Set(); !e.Equal(a) {
+		t.Errorf("Expected %v, got %v", e, a)
+	}
+
+	c := s.Clone()
+	c.Insert("d")
+	s.Delete("b")
+	if e, a := []string{"b", "a", "c", "d"}, c.UnsortedList(); !reflect.DeepEqual(e, a) {
+		t.Errorf("Expected clone to contain %v, got %v", e, a)
+	}
+	if e, a := []string{"a", "c"}, s.UnsortedList(); !reflect.DeepEqual(e, a) {
+		t.Errorf("Expected original to contain %v, got %v", e, a)
+	}
+
+	s.Clear()
+	if s.Len() != 0 || len(s.UnsortedList()) != 0 {
+		t.Errorf("Expected set to be empty after Clear: %v", s.UnsortedList())
+	}
+	s.Insert("z")
+	if e, a := []string{"z"}, s.UnsortedList(); !reflect.DeepEqual(e, a) {
+		t.Errorf("Expected %v, got %v", e, a)
+	}
+}
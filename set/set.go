@@ -17,6 +17,7 @@ limitations under the License.
 package set
 
 import (
+	"encoding/json"
 	"sort"
 )
 
@@ -128,6 +129,10 @@ func (s Set[E]) Intersection(s2 Set[E]) Set[E] {
 
 // IsSuperset returns true if and only if s1 is a superset of s2.
 func (s Set[E]) IsSuperset(s2 Set[E]) bool {
+	if s2.Len() > s.Len() {
+		// a smaller set can't contain every element of a larger one
+		return false
+	}
 	for item := range s2 {
 		if !s.Has(item) {
 			return false
@@ -136,6 +141,31 @@ func (s Set[E]) IsSuperset(s2 Set[E]) bool {
 	return true
 }
 
+// HasAllOf returns true if and only if s is a superset of each of sets.
+func (s Set[E]) HasAllOf(sets ...Set[E]) bool {
+	for _, s2 := range sets {
+		if !s.IsSuperset(s2) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsDisjoint returns true if and only if s1 and s2 have no elements in common. It iterates over the
+// smaller of the two sets.
+func (s Set[E]) IsDisjoint(s2 Set[E]) bool {
+	walk, other := s, s2
+	if walk.Len() > other.Len() {
+		walk, other = other, walk
+	}
+	for item := range walk {
+		if other.Has(item) {
+			return false
+		}
+	}
+	return true
+}
+
 // Difference returns a set of objects that are not in s2
 // For example:
 // s1 = {a1, a2, a3}
@@ -176,6 +206,15 @@ func (s Set[E]) SortedList() []E {
 	return res
 }
 
+// SortedListFunc returns the contents as a slice sorted by less, for callers that need an order
+// other than the natural one, such as by semantic priority. less must be a strict weak ordering;
+// elements it considers equal are returned in unspecified order.
+func (s Set[E]) SortedListFunc(less func(a, b E) bool) []E {
+	res := s.UnsortedList()
+	sort.Slice(res, func(i, j int) bool { return less(res[i], res[j]) })
+	return res
+}
+
 // UnsortedList returns the slice with contents in random order.
 func (s Set[E]) UnsortedList() []E {
 	res := make([]E, 0, len(s))
@@ -195,6 +234,17 @@ func (s Set[E]) PopAny() (E, bool) {
 	return zeroValue, false
 }
 
+// Single returns the only element of the set if it has exactly one element, and false otherwise.
+func (s Set[E]) Single() (E, bool) {
+	if len(s) == 1 {
+		for key := range s {
+			return key, true
+		}
+	}
+	var zeroValue E
+	return zeroValue, false
+}
+
 // Clone returns a new set which is a copy of the current set.
 func (s Set[T]) Clone() Set[T] {
 	result := make(Set[T], len(s))
@@ -211,5 +261,71 @@ func (s Set[T]) Clone() Set[T] {
 // s1.SymmetricDifference(s2) = {a3, a4, a5}
 // s2.SymmetricDifference(s1) = {a3, a4, a5}
 func (s Set[T]) SymmetricDifference(s2 Set[T]) Set[T] {
-	return s.Difference(s2).Union(s2.Difference(s))
+	result := Set[T]{}
+	for key := range s {
+		if !s2.Has(key) {
+			result.Insert(key)
+		}
+	}
+	for key := range s2 {
+		if !s.Has(key) {
+			result.Insert(key)
+		}
+	}
+	return result
+}
+
+// Filter returns a new set with the elements of s for which keep returns true.
+func (s Set[T]) Filter(keep func(T) bool) Set[T] {
+	result := Set[T]{}
+	for key := range s {
+		if keep(key) {
+			result.Insert(key)
+		}
+	}
+	return result
+}
+
+// Any returns true if pred returns true for any element of s. It stops at the first such element.
+func (s Set[T]) Any(pred func(T) bool) bool {
+	for key := range s {
+		if pred(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Map returns a new set with the results of applying f to each element of s. The result may be
+// smaller than s if f maps several elements to the same value.
+func Map[T, U ordered](s Set[T], f func(T) U) Set[U] {
+	result := make(Set[U], len(s))
+	for key := range s {
+		result.Insert(f(key))
+	}
+	return result
+}
+
+// MarshalJSON encodes the set as a JSON array of its elements in sorted order, so that the
+// output is deterministic. A nil set is encoded as null.
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(s.SortedList())
+}
+
+// UnmarshalJSON decodes a JSON array into the set, replacing its previous contents. Duplicate
+// elements are ignored. null decodes to a nil set.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	if items == nil {
+		*s = nil
+		return nil
+	}
+	*s = New(items...)
+	return nil
 }
@@ -0,0 +1,49 @@
+//go:build go1.23
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package set
+
+import (
+	"iter"
+)
+
+// Collect creates a new set from the values of seq, for example from maps.Keys or slices.Values,
+// without building an intermediate slice.
+func Collect[E ordered](seq iter.Seq[E]) Set[E] {
+	return Insert(Set[E]{}, seq)
+}
+
+// Insert adds the values of seq to s and returns s.
+func Insert[E ordered](s Set[E], seq iter.Seq[E]) Set[E] {
+	for item := range seq {
+		s.Insert(item)
+	}
+	return s
+}
+
+// All returns an iterator over the elements of the set, in random order. The set must not be
+// modified during iteration, other than by deleting the element being visited.
+func (s Set[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for key := range s {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
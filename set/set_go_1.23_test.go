@@ -0,0 +1,68 @@
+//go:build go1.23
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package set
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestCollect(t *testing.T) {
+	fromSlice := Collect(slices.Values([]string{"a", "b", "a"}))
+	if !fromSlice.Equal(New("a", "b")) {
+		t.Errorf("Unexpected contents: %v", fromSlice.SortedList())
+	}
+	fromMap := Collect(maps.Keys(map[int]string{1: "one", 2: "two"}))
+	if !fromMap.Equal(New(1, 2)) {
+		t.Errorf("Unexpected contents: %v", fromMap.SortedList())
+	}
+	empty := Collect(slices.Values([]int(nil)))
+	if empty == nil || empty.Len() != 0 {
+		t.Errorf("Expected an empty, non-nil set: %#v", empty)
+	}
+}
+
+func TestInsertSeq(t *testing.T) {
+	s := New(1)
+	if r := Insert(s, slices.Values([]int{2, 3})); !r.Equal(New(1, 2, 3)) || !s.Equal(r) {
+		t.Errorf("Unexpected contents: %v", s.SortedList())
+	}
+}
+
+func TestAll(t *testing.T) {
+	s := New("a", "b", "c")
+	if e, a := s.SortedList(), slices.Sorted(s.All()); !slices.Equal(e, a) {
+		t.Errorf("Expected %v, got %v", e, a)
+	}
+
+	count := 0
+	for range s.All() {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("Expected iteration to stop after break, got %d elements", count)
+	}
+
+	// Round trip through another set.
+	if c := Collect(s.All()); !c.Equal(s) {
+		t.Errorf("Expected %v, got %v", s.SortedList(), c.SortedList())
+	}
+}
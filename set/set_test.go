@@ -17,7 +17,9 @@ limitations under the License.
 package set
 
 import (
+	"encoding/json"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -105,6 +107,22 @@ func TestStringSetSortedList(t *testing.T) {
 	}
 }
 
+func TestSortedListFunc(t *testing.T) {
+	priority := map[string]int{"critical": 0, "high": 1, "medium": 2, "low": 3}
+	s := New("low", "critical", "medium", "high")
+	byPriority := s.SortedListFunc(func(a, b string) bool { return priority[a] < priority[b] })
+	if e, a := []string{"critical", "high", "medium", "low"}, byPriority; !reflect.DeepEqual(e, a) {
+		t.Errorf("Expected %v, got %v", e, a)
+	}
+	descending := New(3, 1, 2).SortedListFunc(func(a, b int) bool { return a > b })
+	if e, a := []int{3, 2, 1}, descending; !reflect.DeepEqual(e, a) {
+		t.Errorf("Expected %v, got %v", e, a)
+	}
+	if empty := New[int]().SortedListFunc(func(a, b int) bool { return a < b }); len(empty) != 0 {
+		t.Errorf("Expected an empty list, got %v", empty)
+	}
+}
+
 func TestStringSetUnsortedList(t *testing.T) {
 	s := New[string]("z", "y", "x", "a")
 	ul := s.UnsortedList()
@@ -323,6 +341,30 @@ func TestSetSymmetricDifference(t *testing.T) {
 	}
 }
 
+func TestSetSymmetricDifferenceEdgeCases(t *testing.T) {
+	tests := []struct {
+		name     string
+		s1       Set[int]
+		s2       Set[int]
+		expected Set[int]
+	}{
+		{name: "both empty", s1: New[int](), s2: New[int](), expected: New[int]()},
+		{name: "one empty", s1: New(1, 2), s2: New[int](), expected: New(1, 2)},
+		{name: "equal", s1: New(1, 2), s2: New(1, 2), expected: New[int]()},
+		{name: "disjoint", s1: New(1, 2), s2: New(3), expected: New(1, 2, 3)},
+		{name: "subset", s1: New(1, 2, 3), s2: New(2), expected: New(1, 3)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, result := range []Set[int]{tt.s1.SymmetricDifference(tt.s2), tt.s2.SymmetricDifference(tt.s1)} {
+				if !result.Equal(tt.expected) {
+					t.Errorf("Expected %v, got %v", tt.expected.SortedList(), result.SortedList())
+				}
+			}
+		})
+	}
+}
+
 func TestSetClear(t *testing.T) {
 	s := New[string]()
 	s.Insert("a", "b", "c")
@@ -393,6 +435,28 @@ func TestPopAny(t *testing.T) {
 	}
 }
 
+func TestSingle(t *testing.T) {
+	tests := []struct {
+		name       string
+		set        Set[string]
+		expected   string
+		expectedOK bool
+	}{
+		{name: "nil", set: nil},
+		{name: "empty", set: New[string]()},
+		{name: "one", set: New("a"), expected: "a", expectedOK: true},
+		{name: "two", set: New("a", "b")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, ok := tt.set.Single()
+			if item != tt.expected || ok != tt.expectedOK {
+				t.Errorf("Expected %q, %v; got %q, %v", tt.expected, tt.expectedOK, item, ok)
+			}
+		})
+	}
+}
+
 func TestClone(t *testing.T) {
 	a := New[string]("1", "2")
 	a.Insert("3")
@@ -402,3 +466,183 @@ func TestClone(t *testing.T) {
 		t.Errorf("Expected to be equal: %v vs %v", got, a)
 	}
 }
+
+func TestSetMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		set      Set[string]
+		expected string
+	}{
+		{name: "nil", set: nil, expected: `null`},
+		{name: "empty", set: New[string](), expected: `[]`},
+		{name: "sorted", set: New("c", "a", "b"), expected: `["a","b","c"]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.set)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if string(data) != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, data)
+			}
+		})
+	}
+}
+
+func TestSetUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		expected    Set[int]
+		expectedErr bool
+	}{
+		{name: "null", data: `null`, expected: nil},
+		{name: "empty", data: `[]`, expected: New[int]()},
+		{name: "duplicates", data: `[3,1,3,2]`, expected: New(1, 2, 3)},
+		{name: "wrong type", data: `["a"]`, expectedErr: true},
+		{name: "not an array", data: `{}`, expectedErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(10)
+			err := json.Unmarshal([]byte(tt.data), &s)
+			if tt.expectedErr {
+				if err == nil {
+					t.Errorf("Expected error, got %v", s)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(s, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, s)
+			}
+		})
+	}
+}
+
+func TestSetJSONInStruct(t *testing.T) {
+	type config struct {
+		Features Set[string] `json:"features"`
+	}
+	in := config{Features: New("b", "a")}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if e, a := `{"features":["a","b"]}`, string(data); e != a {
+		t.Errorf("Expected %s, got %s", e, a)
+	}
+	var out config
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !out.Features.Equal(in.Features) {
+		t.Errorf("Expected %v, got %v", in.Features, out.Features)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	s := New(1, 2, 3, 4, 5)
+	even := s.Filter(func(i int) bool { return i%2 == 0 })
+	if !even.Equal(New(2, 4)) {
+		t.Errorf("Unexpected contents: %v", even.SortedList())
+	}
+	if none := s.Filter(func(int) bool { return false }); none.Len() != 0 {
+		t.Errorf("Unexpected contents: %v", none.SortedList())
+	}
+	if !s.Equal(New(1, 2, 3, 4, 5)) {
+		t.Errorf("Expected original set to be unchanged: %v", s.SortedList())
+	}
+	if empty := New[int]().Filter(func(int) bool { return true }); empty == nil || empty.Len() != 0 {
+		t.Errorf("Expected an empty, non-nil set: %#v", empty)
+	}
+}
+
+func TestAny(t *testing.T) {
+	s := New("a", "bb", "ccc")
+	if !s.Any(func(s string) bool { return len(s) == 2 }) {
+		t.Errorf("Expected an element of length 2 in %v", s.SortedList())
+	}
+	if s.Any(func(s string) bool { return len(s) > 3 }) {
+		t.Errorf("Expected no element longer than 3 in %v", s.SortedList())
+	}
+	if New[string]().Any(func(string) bool { return true }) {
+		t.Errorf("Expected false for an empty set")
+	}
+}
+
+func TestMap(t *testing.T) {
+	s := New("a", "B", "b")
+	lower := Map(s, strings.ToLower)
+	if !lower.Equal(New("a", "b")) {
+		t.Errorf("Unexpected contents: %v", lower.SortedList())
+	}
+	lengths := Map(New("a", "bb", "cc"), func(s string) int { return len(s) })
+	if !lengths.Equal(New(1, 2)) {
+		t.Errorf("Unexpected contents: %v", lengths.SortedList())
+	}
+}
+
+func TestIsSuperset(t *testing.T) {
+	tests := []struct {
+		name     string
+		s1       Set[int]
+		s2       Set[int]
+		expected bool
+	}{
+		{name: "both empty", s1: New[int](), s2: New[int](), expected: true},
+		{name: "empty subset", s1: New(1), s2: New[int](), expected: true},
+		{name: "equal", s1: New(1, 2), s2: New(1, 2), expected: true},
+		{name: "proper superset", s1: New(1, 2, 3), s2: New(1, 3), expected: true},
+		{name: "larger subset", s1: New(1, 2), s2: New(1, 2, 3), expected: false},
+		{name: "same size, different", s1: New(1, 2), s2: New(1, 3), expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if a := tt.s1.IsSuperset(tt.s2); a != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, a)
+			}
+		})
+	}
+}
+
+func TestHasAllOf(t *testing.T) {
+	s := New(1, 2, 3, 4)
+	if !s.HasAllOf() {
+		t.Errorf("Expected true with no sets")
+	}
+	if !s.HasAllOf(New(1, 2), New(3), New[int]()) {
+		t.Errorf("Expected %v to contain all of the sets", s.SortedList())
+	}
+	if s.HasAllOf(New(1, 2), New(5)) {
+		t.Errorf("Expected %v not to contain 5", s.SortedList())
+	}
+}
+
+func TestIsDisjoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		s1       Set[string]
+		s2       Set[string]
+		expected bool
+	}{
+		{name: "both empty", s1: New[string](), s2: New[string](), expected: true},
+		{name: "one empty", s1: New("a"), s2: New[string](), expected: true},
+		{name: "disjoint", s1: New("a", "b"), s2: New("c", "d", "e"), expected: true},
+		{name: "one in common", s1: New("a", "b"), s2: New("b", "c", "d"), expected: false},
+		{name: "equal", s1: New("a"), s2: New("a"), expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if a := tt.s1.IsDisjoint(tt.s2); a != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, a)
+			}
+			if a := tt.s2.IsDisjoint(tt.s1); a != tt.expected {
+				t.Errorf("Expected %v when reversed, got %v", tt.expected, a)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package set
+
+import (
+	"math"
+	"reflect"
+	"sync"
+)
+
+// syncSetShards is the number of shards in a SyncSet.
+const syncSetShards = 32
+
+// SyncSet is a set that is safe for concurrent use. Its elements are spread over a number of
+// shards, each guarded by its own lock, so that goroutines working on different elements rarely
+// contend. Operations on multiple elements, such as HasAll and UnsortedList, are not atomic with
+// respect to concurrent changes.
+// The zero value is an empty set ready to use. A SyncSet must not be copied after first use.
+type SyncSet[E ordered] struct {
+	shards [syncSetShards]syncSetShard[E]
+}
+
+type syncSetShard[E ordered] struct {
+	lock  sync.RWMutex
+	items Set[E]
+	// pad shards to a typical cache line size of 64 bytes, so that locking
+	// one shard doesn't slow down access to its neighbours
+	_ [32]byte
+}
+
+// NewSyncSet creates a new SyncSet.
+func NewSyncSet[E ordered](items ...E) *SyncSet[E] {
+	s := &SyncSet[E]{}
+	s.Insert(items...)
+	return s
+}
+
+func (s *SyncSet[E]) shard(item E) *syncSetShard[E] {
+	return &s.shards[shardOf(item)%syncSetShards]
+}
+
+// hashOrdered returns a hash of item. It is used to pick the shard of an item before Go 1.24,
+// which lacks maphash.Comparable. Equal items, including 0 and -0, have equal hashes.
+func hashOrdered[E ordered](item E) uint64 {
+	if s, ok := any(item).(string); ok {
+		return hashString(s)
+	}
+	v := reflect.ValueOf(item)
+	switch v.Kind() {
+	case reflect.String:
+		return hashString(v.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return mix64(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return mix64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if f == 0 {
+			// -0 == 0, so they must hash the same
+			f = 0
+		}
+		return mix64(math.Float64bits(f))
+	default:
+		return 0
+	}
+}
+
+// hashString returns the FNV-1a hash of s, mixed so that its low bits are usable as a shard index.
+func hashString(s string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= 1099511628211
+	}
+	return mix64(h)
+}
+
+// mix64 is the splitmix64 finalizer, which spreads every bit of x over the whole result.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// Insert adds items to the set.
+func (s *SyncSet[E]) Insert(items ...E) *SyncSet[E] {
+	for _, item := range items {
+		shard := s.shard(item)
+		shard.lock.Lock()
+		if shard.items == nil {
+			shard.items = Set[E]{}
+		}
+		shard.items.Insert(item)
+		shard.lock.Unlock()
+	}
+	return s
+}
+
+// Delete removes all items from the set.
+func (s *SyncSet[E]) Delete(items ...E) *SyncSet[E] {
+	for _, item := range items {
+		shard := s.shard(item)
+		shard.lock.Lock()
+		shard.items.Delete(item)
+		shard.lock.Unlock()
+	}
+	return s
+}
+
+// Has returns true if and only if item is contained in the set.
+func (s *SyncSet[E]) Has(item E) bool {
+	shard := s.shard(item)
+	shard.lock.RLock()
+	defer shard.lock.RUnlock()
+	return shard.items.Has(item)
+}
+
+// HasAll returns true if and only if all items are contained in the set.
+func (s *SyncSet[E]) HasAll(items ...E) bool {
+	for _, item := range items {
+		if !s.Has(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasAny returns true if any items are contained in the set.
+func (s *SyncSet[E]) HasAny(items ...E) bool {
+	for _, item := range items {
+		if s.Has(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of elements in the set.
+func (s *SyncSet[E]) Len() int {
+	n := 0
+	for i := range s.shards {
+		s.shards[i].lock.RLock()
+		n += s.shards[i].items.Len()
+		s.shards[i].lock.RUnlock()
+	}
+	return n
+}
+
+// Clear empties the set.
+func (s *SyncSet[E]) Clear() *SyncSet[E] {
+	for i := range s.shards {
+		s.shards[i].lock.Lock()
+		s.shards[i].items = nil
+		s.shards[i].lock.Unlock()
+	}
+	return s
+}
+
+// Set returns a copy of the contents as a Set.
+func (s *SyncSet[E]) Set() Set[E] {
+	result := Set[E]{}
+	for i := range s.shards {
+		s.shards[i].lock.RLock()
+		for key := range s.shards[i].items {
+			result.Insert(key)
+		}
+		s.shards[i].lock.RUnlock()
+	}
+	return result
+}
+
+// SortedList returns the contents as a sorted slice.
+func (s *SyncSet[E]) SortedList() []E {
+	return s.Set().SortedList()
+}
+
+// UnsortedList returns the slice with contents in random order.
+func (s *SyncSet[E]) UnsortedList() []E {
+	var res []E
+	for i := range s.shards {
+		s.shards[i].lock.RLock()
+		for key := range s.shards[i].items {
+			res = append(res, key)
+		}
+		s.shards[i].lock.RUnlock()
+	}
+	return res
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package set
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestSyncSet(t *testing.T) {
+	s := NewSyncSet("a", "b")
+	if s.Len() != 2 {
+		t.Errorf("Expected len=2: %d", s.Len())
+	}
+	s.Insert("c")
+	if s.Has("d") {
+		t.Errorf("Unexpected contents: %v", s.SortedList())
+	}
+	if !s.HasAll("a", "b", "c") {
+		t.Errorf("Missing contents: %v", s.SortedList())
+	}
+	if s.HasAll("a", "d") {
+		t.Errorf("Unexpected contents: %v", s.SortedList())
+	}
+	if !s.HasAny("d", "a") || s.HasAny("d", "e") {
+		t.Errorf("Unexpected HasAny result for contents: %v", s.SortedList())
+	}
+	s.Delete("a", "d")
+	if s.Has("a") {
+		t.Errorf("Unexpected contents: %v", s.SortedList())
+	}
+	if e, a := []string{"b", "c"}, s.SortedList(); !reflect.DeepEqual(e, a) {
+		t.Errorf("Expected %v, got %v", e, a)
+	}
+	if e, a := New("b", "c"), New(s.UnsortedList()...); !e.Equal(a) {
+		t.Errorf("Expected %v, got %v", e, a)
+	}
+	if e, a := New("b", "c"), s.Set(); !e.Equal(a) {
+		t.Errorf("Expected %v, got %v", e, a)
+	}
+	s.Clear()
+	if s.Len() != 0 {
+		t.Errorf("Expected len=0: %d", s.Len())
+	}
+}
+
+func TestSyncSetZeroValue(t *testing.T) {
+	var s SyncSet[int]
+	if s.Has(1) || s.Len() != 0 {
+		t.Errorf("Expected an empty set")
+	}
+	s.Delete(1)
+	s.Insert(1)
+	if !s.Has(1) {
+		t.Errorf("Missing contents: %v", s.SortedList())
+	}
+}
+
+func TestSyncSetConcurrent(t *testing.T) {
+	const goroutines = 8
+	const items = 1000
+	s := NewSyncSet[int]()
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < items; i++ {
+				item := g*items + i
+				s.Insert(item)
+				if !s.Has(item) {
+					t.Errorf("Missing item %d", item)
+				}
+				if i%2 == 1 {
+					s.Delete(item)
+				}
+				s.Len()
+			}
+		}(g)
+	}
+	wg.Wait()
+	if e, a := goroutines*items/2, s.Len(); e != a {
+		t.Errorf("Expected len=%d: %d", e, a)
+	}
+	for _, item := range s.UnsortedList() {
+		if item%2 != 0 {
+			t.Errorf("Unexpected item %d", item)
+		}
+	}
+}
+
+type namedString string
+
+func TestHashOrdered(t *testing.T) {
+	if hashOrdered("abc") != hashOrdered(namedString("abc")) {
+		t.Errorf("Expected named and unnamed strings to hash the same")
+	}
+	if hashOrdered(0.0) != hashOrdered(math.Copysign(0, -1)) {
+		t.Errorf("Expected 0 and -0 to hash the same")
+	}
+	if hashOrdered(int8(-1)) != hashOrdered(int64(-1)) {
+		t.Errorf("Expected equal integers to hash the same regardless of size")
+	}
+
+	// Sequential items must be spread over every shard, so that SyncSet is
+	// sharded on toolchains that use hashOrdered.
+	intShards := make(map[uint64]bool)
+	stringShards := make(map[uint64]bool)
+	floatShards := make(map[uint64]bool)
+	for i := 0; i < 1000; i++ {
+		intShards[hashOrdered(uint16(i))%syncSetShards] = true
+		stringShards[hashOrdered(strconv.Itoa(i))%syncSetShards] = true
+		floatShards[hashOrdered(float32(i))%syncSetShards] = true
+	}
+	for name, shards := range map[string]map[uint64]bool{"int": intShards, "string": stringShards, "float": floatShards} {
+		if len(shards) != syncSetShards {
+			t.Errorf("Expected %s items to use all %d shards, got %d", name, syncSetShards, len(shards))
+		}
+	}
+}
+
+// mutexSet is a Set guarded by a single lock, for comparison with SyncSet.
+type mutexSet[E ordered] struct {
+	lock sync.RWMutex
+	set  Set[E]
+}
+
+func (s *mutexSet[E]) Insert(item E) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.set.Insert(item)
+}
+
+func (s *mutexSet[E]) Has(item E) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.Has(item)
+}
+
+func benchmarkItems(n int) []string {
+	items := make([]string, n)
+	for i := range items {
+		items[i] = "item-" + strconv.Itoa(i)
+	}
+	return items
+}
+
+func BenchmarkSyncSetInsertHas(b *testing.B) {
+	items := benchmarkItems(1024)
+	s := NewSyncSet[string]()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			item := items[i%len(items)]
+			if i%4 == 0 {
+				s.Insert(item)
+			} else {
+				s.Has(item)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkMutexSetInsertHas(b *testing.B) {
+	items := benchmarkItems(1024)
+	s := &mutexSet[string]{set: New[string]()}
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			item := items[i%len(items)]
+			if i%4 == 0 {
+				s.Insert(item)
+			} else {
+				s.Has(item)
+			}
+			i++
+		}
+	})
+}
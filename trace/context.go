@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+)
+
+// ObserveContext associates ctx with the trace. Each time a step is recorded and when the
+// trace is logged, ctx is checked, and the first time it is found to be cancelled or past its
+// deadline, the error and the step at which it was noticed are recorded and included in the
+// output. This shows why a slow request was aborted. It returns the trace to allow chaining
+// with New.
+func (t *Trace) ObserveContext(ctx context.Context) *Trace {
+	if t.unsampled {
+		return t
+	}
+	t.lock.Lock()
+	t.ctx = ctx
+	t.lock.Unlock()
+	return t
+}
+
+// checkContext records the error of the observed context, if any, as having been noticed at
+// the given step, or at the end of the trace if step is empty. It must be called with t.lock
+// held.
+func (t *Trace) checkContext(step string) {
+	if t.ctx == nil || t.ctxErr != nil {
+		return
+	}
+	if err := t.ctx.Err(); err != nil {
+		t.ctxErr = err
+		t.ctxErrStep = step
+	}
+}
+
+// contextErrString describes the error of the observed context, or returns an empty string
+// if there is none. It must be called with t.lock held for reading.
+func (t *Trace) contextErrString() string {
+	if t.ctxErr == nil {
+		return ""
+	}
+	if t.ctxErrStep == "" {
+		return fmt.Sprintf("%v by end of trace", t.ctxErr)
+	}
+	return fmt.Sprintf("%v by step %q", t.ctxErr, t.ctxErrStep)
+}
+
+// writeContextErr writes the error of the observed context, if any, on its own line
+// prefixed by formatter. It must be called with t.lock held for reading.
+func (t *Trace) writeContextErr(b *bytes.Buffer, formatter string) {
+	if s := t.contextErrString(); s != "" {
+		b.WriteString(fmt.Sprintf("%s---context error: %s", formatter, s))
+	}
+}
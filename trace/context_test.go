@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestObserveContext(t *testing.T) {
+	tests := []struct {
+		name           string
+		run            func(trace *Trace, cancel context.CancelFunc)
+		expectedOutput string
+	}{
+		{
+			name: "Context not cancelled",
+			run: func(trace *Trace, cancel context.CancelFunc) {
+				trace.Step("step1")
+			},
+		},
+		{
+			name: "Cancelled before a step",
+			run: func(trace *Trace, cancel context.CancelFunc) {
+				trace.Step("step1")
+				cancel()
+				trace.Step("step2")
+				trace.Step("step3")
+			},
+			expectedOutput: `---context error: context canceled by step "step2"`,
+		},
+		{
+			name: "Cancelled after the last step",
+			run: func(trace *Trace, cancel context.CancelFunc) {
+				trace.Step("step1")
+				cancel()
+			},
+			expectedOutput: `---context error: context canceled by end of trace`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			sink := &bufferSink{verbosity: 2}
+			trace := New("context test").WithSink(sink).ObserveContext(ctx)
+			tt.run(trace, cancel)
+			trace.Log()
+
+			if len(sink.msgs) != 1 {
+				t.Fatalf("expected 1 message, got %v", sink.msgs)
+			}
+			if tt.expectedOutput == "" {
+				if strings.Contains(sink.msgs[0], "context error") {
+					t.Errorf("expected no context error, got:\n%s", sink.msgs[0])
+				}
+				return
+			}
+			if !strings.Contains(sink.msgs[0], tt.expectedOutput) {
+				t.Errorf("expected output to contain %q, got:\n%s", tt.expectedOutput, sink.msgs[0])
+			}
+		})
+	}
+}
+
+func TestObserveContextDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	sink := &bufferSink{verbosity: 2}
+	trace := New("deadline test").WithSink(sink)
+	nested := trace.Nest("nested").ObserveContext(ctx)
+	<-ctx.Done()
+	nested.Step("slow step")
+	nested.Log()
+	trace.Log()
+
+	expected := `---context error: context deadline exceeded by step "slow step"`
+	if len(sink.msgs) != 1 || !strings.Contains(sink.msgs[0], expected) {
+		t.Errorf("expected output to contain %q, got %v", expected, sink.msgs)
+	}
+
+	out, err := json.Marshal(trace)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedJSON := `"contextError":"context deadline exceeded by step \"slow step\""`
+	if !strings.Contains(string(out), expectedJSON) {
+		t.Errorf("expected JSON to contain %s, got %s", expectedJSON, out)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// jsonItem is the JSON representation of a trace or one of its steps.
+type jsonItem struct {
+	Name   string            `json:"name"`
+	Fields map[string]string `json:"fields,omitempty"`
+	Start  time.Time         `json:"start"`
+	// DurationMs is omitted for traces that have not been completed.
+	DurationMs *float64 `json:"durationMs,omitempty"`
+	// ContextError describes the error of the context observed with
+	// ObserveContext, if there was one.
+	ContextError string `json:"contextError,omitempty"`
+	// Error is the error recorded on the trace, if any.
+	Error string `json:"error,omitempty"`
+	// DroppedSteps is the number of steps dropped due to WithMaxSteps.
+	DroppedSteps int        `json:"droppedSteps,omitempty"`
+	Steps        []jsonItem `json:"steps,omitempty"`
+}
+
+func jsonFields(fields []Field) map[string]string {
+	if len(fields) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(fields))
+	for _, f := range fields {
+		m[f.Key] = fmt.Sprintf("%v", f.Value)
+	}
+	return m
+}
+
+func jsonDuration(d time.Duration) *float64 {
+	ms := float64(d) / float64(time.Millisecond)
+	return &ms
+}
+
+// toJSONItem must be called with t.lock held for reading.
+func (t *Trace) toJSONItem() jsonItem {
+	item := jsonItem{
+		Name:         t.name,
+		Fields:       jsonFields(t.fields),
+		Start:        t.startTime,
+		ContextError: t.contextErrString(),
+		DroppedSteps: t.droppedSteps,
+	}
+	if t.err != nil {
+		item.Error = t.err.Error()
+	}
+	if t.endTime != nil {
+		item.DurationMs = jsonDuration(t.endTime.Sub(t.startTime))
+	}
+	lastStepTime := t.startTime
+	for _, stepOrTrace := range t.traceItems {
+		stepOrTrace.rLock()
+		switch s := stepOrTrace.(type) {
+		case traceStep:
+			item.Steps = append(item.Steps, jsonItem{
+				Name:       s.msg,
+				Fields:     jsonFields(s.fields),
+				Start:      s.start(lastStepTime),
+				DurationMs: jsonDuration(s.stepTime.Sub(s.start(lastStepTime))),
+			})
+		case *Trace:
+			item.Steps = append(item.Steps, s.toJSONItem())
+		}
+		lastStepTime = stepOrTrace.time()
+		stepOrTrace.rUnlock()
+	}
+	return item
+}
+
+// MarshalJSON returns the trace as a JSON object holding its name, fields,
+// start time and duration, along with all of its steps and nested traces in
+// the order they were recorded. Unlike Log and LogIfLong, no thresholds are
+// applied, so every step is included. Field values are formatted as strings
+// in the same way as in the log output.
+func (t *Trace) MarshalJSON() ([]byte, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return json.Marshal(t.toJSONItem())
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	end := start.Add(300 * time.Millisecond)
+	nestedEnd := start.Add(150 * time.Millisecond)
+	threshold := time.Hour
+	trace := &Trace{
+		name:      "root",
+		fields:    []Field{{Key: "a", Value: 1}},
+		startTime: start,
+		endTime:   &end,
+		threshold: &threshold,
+	}
+	trace.traceItems = []traceItem{
+		traceStep{stepTime: start.Add(100 * time.Millisecond), msg: "step1", fields: []Field{{Key: "b", Value: "x"}}},
+		&Trace{
+			name:        "nested",
+			startTime:   start.Add(100 * time.Millisecond),
+			endTime:     &nestedEnd,
+			parentTrace: trace,
+			traceItems: []traceItem{
+				traceStep{stepTime: start.Add(120 * time.Millisecond), msg: "inner"},
+			},
+		},
+		traceStep{stepTime: end, msg: "step2"},
+		&Trace{name: "incomplete", startTime: end, parentTrace: trace},
+	}
+
+	got, err := json.Marshal(trace)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"name":"root","fields":{"a":"1"},"start":"2026-01-02T03:04:05Z","durationMs":300,"steps":[` +
+		`{"name":"step1","fields":{"b":"x"},"start":"2026-01-02T03:04:05Z","durationMs":100},` +
+		`{"name":"nested","start":"2026-01-02T03:04:05.1Z","durationMs":50,"steps":[` +
+		`{"name":"inner","start":"2026-01-02T03:04:05.1Z","durationMs":20}]},` +
+		`{"name":"step2","start":"2026-01-02T03:04:05.15Z","durationMs":150},` +
+		`{"name":"incomplete","start":"2026-01-02T03:04:05.3Z"}]}`
+	if string(got) != expected {
+		t.Errorf("expected JSON:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestMarshalJSONEmpty(t *testing.T) {
+	got, err := New("empty").MarshalJSON()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(got, &decoded); err != nil {
+		t.Fatalf("invalid JSON %s: %v", got, err)
+	}
+	if decoded["name"] != "empty" {
+		t.Errorf("expected name %q, got %v", "empty", decoded["name"])
+	}
+	if _, ok := decoded["steps"]; ok {
+		t.Errorf("expected no steps, got %s", got)
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"github.com/go-logr/logr"
+)
+
+// logrSink is a Sink that writes to a logr.Logger.
+type logrSink struct {
+	logger logr.Logger
+}
+
+func (s logrSink) Enabled(level int) bool {
+	return s.logger.V(level).Enabled()
+}
+
+func (s logrSink) Write(msg string) {
+	s.logger.Info(msg)
+}
+
+// WithLogger makes the trace write to logger instead of the global klog state, so that traces
+// created inside components with contextual loggers keep the logger's name and values, such as
+// a request ID. Verbosity is checked with logger.V. It is shorthand for WithSink with a Sink
+// backed by logger, and returns the trace to allow chaining with New.
+func (t *Trace) WithLogger(logger logr.Logger) *Trace {
+	return t.WithSink(logrSink{logger: logger})
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/funcr"
+	"k8s.io/klog/v2"
+)
+
+func TestWithLogger(t *testing.T) {
+	tests := []struct {
+		name              string
+		verbosity         int
+		expectedLines     int
+		expectedToContain []string
+		notExpected       []string
+	}{
+		{
+			name:          "Logger below verbosity 2",
+			verbosity:     1,
+			expectedLines: 0,
+		},
+		{
+			name:              "Logger at verbosity 2",
+			verbosity:         2,
+			expectedLines:     1,
+			expectedToContain: []string{`"logger":"component"`, `"requestID":"abc"`, `\"logr test\"`, `\"slow step\"`},
+			notExpected:       []string{`\"fast step\"`},
+		},
+		{
+			name:              "Logger at verbosity 4",
+			verbosity:         4,
+			expectedLines:     1,
+			expectedToContain: []string{`\"slow step\"`, `\"fast step\"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var klogBuf bytes.Buffer
+			klog.SetOutput(&klogBuf)
+
+			var lines []string
+			logger := funcr.NewJSON(func(obj string) {
+				lines = append(lines, obj)
+			}, funcr.Options{Verbosity: tt.verbosity})
+			logger = logger.WithName("component").WithValues("requestID", "abc")
+
+			trace := New("logr test").WithLogger(logger)
+			trace.Step("fast step")
+			time.Sleep(20 * time.Millisecond)
+			trace.Step("slow step")
+			trace.LogIfLong(10 * time.Millisecond)
+
+			if len(lines) != tt.expectedLines {
+				t.Fatalf("expected %d lines, got %d: %v", tt.expectedLines, len(lines), lines)
+			}
+			for _, s := range tt.expectedToContain {
+				if !strings.Contains(lines[0], s) {
+					t.Errorf("expected output to contain %s, got:\n%s", s, lines[0])
+				}
+			}
+			for _, s := range tt.notExpected {
+				if strings.Contains(lines[0], s) {
+					t.Errorf("expected output not to contain %s, got:\n%s", s, lines[0])
+				}
+			}
+			if klogBuf.Len() != 0 {
+				t.Errorf("expected nothing to be written to klog, got:\n%s", klogBuf.String())
+			}
+		})
+	}
+}
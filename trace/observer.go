@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"time"
+)
+
+// Observer is notified of the duration of every step of a trace when it is
+// logged, for example to feed the durations into histograms. Unlike the log
+// output, observations are not subject to thresholds or verbosity.
+type Observer interface {
+	// ObserveStep is called for each step of a trace, with the name of the
+	// trace the step belongs to. A completed nested trace is observed as a
+	// step of its parent, with the nested trace's name as the message, in
+	// addition to its own steps being observed.
+	ObserveStep(traceName, stepMsg string, duration time.Duration)
+}
+
+// ObserverFunc is an adapter to allow the use of an ordinary function as an
+// Observer.
+type ObserverFunc func(traceName, stepMsg string, duration time.Duration)
+
+// ObserveStep calls f(traceName, stepMsg, duration).
+func (f ObserverFunc) ObserveStep(traceName, stepMsg string, duration time.Duration) {
+	f(traceName, stepMsg, duration)
+}
+
+// WithObserver sets the Observer that is notified of step durations when the
+// trace is logged with Log or LogIfLong. As with the log output, only the
+// observer of the top level trace is used, and it is also notified of the
+// steps of nested traces. It returns the trace to allow chaining with New.
+func (t *Trace) WithObserver(observer Observer) *Trace {
+	if t.unsampled {
+		return t
+	}
+	t.lock.Lock()
+	t.observer = observer
+	t.lock.Unlock()
+	return t
+}
+
+type stepObservation struct {
+	traceName string
+	stepMsg   string
+	duration  time.Duration
+}
+
+// collectObservations appends the durations of the steps of t and its nested
+// traces to observations. It must be called with t.lock held for reading.
+func (t *Trace) collectObservations(observations []stepObservation) []stepObservation {
+	lastStepTime := t.startTime
+	for _, stepOrTrace := range t.traceItems {
+		stepOrTrace.rLock()
+		switch s := stepOrTrace.(type) {
+		case traceStep:
+			observations = append(observations, stepObservation{t.name, s.msg, s.stepTime.Sub(s.start(lastStepTime))})
+		case *Trace:
+			if s.endTime != nil {
+				observations = append(observations, stepObservation{t.name, s.name, s.endTime.Sub(s.startTime)})
+			}
+			observations = s.collectObservations(observations)
+		}
+		lastStepTime = stepOrTrace.time()
+		stepOrTrace.rUnlock()
+	}
+	return observations
+}
+
+// observe notifies observer of the durations of all steps in t.
+func (t *Trace) observe(observer Observer) {
+	t.lock.RLock()
+	observations := t.collectObservations(nil)
+	t.lock.RUnlock()
+	for _, o := range observations {
+		observer.ObserveStep(o.traceName, o.stepMsg, o.duration)
+	}
+}
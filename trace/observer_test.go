@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestObserver(t *testing.T) {
+	start := time.Now()
+	end := start.Add(300 * time.Millisecond)
+	nestedEnd := start.Add(150 * time.Millisecond)
+	threshold := time.Hour
+	trace := &Trace{name: "root", startTime: start}
+	trace.traceItems = []traceItem{
+		traceStep{stepTime: start.Add(100 * time.Millisecond), msg: "step1"},
+		&Trace{
+			name:        "nested",
+			startTime:   start.Add(100 * time.Millisecond),
+			endTime:     &nestedEnd,
+			parentTrace: trace,
+			traceItems: []traceItem{
+				traceStep{stepTime: start.Add(120 * time.Millisecond), msg: "inner"},
+			},
+		},
+		traceStep{stepTime: end, msg: "step2"},
+	}
+
+	var got []stepObservation
+	trace.WithObserver(ObserverFunc(func(traceName, stepMsg string, duration time.Duration) {
+		got = append(got, stepObservation{traceName, stepMsg, duration})
+	}))
+	// The observer is notified even though the trace is under its threshold
+	// and is not written out.
+	trace.LogIfLong(threshold)
+
+	expected := []stepObservation{
+		{"root", "step1", 100 * time.Millisecond},
+		{"root", "nested", 50 * time.Millisecond},
+		{"nested", "inner", 20 * time.Millisecond},
+		{"root", "step2", 150 * time.Millisecond},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected observations %v, got %v", expected, got)
+	}
+}
+
+func TestObserverNestedLog(t *testing.T) {
+	calls := 0
+	trace := New("root").WithObserver(ObserverFunc(func(string, string, time.Duration) {
+		calls++
+	}))
+	nested := trace.Nest("nested")
+	nested.Step("step")
+	nested.Log()
+	if calls != 0 {
+		t.Errorf("expected no observations before the top level trace is logged, got %d", calls)
+	}
+	trace.Log()
+	if calls != 2 {
+		t.Errorf("expected 2 observations, got %d", calls)
+	}
+}
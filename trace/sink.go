@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"sync/atomic"
+
+	"k8s.io/klog/v2"
+)
+
+// Sink is the destination for the output of Log and LogIfLong.
+type Sink interface {
+	// Enabled returns true if output at the given verbosity level should be
+	// written. Traces are written at level 2, and at level 4 all of their steps
+	// are written regardless of thresholds.
+	Enabled(level int) bool
+	// Write outputs a single, possibly multi-line, trace message.
+	Write(msg string)
+}
+
+// klogSink is the default Sink, which writes to the global klog state.
+type klogSink struct{}
+
+func (klogSink) Enabled(level int) bool {
+	return klogV(klog.Level(level))
+}
+
+func (klogSink) Write(msg string) {
+	klog.Info(msg)
+}
+
+// sinkHolder wraps a Sink so that it can be stored in an atomic.Value, which
+// requires a consistent concrete type.
+type sinkHolder struct {
+	sink Sink
+}
+
+var defaultSink atomic.Value
+
+func init() {
+	defaultSink.Store(sinkHolder{sink: klogSink{}})
+}
+
+// SetDefaultSink sets the Sink used by traces that have none set with
+// WithSink. Passing nil restores the default, which writes to klog.
+func SetDefaultSink(sink Sink) {
+	if sink == nil {
+		sink = klogSink{}
+	}
+	defaultSink.Store(sinkHolder{sink: sink})
+}
+
+// WithSink sets the Sink that the trace is written to. Only the sink of the
+// trace on which Log or LogIfLong is called matters: nested traces are
+// written along with it. It returns the trace to allow chaining with New.
+func (t *Trace) WithSink(sink Sink) *Trace {
+	if t.unsampled {
+		return t
+	}
+	t.lock.Lock()
+	t.sink = sink
+	t.lock.Unlock()
+	return t
+}
+
+// getSink returns the Sink to write t to. It must be called with t.lock held.
+func (t *Trace) getSink() Sink {
+	if t.sink != nil {
+		return t.sink
+	}
+	return defaultSink.Load().(sinkHolder).sink
+}
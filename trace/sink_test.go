@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// bufferSink is a Sink that collects messages up to a given verbosity level.
+type bufferSink struct {
+	verbosity int
+	msgs      []string
+}
+
+func (s *bufferSink) Enabled(level int) bool {
+	return level <= s.verbosity
+}
+
+func (s *bufferSink) Write(msg string) {
+	s.msgs = append(s.msgs, msg)
+}
+
+func TestWithSink(t *testing.T) {
+	tests := []struct {
+		name              string
+		verbosity         int
+		expectedMessages  int
+		expectedToContain []string
+		notExpected       []string
+	}{
+		{
+			name:             "Sink disabled",
+			verbosity:        1,
+			expectedMessages: 0,
+		},
+		{
+			name:              "Sink enabled, fast steps hidden",
+			verbosity:         2,
+			expectedMessages:  1,
+			expectedToContain: []string{`"sink test"`, `"slow step"`},
+			notExpected:       []string{`"fast step"`},
+		},
+		{
+			name:              "Sink at verbosity 4 shows all steps",
+			verbosity:         4,
+			expectedMessages:  1,
+			expectedToContain: []string{`"sink test"`, `"slow step"`, `"fast step"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var klogBuf bytes.Buffer
+			klog.SetOutput(&klogBuf)
+
+			sink := &bufferSink{verbosity: tt.verbosity}
+			trace := New("sink test").WithSink(sink)
+			trace.Step("fast step")
+			time.Sleep(20 * time.Millisecond)
+			trace.Step("slow step")
+			trace.LogIfLong(10 * time.Millisecond)
+
+			if len(sink.msgs) != tt.expectedMessages {
+				t.Fatalf("expected %d messages, got %d: %v", tt.expectedMessages, len(sink.msgs), sink.msgs)
+			}
+			for _, msg := range tt.expectedToContain {
+				if !strings.Contains(sink.msgs[0], msg) {
+					t.Errorf("expected output to contain %s, got:\n%s", msg, sink.msgs[0])
+				}
+			}
+			for _, msg := range tt.notExpected {
+				if strings.Contains(sink.msgs[0], msg) {
+					t.Errorf("expected output not to contain %s, got:\n%s", msg, sink.msgs[0])
+				}
+			}
+			if klogBuf.Len() != 0 {
+				t.Errorf("expected nothing to be written to klog, got:\n%s", klogBuf.String())
+			}
+		})
+	}
+}
+
+func TestSetDefaultSink(t *testing.T) {
+	var klogBuf bytes.Buffer
+	klog.SetOutput(&klogBuf)
+
+	sink := &bufferSink{verbosity: 2}
+	SetDefaultSink(sink)
+	defer SetDefaultSink(nil)
+
+	trace := New("default sink test")
+	nested := trace.Nest("nested")
+	nested.Step("nested step")
+	nested.Log()
+	trace.Log()
+	if len(sink.msgs) != 1 || !strings.Contains(sink.msgs[0], `"nested step"`) {
+		t.Errorf("expected one message containing the nested step, got %v", sink.msgs)
+	}
+	if klogBuf.Len() != 0 {
+		t.Errorf("expected nothing to be written to klog, got:\n%s", klogBuf.String())
+	}
+
+	// A per-trace sink takes precedence over the default.
+	perTrace := &bufferSink{verbosity: 2}
+	New("per-trace sink test").WithSink(perTrace).Log()
+	if len(perTrace.msgs) != 1 || len(sink.msgs) != 1 {
+		t.Errorf("expected the per-trace sink to be used, got %v and %v", perTrace.msgs, sink.msgs)
+	}
+
+	SetDefaultSink(nil)
+	New("klog test").Log()
+	if !strings.Contains(klogBuf.String(), `"klog test"`) {
+		t.Errorf("expected klog output after restoring the default sink, got:\n%s", klogBuf.String())
+	}
+}
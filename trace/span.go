@@ -0,0 +1,46 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+// Span receives the steps and nested traces of a Trace as they are recorded,
+// so that they can also be reported to a distributed tracing system such as
+// OpenTelemetry. This package does not depend on any tracing library: callers
+// provide a small adapter, typically around the span already present in the
+// request context, and attach it with WithSpan. The klog output of the Trace
+// is unaffected.
+type Span interface {
+	// AddEvent is called when a step is recorded on the trace.
+	AddEvent(msg string, fields ...Field)
+	// Nest is called when a nested trace is created, and returns the Span
+	// that receives the nested trace's steps.
+	Nest(name string, fields ...Field) Span
+	// End is called when the trace is completed by Log or LogIfLong.
+	End()
+}
+
+// WithSpan attaches span to the trace, so that steps and nested traces
+// recorded from now on are also reported to it. Nested traces get their spans
+// from span.Nest. It returns the trace to allow chaining with New.
+func (t *Trace) WithSpan(span Span) *Trace {
+	if t.unsampled {
+		return t
+	}
+	t.lock.Lock()
+	t.span = span
+	t.lock.Unlock()
+	return t
+}
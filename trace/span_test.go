@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// recordingSpan is a Span that records the calls made to it, prefixed with
+// the name of the span.
+type recordingSpan struct {
+	name   string
+	events *[]string
+}
+
+func (s *recordingSpan) record(format string, args ...interface{}) {
+	*s.events = append(*s.events, s.name+": "+fmt.Sprintf(format, args...))
+}
+
+func fieldsString(fields []Field) string {
+	var b bytes.Buffer
+	writeFields(&b, fields)
+	return "{" + b.String() + "}"
+}
+
+func (s *recordingSpan) AddEvent(msg string, fields ...Field) {
+	s.record("event %q %s", msg, fieldsString(fields))
+}
+
+func (s *recordingSpan) Nest(name string, fields ...Field) Span {
+	s.record("nest %q %s", name, fieldsString(fields))
+	return &recordingSpan{name: s.name + "/" + name, events: s.events}
+}
+
+func (s *recordingSpan) End() {
+	s.record("end")
+}
+
+func TestWithSpan(t *testing.T) {
+	var events []string
+	trace := New("root").WithSpan(&recordingSpan{name: "root", events: &events})
+	trace.Step("step1", Field{Key: "a", Value: 1})
+	nested := trace.Nest("inner", Field{Key: "b", Value: "x"})
+	nested.Step("step2")
+	nested.Log()
+	trace.Step("step3")
+	trace.Log()
+
+	expected := []string{
+		`root: event "step1" {a:1}`,
+		`root: nest "inner" {b:x}`,
+		`root/inner: event "step2" {}`,
+		`root/inner: end`,
+		`root: event "step3" {}`,
+		`root: end`,
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("expected span calls:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(events, "\n"))
+	}
+}
+
+func TestWithoutSpan(t *testing.T) {
+	trace := New("root")
+	nested := trace.Nest("inner")
+	if nested.span != nil {
+		t.Errorf("expected nested trace of a trace without a span to have no span, got %v", nested.span)
+	}
+	nested.Step("step")
+	trace.Log()
+}
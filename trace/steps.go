@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"time"
+)
+
+// StepInfo describes a step or nested trace recorded on a Trace.
+type StepInfo struct {
+	// Msg is the message of the step, or the name of the nested trace.
+	Msg string
+	// Fields are the fields of the step or nested trace.
+	Fields []Field
+	// Start is when the step started, which unless it was recorded with
+	// StepFunc is when the preceding step ended.
+	Start time.Time
+	// Duration is how long the step took. It is 0 for nested traces that
+	// have not been completed with Log or LogIfLong.
+	Duration time.Duration
+	// Nested is the nested trace, or nil if this is a step.
+	Nested *Trace
+}
+
+// Name returns the name of the trace.
+func (t *Trace) Name() string {
+	return t.name
+}
+
+// Steps returns the steps and nested traces recorded on the trace so far, in the order they
+// were recorded. The steps of nested traces can be obtained by calling Steps on them. Steps is
+// intended for tests and for analyzing traces programmatically.
+func (t *Trace) Steps() []StepInfo {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	steps := make([]StepInfo, 0, len(t.traceItems))
+	lastStepTime := t.startTime
+	for _, stepOrTrace := range t.traceItems {
+		stepOrTrace.rLock()
+		switch s := stepOrTrace.(type) {
+		case traceStep:
+			start := s.start(lastStepTime)
+			steps = append(steps, StepInfo{
+				Msg:      s.msg,
+				Fields:   s.fields,
+				Start:    start,
+				Duration: s.stepTime.Sub(start),
+			})
+		case *Trace:
+			step := StepInfo{
+				Msg:    s.name,
+				Fields: s.fields,
+				Start:  s.startTime,
+				Nested: s,
+			}
+			if s.endTime != nil {
+				step.Duration = s.endTime.Sub(s.startTime)
+			}
+			steps = append(steps, step)
+		}
+		lastStepTime = stepOrTrace.time()
+		stepOrTrace.rUnlock()
+	}
+	return steps
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSteps(t *testing.T) {
+	start := time.Now()
+	nestedEnd := start.Add(150 * time.Millisecond)
+	trace := &Trace{name: "root", startTime: start}
+	nested := &Trace{
+		name:        "nested",
+		fields:      []Field{{Key: "b", Value: 2}},
+		startTime:   start.Add(100 * time.Millisecond),
+		endTime:     &nestedEnd,
+		parentTrace: trace,
+		traceItems: []traceItem{
+			traceStep{stepTime: start.Add(120 * time.Millisecond), msg: "inner"},
+		},
+	}
+	incomplete := &Trace{name: "incomplete", startTime: start.Add(200 * time.Millisecond), parentTrace: trace}
+	trace.traceItems = []traceItem{
+		traceStep{stepTime: start.Add(100 * time.Millisecond), msg: "step1", fields: []Field{{Key: "a", Value: 1}}},
+		nested,
+		traceStep{startTime: start.Add(180 * time.Millisecond), stepTime: start.Add(200 * time.Millisecond), msg: "func step"},
+		incomplete,
+	}
+
+	expected := []StepInfo{
+		{Msg: "step1", Fields: []Field{{Key: "a", Value: 1}}, Start: start, Duration: 100 * time.Millisecond},
+		{Msg: "nested", Fields: []Field{{Key: "b", Value: 2}}, Start: start.Add(100 * time.Millisecond), Duration: 50 * time.Millisecond, Nested: nested},
+		{Msg: "func step", Start: start.Add(180 * time.Millisecond), Duration: 20 * time.Millisecond},
+		{Msg: "incomplete", Start: start.Add(200 * time.Millisecond), Nested: incomplete},
+	}
+	if got := trace.Steps(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected steps:\n%+v\ngot:\n%+v", expected, got)
+	}
+
+	expectedNested := []StepInfo{
+		{Msg: "inner", Start: start.Add(100 * time.Millisecond), Duration: 20 * time.Millisecond},
+	}
+	if got := nested.Steps(); !reflect.DeepEqual(got, expectedNested) {
+		t.Errorf("expected nested steps:\n%+v\ngot:\n%+v", expectedNested, got)
+	}
+	if nested.Name() != "nested" {
+		t.Errorf("expected name %q, got %q", "nested", nested.Name())
+	}
+}
+
+func TestStepsEmpty(t *testing.T) {
+	if steps := New("empty").Steps(); len(steps) != 0 {
+		t.Errorf("expected no steps, got %v", steps)
+	}
+	if steps := NewSampled("unsampled", 0).Steps(); len(steps) != 0 {
+		t.Errorf("expected no steps for an unsampled trace, got %v", steps)
+	}
+}
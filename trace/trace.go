@@ -73,15 +73,18 @@ type traceItem interface {
 	// time returns when the trace was recorded as completed.
 	time() time.Time
 	// writeItem outputs the traceItem to the buffer. If stepThreshold is non-nil, only output the
-	// traceItem if its the duration exceeds the stepThreshold.
+	// traceItem if its the duration exceeds the stepThreshold, or if sink is enabled at level 4.
 	// Each line of output is prefixed by formatter to visually indent nested items.
-	writeItem(b *bytes.Buffer, formatter string, startTime time.Time, stepThreshold *time.Duration)
+	writeItem(b *bytes.Buffer, sink Sink, formatter string, startTime time.Time, stepThreshold *time.Duration)
 }
 
 type traceStep struct {
-	stepTime time.Time
-	msg      string
-	fields   []Field
+	// startTime is only set for steps recorded with StepFunc. Other steps
+	// start when the preceding item ended.
+	startTime time.Time
+	stepTime  time.Time
+	msg       string
+	fields    []Field
 }
 
 // rLock doesn't need to do anything because traceStep instances are immutable.
@@ -92,27 +95,51 @@ func (s traceStep) time() time.Time {
 	return s.stepTime
 }
 
-func (s traceStep) writeItem(b *bytes.Buffer, formatter string, startTime time.Time, stepThreshold *time.Duration) {
-	stepDuration := s.stepTime.Sub(startTime)
-	if stepThreshold == nil || *stepThreshold == 0 || stepDuration >= *stepThreshold || klogV(4) {
+// start returns when the step started, given the time the preceding item ended.
+func (s traceStep) start(lastStepTime time.Time) time.Time {
+	if !s.startTime.IsZero() {
+		return s.startTime
+	}
+	return lastStepTime
+}
+
+func (s traceStep) writeItem(b *bytes.Buffer, sink Sink, formatter string, startTime time.Time, stepThreshold *time.Duration) {
+	stepDuration := s.stepTime.Sub(s.start(startTime))
+	if stepThreshold == nil || *stepThreshold == 0 || stepDuration >= *stepThreshold || sink.Enabled(4) {
 		b.WriteString(fmt.Sprintf("%s---", formatter))
 		writeTraceItemSummary(b, s.msg, stepDuration, s.stepTime, s.fields)
 	}
 }
 
 // Trace keeps track of a set of "steps" and allows us to log a specific
-// step if it took longer than its share of the total allowed time
+// step if it took longer than its share of the total allowed time.
+// A Trace is safe for concurrent use: steps may be recorded and nested traces
+// created from multiple goroutines, including while the trace is being logged.
+// Steps recorded concurrently are ordered by when they acquire the trace's lock.
 type Trace struct {
 	// constant fields
 	name        string
 	fields      []Field
 	startTime   time.Time
 	parentTrace *Trace
+	// unsampled is set on the trace returned by NewSampled when the trace
+	// is not sampled, which makes all of its methods no-ops.
+	unsampled bool
 	// fields guarded by a lock
 	lock       sync.RWMutex
 	threshold  *time.Duration
 	endTime    *time.Time
 	traceItems []traceItem
+	span       Span
+	sink       Sink
+	observer   Observer
+	ctx        context.Context
+	ctxErr     error
+	ctxErrStep string
+	// maxSteps is the maximum length of traceItems, or 0 if it is unbounded.
+	maxSteps     int
+	droppedSteps int
+	err          error
 }
 
 func (t *Trace) rLock() {
@@ -130,21 +157,27 @@ func (t *Trace) time() time.Time {
 	return t.startTime // if the trace is incomplete, don't assume an end time
 }
 
-func (t *Trace) writeItem(b *bytes.Buffer, formatter string, startTime time.Time, stepThreshold *time.Duration) {
-	if t.durationIsWithinThreshold() || klogV(4) {
+func (t *Trace) writeItem(b *bytes.Buffer, sink Sink, formatter string, startTime time.Time, stepThreshold *time.Duration) {
+	if t.durationIsWithinThreshold() || sink.Enabled(4) {
 		b.WriteString(fmt.Sprintf("%v[", formatter))
 		writeTraceItemSummary(b, t.name, t.TotalTime(), t.startTime, t.fields)
-		if st := t.calculateStepThreshold(); st != nil {
+		if t.err != nil {
+			// all steps of a failed trace are of interest
+			stepThreshold = nil
+		} else if st := t.calculateStepThreshold(); st != nil {
 			stepThreshold = st
 		}
-		t.writeTraceSteps(b, formatter+" ", stepThreshold)
+		t.writeTraceSteps(b, sink, formatter+" ", stepThreshold)
+		t.writeDroppedSteps(b, formatter+" ")
+		t.writeErr(b, formatter+" ")
+		t.writeContextErr(b, formatter+" ")
 		b.WriteString("]")
 		return
 	}
 	// If the trace should not be written, still check for nested traces that should be written
 	for _, s := range t.traceItems {
 		if nestedTrace, ok := s.(*Trace); ok {
-			nestedTrace.writeItem(b, formatter, startTime, stepThreshold)
+			nestedTrace.writeItem(b, sink, formatter, startTime, stepThreshold)
 		}
 	}
 }
@@ -155,17 +188,136 @@ func New(name string, fields ...Field) *Trace {
 	return &Trace{name: name, startTime: time.Now(), fields: fields}
 }
 
+// unsampledTrace is returned by NewSampled for traces that are not sampled. It is never modified.
+var unsampledTrace = &Trace{unsampled: true}
+
+// NewSampled is like New, but only creates a real Trace for the given fraction of calls, where a
+// rate of 0 or less never traces and a rate of 1 or more always does. Otherwise it returns a shared
+// trace on which Step, Nest, Log and all other methods are no-ops that do not allocate, so that
+// high QPS paths only pay for tracing a fraction of requests. Nest on such a trace returns the
+// receiver, so nested traces are not sampled either.
+func NewSampled(name string, rate float64, fields ...Field) *Trace {
+	if rate <= 0 || (rate < 1 && rand.Float64() >= rate) {
+		return unsampledTrace
+	}
+	return New(name, fields...)
+}
+
 // Step adds a new step with a specific message. Call this at the end of an execution step to record
 // how long it took. The Fields add key value pairs to provide additional details about the trace
 // step.
 func (t *Trace) Step(msg string, fields ...Field) {
+	if t.unsampled {
+		return
+	}
+	t.addStep(traceStep{stepTime: time.Now(), msg: msg, fields: fields}, nil)
+}
+
+// StepFunc calls fn and records a step with the given message and fields once it returns, with
+// a duration covering only the call to fn. If fn returns an error, it is attached to the step
+// and the trace as with StepWithError. The error from fn is returned.
+func (t *Trace) StepFunc(msg string, fn func() error, fields ...Field) error {
+	if t.unsampled {
+		return fn()
+	}
+	startTime := time.Now()
+	err := fn()
+	t.addStep(traceStep{startTime: startTime, stepTime: time.Now(), msg: msg, fields: fields}, err)
+	return err
+}
+
+// StepWithError is like Step, but if err is non-nil it is attached to the step as the "err" field
+// and recorded as the error of the trace as with SetError, unless one was already set.
+func (t *Trace) StepWithError(msg string, err error, fields ...Field) {
+	if t.unsampled {
+		return
+	}
+	t.addStep(traceStep{stepTime: time.Now(), msg: msg, fields: fields}, err)
+}
+
+// SetError records err as the error the traced operation ended in. A trace with an error is
+// logged by Log and LogIfLong even if it took less than the threshold, along with all of its
+// steps, since failures that complete quickly are otherwise invisible. Passing nil clears it.
+func (t *Trace) SetError(err error) {
+	if t.unsampled {
+		return
+	}
+	t.lock.Lock()
+	t.err = err
+	t.lock.Unlock()
+}
+
+// Err returns the error recorded with SetError, StepWithError or StepFunc, if any.
+func (t *Trace) Err() error {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.err
+}
+
+// writeErr writes the error of the trace, if any, on its own line prefixed by formatter. It must
+// be called with t.lock held for reading.
+func (t *Trace) writeErr(b *bytes.Buffer, formatter string) {
+	if t.err != nil {
+		b.WriteString(fmt.Sprintf("%s---error: %v", formatter, t.err))
+	}
+}
+
+// addStep records step, attaching err to it and to the trace if it is non-nil.
+func (t *Trace) addStep(step traceStep, err error) {
+	if err != nil {
+		step.fields = append(step.fields[:len(step.fields):len(step.fields)], Field{Key: "err", Value: err})
+	}
 	t.lock.Lock()
-	defer t.lock.Unlock()
+	if err != nil && t.err == nil {
+		t.err = err
+	}
 	if t.traceItems == nil {
 		// traces almost always have less than 6 steps, do this to avoid more than a single allocation
 		t.traceItems = make([]traceItem, 0, 6)
 	}
-	t.traceItems = append(t.traceItems, traceStep{stepTime: time.Now(), msg: msg, fields: fields})
+	t.appendItem(step)
+	t.checkContext(step.msg)
+	span := t.span
+	t.lock.Unlock()
+	if span != nil {
+		span.AddEvent(step.msg, step.fields...)
+	}
+}
+
+// appendItem adds item to the trace, unless the trace already holds maxSteps items, in which
+// case it is counted as dropped. It must be called with t.lock held.
+func (t *Trace) appendItem(item traceItem) {
+	if t.maxSteps > 0 && len(t.traceItems) >= t.maxSteps {
+		t.droppedSteps++
+		return
+	}
+	t.traceItems = append(t.traceItems, item)
+}
+
+// WithMaxSteps bounds the number of steps and nested traces that the trace holds to max, so
+// that it can be used for long running operations without growing without bound. Once the
+// limit is reached, further steps and nested traces are dropped, and the number dropped is
+// included in the output. Nested traces created afterwards inherit the limit. A max of 0 or
+// less removes the limit. It returns the trace to allow chaining with New.
+func (t *Trace) WithMaxSteps(max int) *Trace {
+	if t.unsampled {
+		return t
+	}
+	if max < 0 {
+		max = 0
+	}
+	t.lock.Lock()
+	t.maxSteps = max
+	t.lock.Unlock()
+	return t
+}
+
+// writeDroppedSteps writes the number of dropped steps, if any, on its own line prefixed by
+// formatter. It must be called with t.lock held for reading.
+func (t *Trace) writeDroppedSteps(b *bytes.Buffer, formatter string) {
+	if t.droppedSteps > 0 {
+		b.WriteString(fmt.Sprintf("%s---%d steps dropped", formatter, t.droppedSteps))
+	}
 }
 
 // Nest adds a nested trace with the given message and fields and returns it.
@@ -173,12 +325,20 @@ func (t *Trace) Step(msg string, fields ...Field) {
 // one to call FromContext(ctx).Nest without having to check if the trace
 // in the context is nil.
 func (t *Trace) Nest(msg string, fields ...Field) *Trace {
+	if t != nil && t.unsampled {
+		return t
+	}
 	newTrace := New(msg, fields...)
 	if t != nil {
 		newTrace.parentTrace = t
 		t.lock.Lock()
-		t.traceItems = append(t.traceItems, newTrace)
+		newTrace.maxSteps = t.maxSteps
+		t.appendItem(newTrace)
+		span := t.span
 		t.lock.Unlock()
+		if span != nil {
+			newTrace.span = span.Nest(msg, fields...)
+		}
 	}
 	return newTrace
 }
@@ -187,13 +347,26 @@ func (t *Trace) Nest(msg string, fields ...Field) *Trace {
 // If the Trace is nested it is not immediately logged. Instead, it is logged when the trace it is nested within
 // is logged.
 func (t *Trace) Log() {
+	if t.unsampled {
+		return
+	}
 	endTime := time.Now()
 	t.lock.Lock()
 	t.endTime = &endTime
+	t.checkContext("")
+	span := t.span
+	sink := t.getSink()
+	observer := t.observer
 	t.lock.Unlock()
+	if span != nil {
+		span.End()
+	}
+	if t.parentTrace == nil && observer != nil {
+		t.observe(observer)
+	}
 	// an explicit logging request should dump all the steps out at the higher level
-	if t.parentTrace == nil && klogV(2) { // We don't start logging until Log or LogIfLong is called on the root trace
-		t.logTrace()
+	if t.parentTrace == nil && sink.Enabled(2) { // We don't start logging until Log or LogIfLong is called on the root trace
+		t.logTrace(sink)
 	}
 }
 
@@ -205,15 +378,22 @@ func (t *Trace) Log() {
 // If the Trace is nested it is not immediately logged. Instead, it is logged when the trace it
 // is nested within is logged.
 func (t *Trace) LogIfLong(threshold time.Duration) {
+	if t.unsampled {
+		return
+	}
+	// Storing &threshold would make the parameter escape, allocating it on every
+	// call; taking the address of a local copy instead keeps unsampled traces
+	// allocation-free.
+	stored := threshold
 	t.lock.Lock()
-	t.threshold = &threshold
+	t.threshold = &stored
 	t.lock.Unlock()
 	t.Log()
 }
 
 // logTopLevelTraces finds all traces in a hierarchy of nested traces that should be logged but do not have any
 // parents that will be logged, due to threshold limits, and logs them as top level traces.
-func (t *Trace) logTrace() {
+func (t *Trace) logTrace(sink Sink) {
 	t.lock.RLock()
 	defer t.lock.RUnlock()
 	if t.durationIsWithinThreshold() {
@@ -229,27 +409,33 @@ func (t *Trace) logTrace() {
 
 		// if any step took more than it's share of the total allowed time, it deserves a higher log level
 		buffer.WriteString(fmt.Sprintf("(%v) (total time: %vms):", t.startTime.Format("02-Jan-2006 15:04:05.000"), totalTime.Milliseconds()))
-		stepThreshold := t.calculateStepThreshold()
-		t.writeTraceSteps(&buffer, fmt.Sprintf("\nTrace[%d]: ", traceNum), stepThreshold)
+		var stepThreshold *time.Duration
+		if t.err == nil { // all steps of a failed trace are of interest
+			stepThreshold = t.calculateStepThreshold()
+		}
+		t.writeTraceSteps(&buffer, sink, fmt.Sprintf("\nTrace[%d]: ", traceNum), stepThreshold)
+		t.writeDroppedSteps(&buffer, fmt.Sprintf("\nTrace[%d]: ", traceNum))
+		t.writeErr(&buffer, fmt.Sprintf("\nTrace[%d]: ", traceNum))
+		t.writeContextErr(&buffer, fmt.Sprintf("\nTrace[%d]: ", traceNum))
 		buffer.WriteString(fmt.Sprintf("\nTrace[%d]: [%v] [%v] END\n", traceNum, t.endTime.Sub(t.startTime), totalTime))
 
-		klog.Info(buffer.String())
+		sink.Write(buffer.String())
 		return
 	}
 
 	// If the trace should not be logged, still check if nested traces should be logged
 	for _, s := range t.traceItems {
 		if nestedTrace, ok := s.(*Trace); ok {
-			nestedTrace.logTrace()
+			nestedTrace.logTrace(sink)
 		}
 	}
 }
 
-func (t *Trace) writeTraceSteps(b *bytes.Buffer, formatter string, stepThreshold *time.Duration) {
+func (t *Trace) writeTraceSteps(b *bytes.Buffer, sink Sink, formatter string, stepThreshold *time.Duration) {
 	lastStepTime := t.startTime
 	for _, stepOrTrace := range t.traceItems {
 		stepOrTrace.rLock()
-		stepOrTrace.writeItem(b, formatter, lastStepTime, stepThreshold)
+		stepOrTrace.writeItem(b, sink, formatter, lastStepTime, stepThreshold)
 		lastStepTime = stepOrTrace.time()
 		stepOrTrace.rUnlock()
 	}
@@ -259,11 +445,17 @@ func (t *Trace) durationIsWithinThreshold() bool {
 	if t.endTime == nil { // we don't assume incomplete traces meet the threshold
 		return false
 	}
+	if t.err != nil { // failed traces are always logged, however quickly they failed
+		return true
+	}
 	return t.threshold == nil || *t.threshold == 0 || t.endTime.Sub(t.startTime) >= *t.threshold
 }
 
 // TotalTime can be used to figure out how long it took since the Trace was created
 func (t *Trace) TotalTime() time.Duration {
+	if t.unsampled {
+		return 0
+	}
 	return time.Since(t.startTime)
 }
 
@@ -19,9 +19,12 @@ package trace
 import (
 	"bytes"
 	"context"
+	"errors"
 	"flag"
 	"os"
+	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -682,6 +685,340 @@ func TestContext(t *testing.T) {
 	}
 }
 
+func TestConcurrentSteps(t *testing.T) {
+	var buf bytes.Buffer
+	klog.SetOutput(&buf)
+
+	const goroutines = 10
+	const steps = 20
+	trace := New("concurrent")
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nested := trace.Nest("nested")
+			for j := 0; j < steps; j++ {
+				trace.Step("step")
+				nested.Step("nested step")
+			}
+			nested.Log()
+			trace.Log()
+		}()
+	}
+	wg.Wait()
+	trace.Log()
+
+	if got, expected := len(trace.traceItems), goroutines*(steps+1); got != expected {
+		t.Errorf("expected %d trace items, got %d", expected, got)
+	}
+	for _, item := range trace.traceItems {
+		if nested, ok := item.(*Trace); ok && len(nested.traceItems) != steps {
+			t.Errorf("expected %d steps in nested trace, got %d", steps, len(nested.traceItems))
+		}
+	}
+}
+
+func TestStepFunc(t *testing.T) {
+	testErr := errors.New("test error")
+	tests := []struct {
+		name           string
+		fields         []Field
+		err            error
+		expectedFields []Field
+	}{
+		{
+			name:           "Success",
+			fields:         []Field{{Key: "a", Value: 1}},
+			expectedFields: []Field{{Key: "a", Value: 1}},
+		},
+		{
+			name:           "Error",
+			fields:         []Field{{Key: "a", Value: 1}},
+			err:            testErr,
+			expectedFields: []Field{{Key: "a", Value: 1}, {Key: "err", Value: testErr}},
+		},
+		{
+			name:           "Error without fields",
+			err:            testErr,
+			expectedFields: []Field{{Key: "err", Value: testErr}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trace := New("step func")
+			// time before the call should not be attributed to the step
+			time.Sleep(20 * time.Millisecond)
+			called := false
+			err := trace.StepFunc("work", func() error {
+				called = true
+				return tt.err
+			}, tt.fields...)
+			if !called {
+				t.Errorf("expected fn to be called")
+			}
+			if err != tt.err {
+				t.Errorf("expected error %v, got %v", tt.err, err)
+			}
+			if len(trace.traceItems) != 1 {
+				t.Fatalf("expected 1 step, got %d", len(trace.traceItems))
+			}
+			step := trace.traceItems[0].(traceStep)
+			if step.msg != "work" {
+				t.Errorf("expected step message %q, got %q", "work", step.msg)
+			}
+			if d := step.stepTime.Sub(step.start(trace.startTime)); d >= 20*time.Millisecond {
+				t.Errorf("expected step duration to only cover fn, got %v", d)
+			}
+			if !reflect.DeepEqual(step.fields, tt.expectedFields) {
+				t.Errorf("expected fields %v, got %v", tt.expectedFields, step.fields)
+			}
+			if trace.Err() != tt.err {
+				t.Errorf("expected trace error %v, got %v", tt.err, trace.Err())
+			}
+		})
+	}
+
+	unsampledErr := NewSampled("unsampled", 0).StepFunc("work", func() error { return testErr })
+	if unsampledErr != testErr {
+		t.Errorf("expected unsampled trace to return the error from fn, got %v", unsampledErr)
+	}
+}
+
+func TestWithMaxSteps(t *testing.T) {
+	tests := []struct {
+		name            string
+		maxSteps        int
+		steps           int
+		expectedItems   int
+		expectedDropped int
+		expectedOutput  string
+	}{
+		{name: "Unbounded", maxSteps: 0, steps: 10, expectedItems: 10},
+		{name: "Negative is unbounded", maxSteps: -1, steps: 10, expectedItems: 10},
+		{name: "Under the limit", maxSteps: 10, steps: 5, expectedItems: 5},
+		{name: "At the limit", maxSteps: 5, steps: 5, expectedItems: 5},
+		{
+			name:            "Over the limit",
+			maxSteps:        5,
+			steps:           12,
+			expectedItems:   5,
+			expectedDropped: 7,
+			expectedOutput:  "---7 steps dropped",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &bufferSink{verbosity: 2}
+			trace := New("bounded").WithSink(sink).WithMaxSteps(tt.maxSteps)
+			for i := 0; i < tt.steps; i++ {
+				trace.Step("step")
+			}
+			trace.Log()
+
+			if len(trace.traceItems) != tt.expectedItems {
+				t.Errorf("expected %d trace items, got %d", tt.expectedItems, len(trace.traceItems))
+			}
+			if trace.droppedSteps != tt.expectedDropped {
+				t.Errorf("expected %d dropped steps, got %d", tt.expectedDropped, trace.droppedSteps)
+			}
+			if len(sink.msgs) != 1 {
+				t.Fatalf("expected 1 message, got %v", sink.msgs)
+			}
+			if tt.expectedOutput != "" && !strings.Contains(sink.msgs[0], tt.expectedOutput) {
+				t.Errorf("expected output to contain %q, got:\n%s", tt.expectedOutput, sink.msgs[0])
+			}
+			if tt.expectedOutput == "" && strings.Contains(sink.msgs[0], "dropped") {
+				t.Errorf("expected no dropped steps in output, got:\n%s", sink.msgs[0])
+			}
+		})
+	}
+}
+
+func TestWithMaxStepsNested(t *testing.T) {
+	sink := &bufferSink{verbosity: 2}
+	trace := New("bounded").WithSink(sink).WithMaxSteps(2)
+	nested := trace.Nest("nested")
+	for i := 0; i < 3; i++ {
+		nested.Step("nested step")
+	}
+	nested.Log()
+	trace.Step("step")
+	dropped := trace.Nest("dropped nested")
+	dropped.Step("dropped nested step")
+	dropped.Log()
+	trace.Log()
+
+	if len(trace.traceItems) != 2 || trace.droppedSteps != 1 {
+		t.Errorf("expected 2 items and 1 dropped, got %d items and %d dropped", len(trace.traceItems), trace.droppedSteps)
+	}
+	if len(nested.traceItems) != 2 || nested.droppedSteps != 1 {
+		t.Errorf("expected nested trace to inherit the limit, got %d items and %d dropped", len(nested.traceItems), nested.droppedSteps)
+	}
+	if len(sink.msgs) != 1 {
+		t.Fatalf("expected the dropped nested trace not to be logged separately, got %v", sink.msgs)
+	}
+	if strings.Contains(sink.msgs[0], "dropped nested step") {
+		t.Errorf("expected dropped nested trace not to be in the output, got:\n%s", sink.msgs[0])
+	}
+	if strings.Count(sink.msgs[0], "---1 steps dropped") != 2 {
+		t.Errorf("expected dropped markers for the trace and the nested trace, got:\n%s", sink.msgs[0])
+	}
+}
+
+func TestTraceError(t *testing.T) {
+	testErr := errors.New("test error")
+	tests := []struct {
+		name              string
+		run               func(trace *Trace)
+		expectLogged      bool
+		expectedErr       error
+		expectedToContain []string
+	}{
+		{
+			name: "No error, under threshold",
+			run: func(trace *Trace) {
+				trace.Step("step1")
+				trace.StepWithError("step2", nil)
+			},
+		},
+		{
+			name: "SetError, under threshold",
+			run: func(trace *Trace) {
+				trace.Step("step1")
+				trace.SetError(testErr)
+			},
+			expectLogged:      true,
+			expectedErr:       testErr,
+			expectedToContain: []string{`"step1"`, "---error: test error"},
+		},
+		{
+			name: "SetError cleared",
+			run: func(trace *Trace) {
+				trace.SetError(testErr)
+				trace.SetError(nil)
+			},
+		},
+		{
+			name: "StepWithError, under threshold",
+			run: func(trace *Trace) {
+				trace.Step("step1")
+				trace.StepWithError("step2", testErr, Field{Key: "a", Value: 1})
+				trace.StepWithError("step3", errors.New("second error"))
+			},
+			expectLogged:      true,
+			expectedErr:       testErr,
+			expectedToContain: []string{`"step1"`, `"step2" a:1,err:test error`, `"step3" err:second error`, "---error: test error"},
+		},
+		{
+			name: "Error in nested trace",
+			run: func(trace *Trace) {
+				nested := trace.Nest("nested")
+				nested.StepWithError("nested step", testErr)
+				nested.Log()
+			},
+			expectLogged:      true,
+			expectedToContain: []string{`"nested"`, `"nested step" err:test error`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &bufferSink{verbosity: 2}
+			trace := New("error test").WithSink(sink)
+			tt.run(trace)
+			trace.LogIfLong(time.Hour)
+
+			if trace.Err() != tt.expectedErr {
+				t.Errorf("expected error %v, got %v", tt.expectedErr, trace.Err())
+			}
+			if !tt.expectLogged {
+				if len(sink.msgs) != 0 {
+					t.Errorf("expected trace not to be logged, got %v", sink.msgs)
+				}
+				return
+			}
+			if len(sink.msgs) != 1 {
+				t.Fatalf("expected trace to be logged once, got %v", sink.msgs)
+			}
+			for _, s := range tt.expectedToContain {
+				if !strings.Contains(sink.msgs[0], s) {
+					t.Errorf("expected output to contain %q, got:\n%s", s, sink.msgs[0])
+				}
+			}
+		})
+	}
+}
+
+func TestNewSampled(t *testing.T) {
+	tests := []struct {
+		name            string
+		rate            float64
+		expectUnsampled bool
+	}{
+		{name: "zero rate", rate: 0, expectUnsampled: true},
+		{name: "negative rate", rate: -1, expectUnsampled: true},
+		{name: "full rate", rate: 1, expectUnsampled: false},
+		{name: "rate above one", rate: 2, expectUnsampled: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trace := NewSampled("sampled", tt.rate)
+			if trace.unsampled != tt.expectUnsampled {
+				t.Errorf("expected unsampled %v, got %v", tt.expectUnsampled, trace.unsampled)
+			}
+			if !tt.expectUnsampled && trace.name != "sampled" {
+				t.Errorf("expected trace name %q, got %q", "sampled", trace.name)
+			}
+		})
+	}
+
+	sampled := 0
+	for i := 0; i < 1000; i++ {
+		if !NewSampled("sampled", 0.5).unsampled {
+			sampled++
+		}
+	}
+	if sampled == 0 || sampled == 1000 {
+		t.Errorf("expected about half of traces to be sampled at rate 0.5, got %d of 1000", sampled)
+	}
+}
+
+func TestUnsampledTrace(t *testing.T) {
+	var buf bytes.Buffer
+	klog.SetOutput(&buf)
+
+	trace := NewSampled("unsampled", 0)
+	var events []string
+	trace = trace.WithSpan(&recordingSpan{name: "unsampled", events: &events})
+	fields := []Field{{Key: "a", Value: 1}}
+	allocs := testing.AllocsPerRun(100, func() {
+		trace.Step("step", fields...)
+		nested := trace.Nest("nested", fields...)
+		nested.Step("nested step")
+		nested.Log()
+		trace.LogIfLong(time.Millisecond)
+		trace.Log()
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations for an unsampled trace, got %v", allocs)
+	}
+	if nested := trace.Nest("nested"); nested != trace {
+		t.Errorf("expected Nest on an unsampled trace to return the receiver")
+	}
+	if len(trace.traceItems) != 0 || trace.span != nil || trace.threshold != nil {
+		t.Errorf("expected unsampled trace not to be modified, got %+v", trace)
+	}
+	if trace.TotalTime() != 0 {
+		t.Errorf("expected zero total time for an unsampled trace, got %v", trace.TotalTime())
+	}
+	if len(events) != 0 {
+		t.Errorf("expected no span events, got %v", events)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output, got:\n%s", buf.String())
+	}
+}
+
 func ExampleTrace_Step() {
 	t := New("frobber")
 